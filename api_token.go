@@ -0,0 +1,255 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	API_TOKEN_PREFIX      = "ifg_"
+	API_TOKEN_PREFIX_SHOW = 8 // chars of token kept to tell tokens apart
+)
+
+func hashAPIToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+// authenticates op api requests with "Authorization: Bearer <token>", called by OpAuth
+func apiTokenAuth(c *gin.Context, token string) {
+	apiToken, errCode, err := verifyAPIToken(token, c.Request.Method)
+	if err != nil {
+		Error(c, errCode, err.Error())
+		c.Abort()
+		return
+	}
+
+	setOpUserKey(c, apiToken.UserKey)
+	setAPITokenKey(c, apiToken.Key)
+}
+
+// error code is only meaningful when err is not nil
+func verifyAPIToken(token, method string) (*models.APIToken, int, error) {
+	memConfMux.RLock()
+	defer memConfMux.RUnlock()
+
+	apiToken := memConfAPITokensByHash[hashAPIToken(token)]
+	if apiToken == nil || apiToken.Status != models.API_TOKEN_STATUS_ACTIVE {
+		return nil, NOT_LOGIN, fmt.Errorf("api token invalid")
+	}
+	if apiToken.ExpiresUTC > 0 && apiToken.ExpiresUTC < utils.GetNowSecond() {
+		return nil, NOT_LOGIN, fmt.Errorf("api token expired")
+	}
+
+	user := memConfUsers[apiToken.UserKey]
+	if user == nil {
+		return nil, NOT_LOGIN, fmt.Errorf("user not exist")
+	}
+	if user.Status == models.USER_STATUS_INACTIVE {
+		return nil, USER_INACTIVE, fmt.Errorf(errorStr[USER_INACTIVE][1])
+	}
+
+	if apiToken.Scope == models.API_TOKEN_SCOPE_READ && method != http.MethodGet && method != http.MethodHead {
+		return nil, NOT_PERMITTED, fmt.Errorf("api token is read only")
+	}
+
+	return apiToken, 0, nil
+}
+
+// tokens can not be managed with tokens, or a leaked token could renew itself
+func apiTokenManageCheck(c *gin.Context) bool {
+	if getAPITokenKey(c) != "" {
+		Error(c, NOT_PERMITTED, "api tokens can not be managed with api token")
+		return false
+	}
+	return true
+}
+
+type apiTokensByCreatedUTC []*models.APIToken
+
+func (tokens apiTokensByCreatedUTC) Len() int      { return len(tokens) }
+func (tokens apiTokensByCreatedUTC) Swap(i, j int) { tokens[i], tokens[j] = tokens[j], tokens[i] }
+func (tokens apiTokensByCreatedUTC) Less(i, j int) bool {
+	return tokens[i].CreatedUTC > tokens[j].CreatedUTC
+}
+
+// api tokens of login user, hashes are not returned
+func GetAPITokens(c *gin.Context) {
+	userKey := getOpUserKey(c)
+	res := make([]*models.APIToken, 0)
+
+	memConfMux.RLock()
+	for _, apiToken := range memConfAPITokens {
+		if apiToken.UserKey == userKey {
+			t := *apiToken
+			t.Hash = ""
+			res = append(res, &t)
+		}
+	}
+	memConfMux.RUnlock()
+
+	sort.Sort(apiTokensByCreatedUTC(res))
+	Success(c, res)
+}
+
+func NewAPIToken(c *gin.Context) {
+	if !apiTokenManageCheck(c) {
+		return
+	}
+
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	var data struct {
+		Name       string `json:"name" binding:"required"`
+		Type       string `json:"type"`
+		Scope      string `json:"scope"`
+		ExpiresUTC int    `json:"expires_utc"`
+	}
+	if err := c.BindJSON(&data); err != nil {
+		Error(c, BAD_POST_DATA, err.Error())
+		return
+	}
+
+	if data.Type == "" {
+		data.Type = models.API_TOKEN_TYPE_PERSONAL
+	}
+	if data.Scope == "" {
+		data.Scope = models.API_TOKEN_SCOPE_ALL
+	}
+	if err := verifyAPITokenData(data.Name, data.Type, data.Scope, data.ExpiresUTC); err != nil {
+		Error(c, BAD_REQUEST, err.Error())
+		return
+	}
+
+	token := API_TOKEN_PREFIX + utils.GenerateSecret(20)
+	apiToken := &models.APIToken{
+		Key:        utils.GenerateKey(),
+		UserKey:    getOpUserKey(c),
+		Name:       data.Name,
+		Type:       data.Type,
+		Scope:      data.Scope,
+		Hash:       hashAPIToken(token),
+		Prefix:     token[:len(API_TOKEN_PREFIX)+API_TOKEN_PREFIX_SHOW],
+		ExpiresUTC: data.ExpiresUTC,
+		Status:     models.API_TOKEN_STATUS_ACTIVE,
+		CreatorKey: getOpUserKey(c),
+		CreatedUTC: utils.GetNowSecond(),
+	}
+	if _, err := updateAPIToken(apiToken, nil); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	setAuditLog(c, AUDIT_ACTION_API_TOKEN_NEW, apiToken.Key, nil, apiToken)
+
+	// the token is only shown once
+	res := map[string]interface{}{"key": apiToken.Key, "token": token}
+	if failedNodes := syncData2SlaveIfNeed(apiToken, getOpUserKey(c)); len(failedNodes) > 0 {
+		res["failed_nodes"] = failedNodes
+	}
+	Success(c, res)
+}
+
+func RevokeAPIToken(c *gin.Context) {
+	if !apiTokenManageCheck(c) {
+		return
+	}
+
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	memConfMux.RLock()
+	oldToken := memConfAPITokens[c.Param("key")]
+	memConfMux.RUnlock()
+
+	if oldToken == nil || oldToken.UserKey != getOpUserKey(c) {
+		Error(c, BAD_REQUEST, "api token not exists: "+c.Param("key"))
+		return
+	}
+	if oldToken.Status == models.API_TOKEN_STATUS_REVOKED {
+		Success(c, nil)
+		return
+	}
+
+	apiToken := *oldToken
+	apiToken.Status = models.API_TOKEN_STATUS_REVOKED
+	if _, err := updateAPIToken(&apiToken, nil); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	setAuditLog(c, AUDIT_ACTION_API_TOKEN_REVOKE, apiToken.Key, oldToken, &apiToken)
+
+	failedNodes := syncData2SlaveIfNeed(&apiToken, getOpUserKey(c))
+	if len(failedNodes) > 0 {
+		Success(c, map[string]interface{}{"failed_nodes": failedNodes})
+	} else {
+		Success(c, nil)
+	}
+}
+
+func verifyAPITokenData(name, tokenType, scope string, expiresUTC int) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("api token name required")
+	}
+	if tokenType != models.API_TOKEN_TYPE_PERSONAL && tokenType != models.API_TOKEN_TYPE_SERVICE {
+		return fmt.Errorf("unknown api token type: %s", tokenType)
+	}
+	if scope != models.API_TOKEN_SCOPE_ALL && scope != models.API_TOKEN_SCOPE_READ {
+		return fmt.Errorf("unknown api token scope: %s", scope)
+	}
+	if expiresUTC != 0 && expiresUTC <= utils.GetNowSecond() {
+		return fmt.Errorf("expires_utc must be in future")
+	}
+
+	return nil
+}
+
+func updateAPIToken(apiToken *models.APIToken, newDataVersion *models.DataVersion) (*models.APIToken, error) {
+	s := models.NewSession()
+	defer s.Close()
+	if err := s.Begin(); err != nil {
+		s.Rollback()
+		return nil, err
+	}
+
+	node := *memConfNodes[conf.ClientAddr]
+	oldToken := memConfAPITokens[apiToken.Key]
+
+	if newDataVersion == nil {
+		newDataVersion = genNewDataVersion(memConfDataVersion)
+	}
+	if err := updateNodeDataVersion(s, &node, newDataVersion); err != nil {
+		s.Rollback()
+		return nil, err
+	}
+
+	if oldToken == nil {
+		if err := models.InsertRow(s, apiToken); err != nil {
+			s.Rollback()
+			return nil, err
+		}
+	} else {
+		if err := models.UpdateDBModel(s, apiToken); err != nil {
+			s.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := s.Commit(); err != nil {
+		s.Rollback()
+		return nil, err
+	}
+
+	updateMemConf(apiToken, newDataVersion, &node)
+
+	return apiToken, nil
+}
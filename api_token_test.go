@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIToken(t *testing.T) {
+	err := _clearModelData()
+	assert.True(t, err == nil, "must correctly clear data")
+	loadAllData()
+	initNodeData()
+
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	user, err := newUserWithNewUserData(&newUserData{Name: "rahuahua", PassCode: "huahua"}, "1234567", "1234567")
+	assert.True(t, err == nil, "must correctly add new user")
+
+	assert.True(t, verifyAPITokenData("ci", models.API_TOKEN_TYPE_SERVICE, models.API_TOKEN_SCOPE_READ, 0) == nil)
+	assert.True(t, verifyAPITokenData("", models.API_TOKEN_TYPE_SERVICE, models.API_TOKEN_SCOPE_READ, 0) != nil)
+	assert.True(t, verifyAPITokenData("ci", "robot", models.API_TOKEN_SCOPE_READ, 0) != nil)
+	assert.True(t, verifyAPITokenData("ci", models.API_TOKEN_TYPE_SERVICE, "write", 0) != nil)
+	assert.True(t, verifyAPITokenData("ci", models.API_TOKEN_TYPE_SERVICE, models.API_TOKEN_SCOPE_READ, 1) != nil)
+
+	token := API_TOKEN_PREFIX + utils.GenerateSecret(20)
+	apiToken := &models.APIToken{
+		Key:     utils.GenerateKey(),
+		UserKey: user.Key,
+		Name:    "ci",
+		Type:    models.API_TOKEN_TYPE_SERVICE,
+		Scope:   models.API_TOKEN_SCOPE_READ,
+		Hash:    hashAPIToken(token),
+		Status:  models.API_TOKEN_STATUS_ACTIVE,
+	}
+	_, err = updateAPIToken(apiToken, nil)
+	assert.True(t, err == nil, "must correctly add api token")
+
+	tokens, err := models.GetAllAPITokens(nil)
+	assert.True(t, err == nil && len(tokens) == 1 && tokens[0].Hash != token, "token must be stored hashed")
+
+	verified, _, err := verifyAPIToken(token, http.MethodGet)
+	assert.True(t, err == nil && verified.UserKey == user.Key)
+	_, errCode, err := verifyAPIToken(token, http.MethodPost)
+	assert.True(t, err != nil && errCode == NOT_PERMITTED, "read only token must not write")
+	_, errCode, err = verifyAPIToken(token+"x", http.MethodGet)
+	assert.True(t, err != nil && errCode == NOT_LOGIN)
+
+	revoked := *apiToken
+	revoked.Status = models.API_TOKEN_STATUS_REVOKED
+	_, err = updateAPIToken(&revoked, nil)
+	assert.True(t, err == nil)
+	_, errCode, err = verifyAPIToken(token, http.MethodGet)
+	assert.True(t, err != nil && errCode == NOT_LOGIN, "revoked token must not be accepted")
+
+	_clearModelData()
+}
+
+func TestAPITokenTOTPEnforce(t *testing.T) {
+	err := _clearModelData()
+	assert.True(t, err == nil, "must correctly clear data")
+	loadAllData()
+	initNodeData()
+
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	user, err := newUserWithNewUserData(&newUserData{Name: "rahuahua", PassCode: "huahua"}, "1234567", "1234567")
+	assert.True(t, err == nil, "must correctly add new user")
+	admin := *user
+	admin.Role = models.USER_ROLE_ADMIN
+	_, err = updateUser(&admin, nil)
+	assert.True(t, err == nil)
+
+	token := API_TOKEN_PREFIX + utils.GenerateSecret(20)
+	_, err = updateAPIToken(&models.APIToken{
+		Key:     utils.GenerateKey(),
+		UserKey: user.Key,
+		Name:    "ci",
+		Type:    models.API_TOKEN_TYPE_PERSONAL,
+		Scope:   models.API_TOKEN_SCOPE_ALL,
+		Hash:    hashAPIToken(token),
+		Status:  models.API_TOKEN_STATUS_ACTIVE,
+	}, nil)
+	assert.True(t, err == nil, "must correctly add api token")
+
+	callOpAuth := func(path string) map[string]interface{} {
+		router := gin.New()
+		router.GET(path, OpAuth, func(c *gin.Context) { Success(c, nil) })
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		res := make(map[string]interface{})
+		json.Unmarshal(w.Body.Bytes(), &res)
+		return res
+	}
+
+	res := callOpAuth("/op/apps/all/1/10")
+	assert.True(t, res["status"] == true, res)
+
+	conf.TOTPEnforceAdmin = true
+	defer func() { conf.TOTPEnforceAdmin = false }()
+	res = callOpAuth("/op/apps/all/1/10")
+	assert.True(t, res["status"] == false && res["code"] == "totp_enroll_required", "admin without totp must not use api token")
+	res = callOpAuth("/op/user/totp/enroll")
+	assert.True(t, res["status"] == false && res["code"] == "totp_enroll_required", "totp must be enrolled in login session")
+
+	_clearModelData()
+}
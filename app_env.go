@@ -0,0 +1,377 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	PROMOTE_ACTION_NEW    = "new"
+	PROMOTE_ACTION_UPDATE = "up"
+	PROMOTE_ACTION_SAME   = "same"
+)
+
+func GetAppEnvs(c *gin.Context) {
+	memConfMux.RLock()
+	envs := memConfAppEnvs[c.Param("app_key")]
+	memConfMux.RUnlock()
+
+	if envs == nil {
+		envs = make([]*models.AppEnv, 0)
+	}
+
+	Success(c, envs)
+}
+
+func NewAppEnv(c *gin.Context) {
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	var data struct {
+		AppKey  string `json:"app_key" binding:"required"`
+		Name    string `json:"name" binding:"required"`
+		Seq     int    `json:"seq"`
+		AuxInfo string `json:"aux_info"`
+	}
+	if err := c.BindJSON(&data); err != nil {
+		Error(c, BAD_POST_DATA, err.Error())
+		return
+	}
+
+	if err := verifyAppEnvData(data.AppKey, "", data.Name); err != nil {
+		Error(c, BAD_REQUEST, err.Error())
+		return
+	}
+
+	env := &models.AppEnv{
+		Key:        utils.GenerateKey(),
+		AppKey:     data.AppKey,
+		Name:       data.Name,
+		Seq:        data.Seq,
+		DataSign:   utils.GenerateKey(),
+		CreatorKey: getOpUserKey(c),
+		CreatedUTC: utils.GetNowSecond(),
+		AuxInfo:    data.AuxInfo,
+	}
+	if _, err := updateAppEnv(env, nil); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	failedNodes := syncData2SlaveIfNeed(env, getOpUserKey(c))
+	if len(failedNodes) > 0 {
+		Success(c, map[string]interface{}{"failed_nodes": failedNodes})
+	} else {
+		Success(c, nil)
+	}
+}
+
+func UpdateAppEnv(c *gin.Context) {
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	var data struct {
+		Key     string `json:"key" binding:"required"`
+		Name    string `json:"name" binding:"required"`
+		Seq     int    `json:"seq"`
+		AuxInfo string `json:"aux_info"`
+	}
+	if err := c.BindJSON(&data); err != nil {
+		Error(c, BAD_POST_DATA, err.Error())
+		return
+	}
+
+	oldEnv := memConfEnvs[data.Key]
+	if oldEnv == nil {
+		Error(c, BAD_REQUEST, "app env not exists: "+data.Key)
+		return
+	}
+
+	if oldEnv.Name == data.Name && oldEnv.Seq == data.Seq && oldEnv.AuxInfo == data.AuxInfo {
+		Success(c, nil)
+		return
+	}
+
+	if err := verifyAppEnvData(oldEnv.AppKey, oldEnv.Key, data.Name); err != nil {
+		Error(c, BAD_REQUEST, err.Error())
+		return
+	}
+
+	env := *oldEnv
+	env.Name = data.Name
+	env.Seq = data.Seq
+	env.AuxInfo = data.AuxInfo
+	if _, err := updateAppEnv(&env, nil); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	failedNodes := syncData2SlaveIfNeed(&env, getOpUserKey(c))
+	if len(failedNodes) > 0 {
+		Success(c, map[string]interface{}{"failed_nodes": failedNodes})
+	} else {
+		Success(c, nil)
+	}
+}
+
+func verifyAppEnvData(appKey, envKey, name string) error {
+	app := memConfApps[appKey]
+	if app == nil {
+		return fmt.Errorf("app key not exists: " + appKey)
+	}
+	if app.Type == models.APP_TYPE_TEMPLATE {
+		return fmt.Errorf("template app can not have env")
+	}
+
+	for _, env := range memConfAppEnvs[appKey] {
+		if env.Name == name && env.Key != envKey {
+			return fmt.Errorf("app env already exists: " + name)
+		}
+	}
+
+	return nil
+}
+
+func updateAppEnv(env *models.AppEnv, newDataVersion *models.DataVersion) (*models.AppEnv, error) {
+	s := models.NewSession()
+	defer s.Close()
+	if err := s.Begin(); err != nil {
+		s.Rollback()
+		return nil, err
+	}
+
+	node := *memConfNodes[conf.ClientAddr]
+	oldEnv := memConfEnvs[env.Key]
+
+	if newDataVersion == nil {
+		newDataVersion = genNewDataVersion(memConfDataVersion)
+	}
+	if err := updateNodeDataVersion(s, &node, newDataVersion); err != nil {
+		s.Rollback()
+		return nil, err
+	}
+
+	if oldEnv == nil {
+		if err := models.InsertRow(s, env); err != nil {
+			s.Rollback()
+			return nil, err
+		}
+	} else {
+		if err := models.UpdateDBModel(s, env); err != nil {
+			s.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := s.Commit(); err != nil {
+		s.Rollback()
+		return nil, err
+	}
+
+	updateMemConf(env, newDataVersion, &node)
+
+	return env, nil
+}
+
+// env promoted to from the given env, empty string for app's default env
+func getNextAppEnvKey(appKey, envKey string) (string, error) {
+	envs := memConfAppEnvs[appKey]
+	for ix, env := range envs {
+		if env.Key != envKey {
+			continue
+		}
+		if ix == len(envs)-1 {
+			return "", nil
+		}
+		return envs[ix+1].Key, nil
+	}
+
+	return "", fmt.Errorf("app env not exists: " + envKey)
+}
+
+func getRawConfigByK(configsKey, k string) *models.Config {
+	for _, config := range memConfAppConfigs[configsKey] {
+		if config.K == k {
+			return memConfRawConfigs[config.Key]
+		}
+	}
+
+	return nil
+}
+
+type promoteDiff struct {
+	K        string `json:"k"`
+	Action   string `json:"action"`
+	OldV     string `json:"old_v"`
+	OldVType string `json:"old_v_type"`
+	NewV     string `json:"new_v"`
+	NewVType string `json:"new_v_type"`
+}
+
+func genPromoteConfigs(appKey, fromEnv, toEnv string, keys []string, batchId, userKey string) ([]*promoteDiff, []*models.Config, error) {
+	toConfigsKey := toEnv
+	if toEnv == "" {
+		toConfigsKey = appKey
+	}
+
+	diffs := make([]*promoteDiff, 0)
+	configs := make([]*models.Config, 0)
+	seen := map[string]bool{}
+	for _, k := range keys {
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+
+		from := getRawConfigByK(fromEnv, k)
+		if from == nil {
+			return nil, nil, fmt.Errorf("config [%s] not exists in env", k)
+		}
+
+		diff := &promoteDiff{
+			K:        k,
+			NewV:     from.V,
+			NewVType: from.VType,
+		}
+		diffs = append(diffs, diff)
+
+		var config models.Config
+		if to := getRawConfigByK(toConfigsKey, k); to == nil {
+			diff.Action = PROMOTE_ACTION_NEW
+			config = *from
+			config.Key = utils.GenerateKey()
+			config.Env = toEnv
+			config.CreatorKey = userKey
+			config.CreatedUTC = utils.GetNowSecond()
+			config.UpdateTimes = 0
+			config.LastUpdateId = ""
+		} else {
+			diff.OldV = to.V
+			diff.OldVType = to.VType
+			if to.V == from.V && to.VType == from.VType && to.Des == from.Des && to.Status == from.Status && to.Schema == from.Schema {
+				diff.Action = PROMOTE_ACTION_SAME
+				continue
+			}
+			diff.Action = PROMOTE_ACTION_UPDATE
+			config = *to
+			config.V = from.V
+			config.VType = from.VType
+			config.Des = from.Des
+			config.Status = from.Status
+			config.Schema = from.Schema
+		}
+		config.UpdateBatchId = batchId
+		config.UpdateMessage, config.UpdateRef = "", ""
+		configs = append(configs, &config)
+	}
+
+	return diffs, configs, nil
+}
+
+func PromoteAppEnvConfigs(c *gin.Context) {
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	var data struct {
+		AppKey  string   `json:"app_key" binding:"required"`
+		FromEnv string   `json:"from_env" binding:"required"`
+		Keys    []string `json:"keys" binding:"required"`
+		DryRun  bool     `json:"dry_run"`
+	}
+	if err := c.BindJSON(&data); err != nil {
+		Error(c, BAD_POST_DATA, err.Error())
+		return
+	}
+
+	toEnv, err := getNextAppEnvKey(data.AppKey, data.FromEnv)
+	if err != nil {
+		Error(c, BAD_REQUEST, err.Error())
+		return
+	}
+
+	batchId := utils.GenerateKey()
+	diffs, configs, err := genPromoteConfigs(data.AppKey, data.FromEnv, toEnv, data.Keys, batchId, getOpUserKey(c))
+	if err != nil {
+		Error(c, BAD_REQUEST, err.Error())
+		return
+	}
+
+	res := map[string]interface{}{
+		"to_env": toEnv,
+		"diffs":  diffs,
+	}
+	if data.DryRun || len(configs) == 0 {
+		Success(c, res)
+		return
+	}
+
+	var oldConfigs []*models.Config
+	for _, config := range configs {
+		if oldConfig := memConfRawConfigs[config.Key]; oldConfig != nil {
+			oldConfigs = append(oldConfigs, oldConfig)
+		}
+	}
+	if err = promoteConfigs(data.AppKey, configs, getOpUserKey(c), nil); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	setAuditLog(c, AUDIT_ACTION_CONFIG_PROMOTE, batchId, oldConfigs, configs)
+
+	res["batch_id"] = batchId
+	failedNodes := syncData2SlaveIfNeed(&promoteData{AppKey: data.AppKey, Configs: configs}, getOpUserKey(c))
+	if len(failedNodes) > 0 {
+		res["failed_nodes"] = failedNodes
+	}
+
+	Success(c, res)
+}
+
+// promoted configs are saved in one session with one data version
+func promoteConfigs(appKey string, configs []*models.Config, userKey string, newDataVersion *models.DataVersion) (err error) {
+	if newDataVersion == nil {
+		newDataVersion = genNewDataVersion(memConfDataVersion)
+	}
+
+	s := models.NewSession()
+	defer s.Close()
+	if err = s.Begin(); err != nil {
+		return
+	}
+
+	for _, config := range configs {
+		if _, err = updateConfig(config, userKey, newDataVersion, s); err != nil {
+			s.Rollback()
+			return
+		}
+	}
+
+	node, err := models.GetNodeByURL(s, conf.ClientAddr)
+	if err != nil {
+		s.Rollback()
+		return err
+	}
+
+	if err = s.Commit(); err != nil {
+		s.Rollback()
+		return
+	}
+
+	for _, config := range configs {
+		if config.Env == "" {
+			// reload app's data sign, template app has no env so there is no dependent app
+			updateMemConf(config, newDataVersion, node, []*models.App{})
+		} else {
+			updateMemConf(config, newDataVersion, node)
+		}
+	}
+
+	if conf.IsMasterNode() {
+		go triggerConfigsWebHooks(configs, newDataVersion.Version)
+	}
+
+	return
+}
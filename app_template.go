@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Instafig/Instafig/models"
+)
+
+// app keys referred by the app, as its parent or by template type configs of the app and its envs
+func getAppRefKeys(appKey string) []string {
+	var keys []string
+	if app := memConfApps[appKey]; app != nil && app.ParentKey != "" {
+		keys = append(keys, app.ParentKey)
+	}
+
+	configsKeys := []string{appKey}
+	for _, env := range memConfAppEnvs[appKey] {
+		configsKeys = append(configsKeys, env.Key)
+	}
+	for _, configsKey := range configsKeys {
+		for _, config := range memConfAppConfigs[configsKey] {
+			if config.VType == models.CONF_V_TYPE_TEMPLATE {
+				keys = append(keys, config.V.(string))
+			}
+		}
+	}
+
+	return keys
+}
+
+func isAppReachable(from, target string, visited map[string]bool) bool {
+	if from == target {
+		return true
+	}
+	if visited[from] {
+		return false
+	}
+	visited[from] = true
+
+	for _, key := range getAppRefKeys(from) {
+		if isAppReachable(key, target, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// check that app referring to the template app does not make a cycle
+func verifyAppTemplateRef(appKey, templateKey string) error {
+	template := memConfApps[templateKey]
+	if template == nil {
+		return fmt.Errorf("template not found for: " + templateKey)
+	}
+	if template.Type != models.APP_TYPE_TEMPLATE {
+		return fmt.Errorf("can not refer to a real app as template")
+	}
+	if isAppReachable(templateKey, appKey, map[string]bool{}) {
+		return fmt.Errorf("template [%s] refers back to app [%s]", template.Name, appKey)
+	}
+
+	return nil
+}
+
+// apps which refer to the template app directly or through other templates
+func getTemplateDependentApps(appKey string) []*models.App {
+	var apps []*models.App
+	visited := map[string]bool{appKey: true}
+	queue := []string{appKey}
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+		for _, _app := range memConfApps {
+			if visited[_app.Key] {
+				continue
+			}
+			for _, refKey := range getAppRefKeys(_app.Key) {
+				if refKey == key {
+					visited[_app.Key] = true
+					apps = append(apps, _app)
+					queue = append(queue, _app.Key)
+					break
+				}
+			}
+		}
+	}
+
+	return apps
+}
+
+// update data sign of the apps and their envs in db, memory data is updated by setMemConfDataSign
+func updateAppsDataSign(s *models.Session, apps []*models.App, dataSign string) error {
+	for _, app := range apps {
+		_app := *app
+		_app.DataSign = dataSign
+		if err := models.UpdateDBModel(s, &_app); err != nil {
+			return err
+		}
+
+		for _, env := range memConfAppEnvs[app.Key] {
+			_env := *env
+			_env.DataSign = dataSign
+			if err := models.UpdateDBModel(s, &_env); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
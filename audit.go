@@ -0,0 +1,378 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	AUDIT_ACTION_LOGIN                = "login"
+	AUDIT_ACTION_LOGOUT               = "logout"
+	AUDIT_ACTION_USER_INIT            = "user_init"
+	AUDIT_ACTION_USER_NEW             = "user_new"
+	AUDIT_ACTION_USER_UPDATE          = "user_update"
+	AUDIT_ACTION_USER_STATUS          = "user_status"
+	AUDIT_ACTION_USER_PASS_CODE       = "user_passcode"
+	AUDIT_ACTION_USER_PASS_CODE_RESET = "user_passcode_reset"
+	AUDIT_ACTION_USER_FREEZE_OVERRIDE = "user_freeze_override"
+	AUDIT_ACTION_APP_NEW              = "app_new"
+	AUDIT_ACTION_APP_UPDATE           = "app_update"
+	AUDIT_ACTION_APP_CLONE            = "app_clone"
+	AUDIT_ACTION_WEBHOOK_NEW          = "webhook_new"
+	AUDIT_ACTION_WEBHOOK_UPDATE       = "webhook_update"
+	AUDIT_ACTION_WEBHOOK_DELETE       = "webhook_delete"
+	AUDIT_ACTION_CONFIG_NEW           = "config_new"
+	AUDIT_ACTION_CONFIG_UPDATE        = "config_update"
+	AUDIT_ACTION_CONFIG_BATCH         = "config_batch"
+	AUDIT_ACTION_CONFIG_PROMOTE       = "config_promote"
+	AUDIT_ACTION_API_TOKEN_NEW        = "api_token_new"
+	AUDIT_ACTION_API_TOKEN_REVOKE     = "api_token_revoke"
+	AUDIT_ACTION_CONFIG_FREEZE_NEW    = "config_freeze_new"
+	AUDIT_ACTION_CONFIG_FREEZE_UPDATE = "config_freeze_update"
+	AUDIT_ACTION_CONFIG_FREEZE_CANCEL = "config_freeze_cancel"
+
+	AUDIT_TARGET_USER          = "user"
+	AUDIT_TARGET_APP           = "app"
+	AUDIT_TARGET_APP_ENV       = "app_env"
+	AUDIT_TARGET_WEBHOOK       = "webhook"
+	AUDIT_TARGET_CONFIG        = "config"
+	AUDIT_TARGET_API_TOKEN     = "api_token"
+	AUDIT_TARGET_CONFIG_FREEZE = "config_freeze"
+
+	AUDIT_HIDDEN_VALUE = "******"
+
+	// in seconds
+	AUDIT_LOG_SYNC_CHECK_INTERVAL = 10
+	AUDIT_LOG_SYNC_RETRY_BASE     = 30
+	AUDIT_LOG_SYNC_RETRY_MAX      = 3600
+
+	AUDIT_LOG_SYNC_BATCH_SIZE = 64
+)
+
+var auditLogSyncCh = make(chan interface{}, 1)
+
+func init() {
+	doEverTask(syncAuditLogsLoop)
+}
+
+// requests changing nothing, only recorded when their handlers call setAuditLog
+var auditLogSkipPaths = map[string]bool{
+	"/op/config/check":     true,
+	"/op/webhook/preview":  true,
+	"/op/user/totp/enroll": true,
+}
+
+type auditLogData struct {
+	Action     string
+	TargetType string
+	TargetKey  string
+	Before     interface{}
+	After      interface{}
+}
+
+// called by op handlers to tell what the request changes, before is nil for new targets
+func setAuditLog(c *gin.Context, action, targetKey string, before, after interface{}) {
+	data := &auditLogData{Action: action, TargetKey: targetKey}
+	if before != nil {
+		data.TargetType, data.Before = auditTarget(before)
+	}
+	if after != nil {
+		data.TargetType, data.After = auditTarget(after)
+	}
+
+	setAuditLogData(c, data)
+}
+
+// type of target and copy of it without secrets
+func auditTarget(i interface{}) (string, interface{}) {
+	switch m := i.(type) {
+	case *models.User:
+		user := *m
+		hideUserSecrets(&user)
+		return AUDIT_TARGET_USER, &user
+	case *models.App:
+		return AUDIT_TARGET_APP, m
+	case *models.AppEnv:
+		return AUDIT_TARGET_APP_ENV, m
+	case *models.Config:
+		return AUDIT_TARGET_CONFIG, m
+	case []*models.Config:
+		return AUDIT_TARGET_CONFIG, m
+	case *models.WebHook:
+		hook := *m
+		hideWebHookSecrets(&hook)
+		return AUDIT_TARGET_WEBHOOK, &hook
+	case *models.APIToken:
+		apiToken := *m
+		apiToken.Hash = ""
+		return AUDIT_TARGET_API_TOKEN, &apiToken
+	case *models.ConfigFreeze:
+		return AUDIT_TARGET_CONFIG_FREEZE, m
+	}
+
+	return "", i
+}
+
+// records op requests after they are handled, GET requests are only recorded when told by handlers
+func AuditLogHandler(c *gin.Context) {
+	c.Next()
+
+	data := getAuditLogData(c)
+	if data == nil {
+		if c.Request.Method == http.MethodGet || auditLogSkipPaths[c.Request.URL.Path] {
+			return
+		}
+		data = &auditLogData{Action: c.Request.Method + " " + c.Request.URL.Path}
+	}
+
+	if err := saveAuditLog(newAuditLog(c, data)); err != nil {
+		logger.Error(map[string]interface{}{
+			"type":  "audit_log",
+			"error": err.Error(),
+		})
+	}
+}
+
+func newAuditLog(c *gin.Context, data *auditLogData) *models.AuditLog {
+	auditLog := &models.AuditLog{
+		Id:          utils.GenerateKey(),
+		UserKey:     getOpUserKey(c),
+		APITokenKey: getAPITokenKey(c),
+		Action:      data.Action,
+		TargetType:  data.TargetType,
+		TargetKey:   data.TargetKey,
+		IP:          c.ClientIP(),
+		NodeURL:     conf.ClientAddr,
+		CreatedUTC:  utils.GetNowSecond(),
+	}
+	if !getServiceStatus(c) {
+		auditLog.ErrorCode = getServiceErrorCode(c)
+	}
+	if data.Before != nil {
+		bs, _ := json.Marshal(data.Before)
+		auditLog.Before = string(bs)
+	}
+	if data.After != nil {
+		bs, _ := json.Marshal(data.After)
+		auditLog.After = string(bs)
+	}
+
+	memConfMux.RLock()
+	if user := memConfUsers[auditLog.UserKey]; user != nil {
+		auditLog.UserName = user.Name
+	}
+	memConfMux.RUnlock()
+
+	return auditLog
+}
+
+// audit logs are not versioned data, master node pushes them to slaves and
+// slaves report theirs to master, so master always has all of them
+func saveAuditLog(auditLog *models.AuditLog) error {
+	if err := models.InsertRow(nil, auditLog); err != nil {
+		return err
+	}
+
+	if conf.IsMasterNode() {
+		queueAuditLogSync2Slaves(auditLog)
+	} else {
+		queueAuditLogSync(auditLog, "")
+	}
+
+	return nil
+}
+
+func queueAuditLogSync2Slaves(auditLog *models.AuditLog) {
+	memConfMux.RLock()
+	urls := make([]string, 0, len(memConfNodes))
+	for _, node := range memConfNodes {
+		if node.Type != models.NODE_TYPE_MASTER && node.URL != auditLog.NodeURL {
+			urls = append(urls, node.URL)
+		}
+	}
+	memConfMux.RUnlock()
+
+	for _, url := range urls {
+		queueAuditLogSync(auditLog, url)
+	}
+}
+
+// audit logs are queued in db and sent by syncAuditLogsLoop, nodeURL is empty for master node
+func queueAuditLogSync(auditLog *models.AuditLog, nodeURL string) {
+	bs, _ := json.Marshal(auditLog)
+	now := utils.GetNowSecond()
+	auditLogSync := &models.AuditLogSync{
+		Key:          utils.GenerateKey(),
+		NodeURL:      nodeURL,
+		AuditLog:     string(bs),
+		NextRetryUTC: now,
+		CreatedUTC:   now,
+	}
+	if err := models.InsertRow(nil, auditLogSync); err != nil {
+		logger.Error(map[string]interface{}{
+			"type":  "audit_log_sync",
+			"node":  nodeURL,
+			"error": err.Error(),
+		})
+		return
+	}
+
+	sendChanAsync(auditLogSyncCh, nil)
+}
+
+func sendAuditLogSync(auditLogSync *models.AuditLogSync) error {
+	auditLog := &models.AuditLog{}
+	if err := json.Unmarshal([]byte(auditLogSync.AuditLog), auditLog); err != nil {
+		return models.DeleteDBModel(nil, auditLogSync)
+	}
+
+	var err error
+	if auditLogSync.NodeURL == "" {
+		if _, err = nodeRequest(conf.MasterAddr, NODE_REQUEST_TYPE_AUDITREPORT, nodeRequestDataT{
+			Auth: nodeAuthString,
+			Data: auditLogSync.AuditLog,
+		}); err == nil {
+			// local audit log is lost if slave node resynced before reporting it
+			_, err = storeNodeAuditLog(auditLog)
+		}
+	} else {
+		memConfMux.RLock()
+		node := memConfNodes[auditLogSync.NodeURL]
+		memConfMux.RUnlock()
+		if node == nil {
+			// node removed, it gets all audit logs by resync if it comes back
+			return models.DeleteDBModel(nil, auditLogSync)
+		}
+		err = syncData2Slave(node, auditLog, nil, "")
+	}
+
+	if err == nil {
+		return models.DeleteDBModel(nil, auditLogSync)
+	}
+
+	auditLogSync.Attempts++
+	auditLogSync.Error = err.Error()
+	auditLogSync.NextRetryUTC = utils.GetNowSecond() + getAuditLogSyncRetryDelay(auditLogSync.Attempts)
+	logger.Error(map[string]interface{}{
+		"type":     "audit_log_sync",
+		"node":     auditLogSync.NodeURL,
+		"attempts": auditLogSync.Attempts,
+		"error":    err.Error(),
+	})
+
+	return models.UpdateDBModel(nil, auditLogSync)
+}
+
+// retry delay doubles with every failed attempt, audit logs are retried until sent
+func getAuditLogSyncRetryDelay(attempts int) int {
+	delay := AUDIT_LOG_SYNC_RETRY_BASE
+	for i := 1; i < attempts && delay < AUDIT_LOG_SYNC_RETRY_MAX; i++ {
+		delay *= 2
+	}
+	if delay > AUDIT_LOG_SYNC_RETRY_MAX {
+		delay = AUDIT_LOG_SYNC_RETRY_MAX
+	}
+	return delay
+}
+
+func syncAuditLogsLoop() {
+	for {
+		select {
+		case <-auditLogSyncCh:
+		case <-time.After(AUDIT_LOG_SYNC_CHECK_INTERVAL * time.Second):
+		}
+
+		auditLogSyncs, err := models.GetAuditLogSyncsToSend(nil, utils.GetNowSecond(), AUDIT_LOG_SYNC_BATCH_SIZE)
+		if err != nil {
+			continue
+		}
+		for _, auditLogSync := range auditLogSyncs {
+			sendAuditLogSync(auditLogSync)
+		}
+		if len(auditLogSyncs) == AUDIT_LOG_SYNC_BATCH_SIZE {
+			// there may be more audit logs to send
+			sendChanAsync(auditLogSyncCh, nil)
+		}
+	}
+}
+
+// stores audit log from other nodes, which may be received more than once
+func storeNodeAuditLog(auditLog *models.AuditLog) (bool, error) {
+	oldAuditLog, err := models.GetAuditLogById(nil, auditLog.Id)
+	if err != nil || oldAuditLog != nil {
+		return false, err
+	}
+
+	return true, models.InsertRow(nil, auditLog)
+}
+
+func GetAuditLogs(c *gin.Context) {
+	memConfMux.RLock()
+	opUser := memConfUsers[getOpUserKey(c)]
+	memConfMux.RUnlock()
+	if !isAdminUser(opUser) {
+		Error(c, NOT_PERMITTED, "can not get audit logs as current user is not admin")
+		return
+	}
+
+	page, err := strconv.Atoi(c.Param("page"))
+	if err != nil {
+		Error(c, BAD_REQUEST, "page not number")
+		return
+	}
+	count, err := strconv.Atoi(c.Param("count"))
+	if err != nil {
+		Error(c, BAD_REQUEST, "count not number")
+		return
+	}
+
+	filter := &models.AuditLogFilter{
+		UserKey:    c.Query("user_key"),
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+		TargetKey:  c.Query("target_key"),
+	}
+	if v := c.Query("from_utc"); v != "" {
+		if filter.FromUTC, err = strconv.Atoi(v); err != nil {
+			Error(c, BAD_REQUEST, "from_utc not number")
+			return
+		}
+	}
+	if v := c.Query("to_utc"); v != "" {
+		if filter.ToUTC, err = strconv.Atoi(v); err != nil {
+			Error(c, BAD_REQUEST, "to_utc not number")
+			return
+		}
+	}
+
+	auditLogs, err := models.GetAuditLogs(nil, filter, page, count)
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	if len(auditLogs) == 0 {
+		Success(c, map[string]interface{}{
+			"total_count": 0,
+			"list":        []interface{}{},
+		})
+		return
+	}
+
+	totalCount, err := models.GetAuditLogCount(nil, filter)
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	Success(c, map[string]interface{}{
+		"total_count": totalCount,
+		"list":        auditLogs,
+	})
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditTarget(t *testing.T) {
+	user := &models.User{Key: "u1", PassCode: "$2a$10$hash", TOTPSecret: "secret"}
+	targetType, data := auditTarget(user)
+	assert.True(t, targetType == AUDIT_TARGET_USER)
+	assert.True(t, data.(*models.User).PassCode == "" && data.(*models.User).TOTPSecret == "" && data.(*models.User).TOTPEnabled)
+	assert.True(t, user.PassCode != "" && user.TOTPSecret != "", "target itself must not be changed")
+
+	targetType, data = auditTarget(&models.WebHook{Key: "h1", AuthInfo: "name:pass", Secret: "secret"})
+	assert.True(t, targetType == AUDIT_TARGET_WEBHOOK)
+	assert.True(t, data.(*models.WebHook).AuthInfo == AUDIT_HIDDEN_VALUE && data.(*models.WebHook).Secret == AUDIT_HIDDEN_VALUE)
+
+	targetType, data = auditTarget(&models.APIToken{Key: "t1", Hash: "hash"})
+	assert.True(t, targetType == AUDIT_TARGET_API_TOKEN && data.(*models.APIToken).Hash == "")
+
+	targetType, _ = auditTarget(map[string]string{"name": "rahuahua"})
+	assert.True(t, targetType == "")
+}
+
+func TestAuditLogQuery(t *testing.T) {
+	err := _clearModelData()
+	assert.True(t, err == nil, "must correctly clear data")
+
+	now := utils.GetNowSecond()
+	auditLogs := []*models.AuditLog{
+		{Id: utils.GenerateKey(), UserKey: "u1", Action: AUDIT_ACTION_LOGIN, CreatedUTC: now - 100},
+		{Id: utils.GenerateKey(), UserKey: "u1", Action: AUDIT_ACTION_APP_NEW, TargetType: AUDIT_TARGET_APP, TargetKey: "a1", CreatedUTC: now - 50},
+		{Id: utils.GenerateKey(), UserKey: "u2", Action: AUDIT_ACTION_APP_UPDATE, TargetType: AUDIT_TARGET_APP, TargetKey: "a1", CreatedUTC: now},
+	}
+	for _, auditLog := range auditLogs {
+		isNew, err := storeNodeAuditLog(auditLog)
+		assert.True(t, err == nil && isNew, "must correctly store audit log")
+	}
+	isNew, err := storeNodeAuditLog(auditLogs[0])
+	assert.True(t, err == nil && !isNew, "audit log received again must be ignored")
+
+	res, err := models.GetAuditLogs(nil, &models.AuditLogFilter{}, 1, 2)
+	assert.True(t, err == nil && len(res) == 2 && res[0].Id == auditLogs[2].Id, "latest audit logs come first")
+	count, err := models.GetAuditLogCount(nil, &models.AuditLogFilter{})
+	assert.True(t, err == nil && count == 3)
+
+	count, _ = models.GetAuditLogCount(nil, &models.AuditLogFilter{UserKey: "u1"})
+	assert.True(t, count == 2)
+	count, _ = models.GetAuditLogCount(nil, &models.AuditLogFilter{TargetType: AUDIT_TARGET_APP, TargetKey: "a1"})
+	assert.True(t, count == 2)
+	res, _ = models.GetAuditLogs(nil, &models.AuditLogFilter{Action: AUDIT_ACTION_LOGIN}, 1, 10)
+	assert.True(t, len(res) == 1 && res[0].Id == auditLogs[0].Id)
+	count, _ = models.GetAuditLogCount(nil, &models.AuditLogFilter{FromUTC: now - 60, ToUTC: now - 10})
+	assert.True(t, count == 1)
+
+	_clearModelData()
+}
+
+func TestAuditLogSync(t *testing.T) {
+	bs, _ := json.Marshal(&models.AuditLog{Id: utils.GenerateKey(), Action: AUDIT_ACTION_LOGIN})
+	auditLogSync := &models.AuditLogSync{
+		Key:          utils.GenerateKey(),
+		NodeURL:      "removed-node:17070",
+		AuditLog:     string(bs),
+		NextRetryUTC: utils.GetNowSecond() + 3600, // not picked by syncAuditLogsLoop
+	}
+	err := models.InsertRow(nil, auditLogSync)
+	assert.True(t, err == nil, "must correctly queue audit log sync")
+
+	err = sendAuditLogSync(auditLogSync)
+	assert.True(t, err == nil, "must correctly send audit log sync")
+	res, _ := models.GetAuditLogSyncsToSend(nil, auditLogSync.NextRetryUTC, 100)
+	for _, _auditLogSync := range res {
+		assert.True(t, _auditLogSync.Key != auditLogSync.Key, "audit log sync of removed node must be dropped")
+	}
+
+	assert.True(t, getAuditLogSyncRetryDelay(2) == AUDIT_LOG_SYNC_RETRY_BASE*2)
+	assert.True(t, getAuditLogSyncRetryDelay(100) == AUDIT_LOG_SYNC_RETRY_MAX)
+}
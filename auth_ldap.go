@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const LDAP_TIMEOUT = 10 * time.Second
+
+type ldapAuthProvider struct{}
+
+func (*ldapAuthProvider) authenticate(name, passCode string) (*externalIdentity, error) {
+	// empty password is an unauthenticated bind which servers accept
+	if name == "" || passCode == "" {
+		return nil, fmt.Errorf("name and pass_code required")
+	}
+
+	l, err := dialLDAP(conf.LDAPURL)
+	if err != nil {
+		return nil, err
+	}
+	defer l.Close()
+
+	if conf.LDAPBindDN != "" {
+		if err := l.Bind(conf.LDAPBindDN, conf.LDAPBindPassword); err != nil {
+			return nil, fmt.Errorf("failed to bind ldap search account: %s", err.Error())
+		}
+	}
+
+	// size limit of 2 is enough to find duplicated users
+	res, err := l.Search(ldap.NewSearchRequest(
+		conf.LDAPBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, int(LDAP_TIMEOUT/time.Second), false,
+		fmt.Sprintf("(%s=%s)", conf.LDAPUserAttr, ldap.EscapeFilter(name)),
+		[]string{conf.LDAPUserAttr, conf.LDAPGroupAttr}, nil))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, err
+	}
+	if len(res.Entries) != 1 {
+		return nil, fmt.Errorf("ldap user not exist or not unique: %s", name)
+	}
+	entry := res.Entries[0]
+
+	if err := l.Bind(entry.DN, passCode); err != nil {
+		return nil, fmt.Errorf("ldap bind failed: %s", err.Error())
+	}
+
+	// name as the directory keeps it
+	if dirName := entry.GetEqualFoldAttributeValue(conf.LDAPUserAttr); dirName != "" {
+		name = dirName
+	}
+
+	return &externalIdentity{
+		Source:     models.USER_SOURCE_LDAP,
+		ExternalId: name,
+		Name:       name,
+		Groups:     entry.GetEqualFoldAttributeValues(conf.LDAPGroupAttr),
+	}, nil
+}
+
+func (*ldapAuthProvider) userRole(groups []string) string {
+	return getGroupsRole(groups, conf.LDAPGroupRoles, conf.LDAPDefaultRole)
+}
+
+// addr is ldap://host[:port] or ldaps://host[:port], ldap:// is refused unless
+// insecure ldap is allowed as bind passcodes are sent in clear text
+func dialLDAP(addr string) (*ldap.Conn, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "ldap":
+		if !conf.LDAPAllowInsecure {
+			return nil, fmt.Errorf("ldap:// sends passcodes in clear text, use ldaps:// or set ldap allow_insecure=on")
+		}
+	case "ldaps":
+	default:
+		return nil, fmt.Errorf("unknown ldap url scheme: %s", u.Scheme)
+	}
+
+	l, err := ldap.DialURL(addr, ldap.DialWithDialer(&net.Dialer{Timeout: LDAP_TIMEOUT}))
+	if err != nil {
+		return nil, err
+	}
+	l.SetTimeout(LDAP_TIMEOUT)
+
+	return l, nil
+}
@@ -0,0 +1,447 @@
+package main
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	OIDC_STATE_COOKIE  = "op_oidc"
+	OIDC_STATE_EXPIRES = 600 // second
+	OIDC_TOTP_COOKIE   = "op_oidc_totp"
+	OIDC_TOTP_EXPIRES  = 300 // second
+)
+
+var oidcHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// part of openid provider metadata used by authorization code flow
+type oidcProviderConfig struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type oidcJWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+func getOIDCJSON(u string, v interface{}) error {
+	resp, err := oidcHTTPClient.Get(u)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bad response status of %s: %d", u, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func getOIDCProviderConfig() (*oidcProviderConfig, error) {
+	res := &oidcProviderConfig{}
+	if err := getOIDCJSON(conf.OIDCIssuer+"/.well-known/openid-configuration", res); err != nil {
+		return nil, err
+	}
+	if strings.TrimRight(res.Issuer, "/") != conf.OIDCIssuer {
+		return nil, fmt.Errorf("oidc issuer not match: %s", res.Issuer)
+	}
+
+	return res, nil
+}
+
+// rsa keys of issuer by key id
+func getOIDCKeys(jwksURI string) (map[string]*rsa.PublicKey, error) {
+	var data struct {
+		Keys []*oidcJWK `json:"keys"`
+	}
+	if err := getOIDCJSON(jwksURI, &data); err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]*rsa.PublicKey)
+	for _, key := range data.Keys {
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, err
+		}
+		res[key.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+
+	return res, nil
+}
+
+func OIDCLogin(c *gin.Context) {
+	providerConfig, err := getOIDCProviderConfig()
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	state, nonce := utils.GenerateSecret(16), utils.GenerateSecret(16)
+	stateToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"state": state,
+		"nonce": nonce,
+		"exp":   utils.GetNowSecond() + OIDC_STATE_EXPIRES,
+	}).SignedString([]byte(conf.SessionSignKey))
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	setOIDCStateCookie(c, stateToken, OIDC_STATE_EXPIRES)
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", conf.OIDCClientID)
+	params.Set("redirect_uri", conf.OIDCRedirectURL)
+	params.Set("scope", conf.OIDCScopes)
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+
+	sep := "?"
+	if strings.Contains(providerConfig.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	c.Redirect(http.StatusFound, providerConfig.AuthorizationEndpoint+sep+params.Encode())
+}
+
+func OIDCCallback(c *gin.Context) {
+	setAuditLog(c, AUDIT_ACTION_LOGIN, "", nil, map[string]string{"provider": models.USER_SOURCE_OIDC})
+	if errStr := c.Query("error"); errStr != "" {
+		Error(c, NOT_LOGIN, fmt.Sprintf("oidc login failed: %s %s", errStr, c.Query("error_description")))
+		return
+	}
+
+	cookie, err := c.Request.Cookie(OIDC_STATE_COOKIE)
+	if err != nil {
+		Error(c, NOT_LOGIN, "oidc login state missing")
+		return
+	}
+	setOIDCStateCookie(c, "", -1)
+
+	nonce, err := verifyOIDCState(cookie.Value, c.Query("state"))
+	if err != nil {
+		Error(c, NOT_LOGIN, err.Error())
+		return
+	}
+
+	identity, err := exchangeOIDCCode(c.Query("code"), nonce)
+	if err != nil {
+		Error(c, NOT_LOGIN, err.Error())
+		return
+	}
+
+	user, errCode, err := provisionExternalUser(identity, getGroupsRole(identity.Groups, conf.OIDCGroupRoles, conf.OIDCDefaultRole))
+	if err != nil {
+		Error(c, errCode, err.Error())
+		return
+	}
+
+	// users enabled totp get no session until OIDCConfirm verifies the second factor
+	if user.TOTPSecret != "" {
+		totpToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"uky": user.Key,
+			"exp": utils.GetNowSecond() + OIDC_TOTP_EXPIRES,
+		}).SignedString([]byte(conf.SessionSignKey))
+		if err != nil {
+			Error(c, SERVER_ERROR, err.Error())
+			return
+		}
+		setOIDCCookie(c, OIDC_TOTP_COOKIE, totpToken, OIDC_TOTP_EXPIRES)
+		c.Redirect(http.StatusFound, "/web/?totp_required=oidc")
+		return
+	}
+
+	if err := startOpSession(c, user); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	loginRedirect(c)
+}
+
+// second step of oidc login for users enabled totp, user is taken from the cookie set by OIDCCallback
+func OIDCConfirm(c *gin.Context) {
+	var data struct {
+		TOTPCode     string `json:"totp_code"`
+		RecoveryCode string `json:"recovery_code"`
+	}
+	if err := c.BindJSON(&data); err != nil {
+		Error(c, BAD_POST_DATA, err.Error())
+		return
+	}
+	setAuditLog(c, AUDIT_ACTION_LOGIN, "", nil, map[string]string{"provider": models.USER_SOURCE_OIDC})
+
+	cookie, err := c.Request.Cookie(OIDC_TOTP_COOKIE)
+	if err != nil {
+		Error(c, NOT_LOGIN, "oidc login missing")
+		return
+	}
+	userKey, err := verifyOIDCTOTPToken(cookie.Value)
+	if err != nil {
+		Error(c, NOT_LOGIN, err.Error())
+		return
+	}
+
+	memConfMux.RLock()
+	user := memConfUsers[userKey]
+	memConfMux.RUnlock()
+	if user == nil || user.Source != models.USER_SOURCE_OIDC {
+		Error(c, USER_NOT_EXIST)
+		return
+	}
+	if user.Status == models.USER_STATUS_INACTIVE {
+		Error(c, USER_INACTIVE)
+		return
+	}
+
+	failureKey := getProviderLoginFailureKey(models.USER_SOURCE_OIDC, user.Name)
+	lockedUntil, err := getLoginLockedUntil(failureKey)
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	if lockedUntil > 0 {
+		Error(c, USER_LOCKED, fmt.Sprintf("too many failed logins, user is locked until %d", lockedUntil))
+		return
+	}
+
+	user, errCode, err := verifyLoginSecondFactor(user, data.TOTPCode, data.RecoveryCode)
+	if err != nil {
+		if errCode == TOTP_CODE_ERROR {
+			if err := recordLoginFailure(failureKey); err != nil {
+				Error(c, SERVER_ERROR, err.Error())
+				return
+			}
+		}
+		Error(c, errCode, err.Error())
+		return
+	}
+	if err := clearLoginFailure(failureKey); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	setOIDCCookie(c, OIDC_TOTP_COOKIE, "", -1)
+
+	if err := startOpSession(c, user); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	Success(c, nil)
+}
+
+// returns key of user passed oidc login and waiting for totp
+func verifyOIDCTOTPToken(totpToken string) (string, error) {
+	token, err := jwt.Parse(totpToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(conf.SessionSignKey), nil
+	})
+	if err != nil {
+		return "", err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid || claims["exp"] == nil {
+		return "", fmt.Errorf("oidc login invalid")
+	}
+	userKey, _ := claims["uky"].(string)
+	if userKey == "" {
+		return "", fmt.Errorf("oidc login invalid")
+	}
+
+	return userKey, nil
+}
+
+func setOIDCStateCookie(c *gin.Context, value string, maxAge int) {
+	setOIDCCookie(c, OIDC_STATE_COOKIE, value, maxAge)
+}
+
+func setOIDCCookie(c *gin.Context, name, value string, maxAge int) {
+	cookie := new(http.Cookie)
+	cookie.Name = name
+	cookie.Value = value
+	cookie.Path = "/op/auth/oidc"
+	cookie.MaxAge = maxAge
+	cookie.HttpOnly = true
+	http.SetCookie(c.Writer, cookie)
+}
+
+// returns nonce of login request when state is the one issued to the browser
+func verifyOIDCState(stateToken, state string) (string, error) {
+	token, err := jwt.Parse(stateToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(conf.SessionSignKey), nil
+	})
+	if err != nil {
+		return "", err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid || claims["exp"] == nil {
+		return "", fmt.Errorf("oidc login state invalid")
+	}
+	if expected, _ := claims["state"].(string); state == "" || expected != state {
+		return "", fmt.Errorf("oidc login state not match")
+	}
+
+	nonce, _ := claims["nonce"].(string)
+	return nonce, nil
+}
+
+func exchangeOIDCCode(code, nonce string) (*externalIdentity, error) {
+	if code == "" {
+		return nil, fmt.Errorf("oidc code missing")
+	}
+
+	providerConfig, err := getOIDCProviderConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", conf.OIDCRedirectURL)
+	req, err := http.NewRequest(http.MethodPost, providerConfig.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(conf.OIDCClientID), url.QueryEscape(conf.OIDCClientSecret))
+
+	resp, err := oidcHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var tokenResp struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return nil, err
+	}
+	if tokenResp.Error != "" {
+		return nil, fmt.Errorf("oidc token request failed: %s %s", tokenResp.Error, tokenResp.ErrorDescription)
+	}
+
+	claims, err := verifyOIDCIDToken(providerConfig, tokenResp.IDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return newOIDCIdentity(claims)
+}
+
+func verifyOIDCIDToken(providerConfig *oidcProviderConfig, idToken, nonce string) (jwt.MapClaims, error) {
+	keys, err := getOIDCKeys(providerConfig.JWKSURI)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := jwt.Parse(idToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		if key := keys[kid]; key != nil {
+			return key, nil
+		}
+		if kid == "" && len(keys) == 1 {
+			for _, key := range keys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("oidc key not found: %s", kid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid || claims["exp"] == nil {
+		return nil, fmt.Errorf("oidc id token invalid")
+	}
+	if iss, _ := claims["iss"].(string); strings.TrimRight(iss, "/") != conf.OIDCIssuer {
+		return nil, fmt.Errorf("oidc id token issuer not match: %s", iss)
+	}
+	if !oidcAudienceContains(claims["aud"], conf.OIDCClientID) {
+		return nil, fmt.Errorf("oidc id token audience not match")
+	}
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return nil, fmt.Errorf("oidc id token nonce not match")
+	}
+
+	return claims, nil
+}
+
+// aud claim is a string or an array of strings
+func oidcAudienceContains(aud interface{}, clientID string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == clientID
+	case []interface{}:
+		for _, a := range v {
+			if s, _ := a.(string); s == clientID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func newOIDCIdentity(claims jwt.MapClaims) (*externalIdentity, error) {
+	sub, _ := claims["sub"].(string)
+	name, _ := claims[conf.OIDCNameClaim].(string)
+	if sub == "" || name == "" {
+		return nil, fmt.Errorf("oidc id token has no sub or %s", conf.OIDCNameClaim)
+	}
+
+	var groups []string
+	switch v := claims[conf.OIDCGroupsClaim].(type) {
+	case string:
+		groups = []string{v}
+	case []interface{}:
+		for _, g := range v {
+			if s, ok := g.(string); ok {
+				groups = append(groups, s)
+			}
+		}
+	}
+
+	return &externalIdentity{
+		Source:     models.USER_SOURCE_OIDC,
+		ExternalId: sub,
+		Name:       name,
+		Groups:     groups,
+	}, nil
+}
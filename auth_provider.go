@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// user authenticated by an external provider
+type externalIdentity struct {
+	Source     string
+	ExternalId string
+	Name       string
+	Groups     []string
+}
+
+// providers checking name and passcode posted to Login
+type passCodeAuthProvider interface {
+	authenticate(name, passCode string) (*externalIdentity, error)
+	userRole(groups []string) string
+}
+
+var passCodeAuthProviders = make(map[string]passCodeAuthProvider)
+
+func init() {
+	if conf.LDAPEnable {
+		passCodeAuthProviders[models.USER_SOURCE_LDAP] = &ldapAuthProvider{}
+	}
+}
+
+var userRoleLevels = map[string]int{
+	models.USER_ROLE_VIEWER: 1,
+	models.USER_ROLE_MEMBER: 2,
+	models.USER_ROLE_ADMIN:  3,
+}
+
+// the highest role of groups, defaultRole if none of groups is mapped
+func getGroupsRole(groups []string, groupRoles map[string]string, defaultRole string) string {
+	role := ""
+	for _, group := range groups {
+		if r := groupRoles[group]; userRoleLevels[r] > userRoleLevels[role] {
+			role = r
+		}
+	}
+	if role == "" {
+		role = defaultRole
+	}
+
+	return role
+}
+
+func isAdminUser(user *models.User) bool {
+	return user.CreatorKey == user.Key || user.Role == models.USER_ROLE_ADMIN
+}
+
+func isViewerUser(user *models.User) bool {
+	return user.Role == models.USER_ROLE_VIEWER
+}
+
+// enabled providers for login page
+func GetAuthProviders(c *gin.Context) {
+	res := []string{"local"}
+	for name := range passCodeAuthProviders {
+		res = append(res, name)
+	}
+	if conf.OIDCEnable {
+		res = append(res, models.USER_SOURCE_OIDC)
+	}
+
+	Success(c, res)
+}
+
+func loginWithProvider(c *gin.Context, provider, name, passCode, totpCode, recoveryCode string) {
+	authProvider := passCodeAuthProviders[provider]
+	if authProvider == nil {
+		Error(c, BAD_REQUEST, "unknown auth provider: "+provider)
+		return
+	}
+
+	// users may not be provisioned yet, so failures are counted by provider and name
+	failureKey := getProviderLoginFailureKey(provider, name)
+	lockedUntil, err := getLoginLockedUntil(failureKey)
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	if lockedUntil > 0 {
+		Error(c, USER_LOCKED, fmt.Sprintf("too many failed logins, user is locked until %d", lockedUntil))
+		return
+	}
+
+	identity, err := authProvider.authenticate(name, passCode)
+	if err != nil {
+		if err := recordLoginFailure(failureKey); err != nil {
+			Error(c, SERVER_ERROR, err.Error())
+			return
+		}
+		Error(c, PASS_CODE_ERROR, err.Error())
+		return
+	}
+
+	user, errCode, err := provisionExternalUser(identity, authProvider.userRole(identity.Groups))
+	if err != nil {
+		Error(c, errCode, err.Error())
+		return
+	}
+	if user, errCode, err = verifyLoginSecondFactor(user, totpCode, recoveryCode); err != nil {
+		if errCode == TOTP_CODE_ERROR {
+			if err := recordLoginFailure(failureKey); err != nil {
+				Error(c, SERVER_ERROR, err.Error())
+				return
+			}
+		}
+		Error(c, errCode, err.Error())
+		return
+	}
+	if err := clearLoginFailure(failureKey); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	if err := startOpSession(c, user); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	Success(c, nil)
+}
+
+func getProviderLoginFailureKey(provider, name string) string {
+	return provider + ":" + name
+}
+
+// creates user on first login and keeps user's role same as provider groups,
+// error code is only meaningful when err is not nil
+func provisionExternalUser(identity *externalIdentity, role string) (*models.User, int, error) {
+	if role == "" {
+		return nil, NOT_PERMITTED, fmt.Errorf("user [%s] is not in any group allowed to login", identity.Name)
+	}
+
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	memConfMux.RLock()
+	var oldUser, initUser *models.User
+	for _, user := range memConfUsers {
+		if user.Source == identity.Source && user.ExternalId == identity.ExternalId {
+			oldUser = user
+		}
+		if user.CreatorKey == user.Key {
+			initUser = user
+		}
+	}
+	nameUser := memConfUsersByName[identity.Name]
+	memConfMux.RUnlock()
+
+	if initUser == nil {
+		return nil, USER_NOT_INIT, fmt.Errorf(errorStr[USER_NOT_INIT][1])
+	}
+	if oldUser != nil {
+		if oldUser.Status == models.USER_STATUS_INACTIVE {
+			return nil, USER_INACTIVE, fmt.Errorf(errorStr[USER_INACTIVE][1])
+		}
+		if oldUser.Role == role {
+			return oldUser, 0, nil
+		}
+	} else if nameUser != nil {
+		return nil, BAD_REQUEST, fmt.Errorf("user name [%s] already exists", identity.Name)
+	}
+
+	// users are only written by master and synced to slaves
+	if !conf.IsMasterNode() {
+		return nil, NOT_PERMITTED, fmt.Errorf("user [%s] must login with master node first as role changed", identity.Name)
+	}
+
+	event := WEBHOOK_EVENT_USER_UPDATE
+	var user models.User
+	if oldUser != nil {
+		user = *oldUser
+	} else {
+		event = WEBHOOK_EVENT_USER_NEW
+		user = models.User{
+			Key:        utils.GenerateKey(),
+			Name:       identity.Name,
+			CreatorKey: initUser.Key,
+			CreatedUTC: utils.GetNowSecond(),
+			Status:     models.USER_STATUS_ACTIVE,
+			Source:     identity.Source,
+			ExternalId: identity.ExternalId,
+		}
+	}
+	user.Role = role
+
+	if _, err := updateUser(&user, nil); err != nil {
+		return nil, SERVER_ERROR, err
+	}
+
+	go TriggerUserWebHooks(event, &user, user.Key)
+	syncData2SlaveIfNeed(&user, user.Key)
+
+	return &user, 0, nil
+}
+
+func loginRedirect(c *gin.Context) {
+	c.Redirect(http.StatusFound, "/web/")
+}
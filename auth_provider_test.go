@@ -0,0 +1,267 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
+	"github.com/dgrijalva/jwt-go"
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type _ldapTestUser struct {
+	dn       string
+	uid      string
+	password string
+	groups   []string
+}
+
+// serves simple bind and equality search for users
+func _startLDAPServer(t *testing.T, users []*_ldapTestUser) net.Listener {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.True(t, err == nil)
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go _serveLDAPConn(conn, users)
+		}
+	}()
+
+	return ln
+}
+
+func _serveLDAPConn(conn net.Conn, users []*_ldapTestUser) {
+	defer conn.Close()
+	reply := func(id interface{}, op *ber.Packet) {
+		packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+		packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+		packet.AppendChild(op)
+		conn.Write(packet.Bytes())
+	}
+	result := func(tag ber.Tag, code int) *ber.Packet {
+		op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+		op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, ""))
+		op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+		op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+		return op
+	}
+	attr := func(name string, values ...string) *ber.Packet {
+		packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+		packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+		for _, value := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, ""))
+		}
+		packet.AppendChild(set)
+		return packet
+	}
+
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		id, op := packet.Children[0].Value, packet.Children[1]
+
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn, password := op.Children[1].Data.String(), op.Children[2].Data.String()
+			code := ldap.LDAPResultInvalidCredentials
+			for _, user := range users {
+				if user.dn == dn && user.password == password {
+					code = ldap.LDAPResultSuccess
+				}
+			}
+			reply(id, result(ldap.ApplicationBindResponse, code))
+		case ldap.ApplicationSearchRequest:
+			filter, _ := ldap.DecompileFilter(op.Children[6])
+			for _, user := range users {
+				if filter != "(uid="+user.uid+")" {
+					continue
+				}
+				entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+				entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, user.dn, ""))
+				attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+				attrs.AppendChild(attr("uid", user.uid))
+				attrs.AppendChild(attr("memberOf", user.groups...))
+				entry.AppendChild(attrs)
+				reply(id, entry)
+			}
+			reply(id, result(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+		case ldap.ApplicationUnbindRequest:
+			return
+		}
+	}
+}
+
+func TestGetGroupsRole(t *testing.T) {
+	groupRoles := map[string]string{"ops": models.USER_ROLE_ADMIN, "dev": models.USER_ROLE_MEMBER}
+	assert.True(t, getGroupsRole([]string{"dev", "ops"}, groupRoles, models.USER_ROLE_VIEWER) == models.USER_ROLE_ADMIN)
+	assert.True(t, getGroupsRole([]string{"dev", "qa"}, groupRoles, models.USER_ROLE_VIEWER) == models.USER_ROLE_MEMBER)
+	assert.True(t, getGroupsRole([]string{"qa"}, groupRoles, models.USER_ROLE_VIEWER) == models.USER_ROLE_VIEWER)
+	assert.True(t, getGroupsRole(nil, groupRoles, "") == "")
+}
+
+func TestLDAPAuthenticate(t *testing.T) {
+	ln := _startLDAPServer(t, []*_ldapTestUser{
+		{dn: "cn=search,dc=example,dc=com", password: "search"},
+		{dn: "uid=rahuahua,ou=people,dc=example,dc=com", uid: "rahuahua", password: "huahua", groups: []string{"cn=ops,ou=groups,dc=example,dc=com"}},
+	})
+	defer ln.Close()
+
+	conf.LDAPURL = "ldap://" + ln.Addr().String()
+	conf.LDAPAllowInsecure = false
+	_, err := (&ldapAuthProvider{}).authenticate("rahuahua", "huahua")
+	assert.True(t, err != nil, "ldap:// must be refused unless insecure ldap allowed")
+	conf.LDAPAllowInsecure = true
+	defer func() { conf.LDAPAllowInsecure = false }()
+	conf.LDAPBindDN, conf.LDAPBindPassword = "cn=search,dc=example,dc=com", "search"
+	conf.LDAPBaseDN, conf.LDAPUserAttr, conf.LDAPGroupAttr = "dc=example,dc=com", "uid", "memberOf"
+
+	provider := &ldapAuthProvider{}
+	identity, err := provider.authenticate("rahuahua", "huahua")
+	assert.True(t, err == nil, "must correctly bind ldap user")
+	assert.True(t, identity.Name == "rahuahua" && identity.Source == models.USER_SOURCE_LDAP)
+	assert.True(t, len(identity.Groups) == 1 && identity.Groups[0] == "cn=ops,ou=groups,dc=example,dc=com")
+
+	_, err = provider.authenticate("rahuahua", "wrong")
+	assert.True(t, err != nil, "wrong passcode must fail")
+	_, err = provider.authenticate("rahuahua", "")
+	assert.True(t, err != nil, "empty passcode must fail")
+	_, err = provider.authenticate("nobody", "huahua")
+	assert.True(t, err != nil, "unknown user must fail")
+
+	conf.LDAPBindPassword = "wrong"
+	_, err = provider.authenticate("rahuahua", "huahua")
+	assert.True(t, err != nil, "wrong search account must fail")
+}
+
+func _startOIDCIssuer(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) *httptest.Server {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&oidcProviderConfig{
+			Issuer:                server.URL,
+			AuthorizationEndpoint: server.URL + "/auth",
+			TokenEndpoint:         server.URL + "/token",
+			JWKSURI:               server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []*oidcJWK{{
+			Kty: "RSA",
+			Kid: "k1",
+			N:   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "instafig" || secret != "secret" || r.PostFormValue("code") != "code" {
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		claims["iss"] = server.URL
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "k1"
+		idToken, _ := token.SignedString(key)
+		json.NewEncoder(w).Encode(map[string]string{"id_token": idToken})
+	})
+
+	return server
+}
+
+func TestOIDCExchangeCode(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.True(t, err == nil)
+
+	claims := jwt.MapClaims{
+		"sub":                "u1",
+		"aud":                []interface{}{"instafig"},
+		"exp":                2000000000,
+		"nonce":              "n1",
+		"preferred_username": "rahuahua",
+		"groups":             []interface{}{"ops"},
+	}
+	server := _startOIDCIssuer(t, key, claims)
+	defer server.Close()
+
+	conf.OIDCIssuer, conf.OIDCClientID, conf.OIDCClientSecret = server.URL, "instafig", "secret"
+	conf.OIDCNameClaim, conf.OIDCGroupsClaim = "preferred_username", "groups"
+
+	identity, err := exchangeOIDCCode("code", "n1")
+	assert.True(t, err == nil, "must correctly verify id token")
+	assert.True(t, identity.ExternalId == "u1" && identity.Name == "rahuahua" && identity.Source == models.USER_SOURCE_OIDC)
+	assert.True(t, len(identity.Groups) == 1 && identity.Groups[0] == "ops")
+
+	_, err = exchangeOIDCCode("code", "n2")
+	assert.True(t, err != nil, "nonce must match")
+	_, err = exchangeOIDCCode("bad", "n1")
+	assert.True(t, err != nil, "bad code must fail")
+
+	claims["aud"] = "other"
+	_, err = exchangeOIDCCode("code", "n1")
+	assert.True(t, err != nil, "audience must match")
+	claims["aud"] = "instafig"
+	claims["exp"] = 1
+	_, err = exchangeOIDCCode("code", "n1")
+	assert.True(t, err != nil, "expired id token must fail")
+}
+
+func TestVerifyOIDCTOTPToken(t *testing.T) {
+	userKey, err := verifyOIDCTOTPToken(_signOpSession(jwt.MapClaims{"uky": "u1", "exp": 2000000000}, conf.SessionSignKey))
+	assert.True(t, err == nil && userKey == "u1")
+
+	_, err = verifyOIDCTOTPToken(_signOpSession(jwt.MapClaims{"uky": "u1", "exp": 1}, conf.SessionSignKey))
+	assert.True(t, err != nil, "expired token must not be accepted")
+	_, err = verifyOIDCTOTPToken(_signOpSession(jwt.MapClaims{"uky": "u1"}, conf.SessionSignKey))
+	assert.True(t, err != nil, "token without exp must not be accepted")
+	_, err = verifyOIDCTOTPToken(_signOpSession(jwt.MapClaims{"uky": "u1", "exp": 2000000000}, conf.NodeAuth))
+	assert.True(t, err != nil, "token signed by node auth must not be accepted")
+}
+
+func TestProvisionExternalUser(t *testing.T) {
+	err := _clearModelData()
+	assert.True(t, err == nil, "must correctly clear data")
+	loadAllData()
+	initNodeData()
+
+	identity := &externalIdentity{Source: models.USER_SOURCE_LDAP, ExternalId: "rahuahua", Name: "rahuahua"}
+	_, errCode, err := provisionExternalUser(identity, models.USER_ROLE_MEMBER)
+	assert.True(t, err != nil && errCode == USER_NOT_INIT)
+
+	confWriteMux.Lock()
+	_, err = newUserWithNewUserData(&newUserData{Name: "admin", PassCode: "huahua"}, "1234567", "1234567")
+	confWriteMux.Unlock()
+	assert.True(t, err == nil, "must correctly add init user")
+
+	_, _, err = provisionExternalUser(identity, "")
+	assert.True(t, err != nil, "user without role must not login")
+
+	user, _, err := provisionExternalUser(identity, models.USER_ROLE_VIEWER)
+	assert.True(t, err == nil && user.Role == models.USER_ROLE_VIEWER && user.Source == models.USER_SOURCE_LDAP)
+	assert.True(t, memConfUsersByName["rahuahua"] != nil && isViewerUser(memConfUsersByName["rahuahua"]))
+
+	sameUser, _, err := provisionExternalUser(identity, models.USER_ROLE_ADMIN)
+	assert.True(t, err == nil && sameUser.Key == user.Key && isAdminUser(memConfUsers[user.Key]), "role must follow groups")
+
+	_, _, err = provisionExternalUser(&externalIdentity{Source: models.USER_SOURCE_OIDC, ExternalId: "u1", Name: "admin"}, models.USER_ROLE_ADMIN)
+	assert.True(t, err != nil, "must not take over user of same name")
+
+	_clearModelData()
+}
@@ -51,7 +51,11 @@ func ClientConf(c *gin.Context) {
 		sendChanAsync(clientQueryParamCh, clientData)
 		setClientData(c, clientData)
 
-		c.JSON(http.StatusOK, getAppMatchConf(clientData.AppKey, clientData))
+		configs := getAppMatchConf(clientData.AppKey, clientData)
+		if conf.StatisticEnable {
+			setClientCodeValues(clientData, clientData.AppKey, configs)
+		}
+		c.JSON(http.StatusOK, configs)
 		return
 	}
 
@@ -59,6 +63,17 @@ func ClientConf(c *gin.Context) {
 	sendChanAsync(clientQueryParamCh, clientData)
 	setClientData(c, clientData)
 
+	// configs of app env are stored under env key
+	configsKey := clientData.AppKey
+	if envName := c.Query("env"); envName != "" {
+		env := getAppEnvByName(clientData.AppKey, envName)
+		if env == nil {
+			Error(c, BAD_REQUEST, "app env not exists: "+envName)
+			return
+		}
+		configsKey = env.Key
+	}
+
 	memConfMux.RLock()
 	if !conf.IsMasterNode() && conf.DataExpires > 0 {
 		if memConfNodes[conf.ClientAddr].LastCheckUTC < utils.GetNowSecond()-conf.DataExpires {
@@ -76,15 +91,18 @@ func ClientConf(c *gin.Context) {
 		ix++
 	}
 
-	needConf := memConfApps[clientData.AppKey] != nil && clientData.DataSign != memConfApps[clientData.AppKey].DataSign
+	needConf := memConfApps[clientData.AppKey] != nil && clientData.DataSign != getMemConfDataSign(configsKey)
 	memConfMux.RUnlock()
 
 	if needConf {
 		var dataSign string
-		configs := getAppMatchConf(clientData.AppKey, clientData)
+		configs := getAppMatchConf(configsKey, clientData)
+		if conf.StatisticEnable {
+			setClientCodeValues(clientData, configsKey, configs)
+		}
 		if len(configs) > 0 {
 			memConfMux.RLock()
-			dataSign = memConfApps[clientData.AppKey].DataSign
+			dataSign = getMemConfDataSign(configsKey)
 			memConfMux.RUnlock()
 		}
 
@@ -1,8 +1,11 @@
 package conf
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 	"os/exec"
@@ -14,6 +17,9 @@ import (
 	"github.com/gpmgo/gopm/modules/goconfig"
 )
 
+// kept in sqlite dir for nodes without session_sign_key in config file
+const SESSION_SIGN_KEY_FILE = "session_sign_key"
+
 var (
 	Port               int
 	SqliteDir          string
@@ -27,6 +33,17 @@ var (
 	DataExpires        int
 
 	UserPassCodeEncryptKey string
+	SessionSignKey         string
+	SessionExpires         int
+
+	PassCodeMinLength  int
+	PassCodeMinClasses int
+	PassCodeBcryptCost int
+	LoginMaxFailures   int
+	LoginLockout       int
+
+	TOTPIssuer       string
+	TOTPEnforceAdmin bool
 
 	WebDebugMode     bool
 	DebugMode        bool
@@ -35,12 +52,43 @@ var (
 	LogDir           string
 
 	StatisticEnable        bool
+	StatisticBackend       string
+	StatisticRetention     int
 	InfluxURL              string
 	InfluxDB               string
 	InfluxUser             string
 	InfluxPassword         string
 	InfluxBatchPointsCount int
 
+	SMTPEnable       bool
+	SMTPAddr         string
+	SMTPUser         string
+	SMTPPassword     string
+	SMTPFrom         string
+	SMTPDigestWindow int
+
+	LDAPEnable        bool
+	LDAPURL           string
+	LDAPBindDN        string
+	LDAPBindPassword  string
+	LDAPBaseDN        string
+	LDAPUserAttr      string
+	LDAPGroupAttr     string
+	LDAPGroupRoles    map[string]string
+	LDAPDefaultRole   string
+	LDAPAllowInsecure bool
+
+	OIDCEnable       bool
+	OIDCIssuer       string
+	OIDCClientID     string
+	OIDCClientSecret string
+	OIDCRedirectURL  string
+	OIDCScopes       string
+	OIDCNameClaim    string
+	OIDCGroupsClaim  string
+	OIDCGroupRoles   map[string]string
+	OIDCDefaultRole  string
+
 	configFile   = flag.String("config", "__unset__", "service config file")
 	maxThreadNum = flag.Int("max-thread", 0, "max threads of service")
 	debugMode    = flag.Bool("debug", false, "debug mode")
@@ -139,6 +187,49 @@ func init() {
 	}
 
 	UserPassCodeEncryptKey, _ = config.GetValue("", "user_passcode_encrypt_key")
+	SessionSignKey, _ = config.GetValue("", "session_sign_key")
+	SessionExpires = 7 * 86400
+	if expiresStr, _ := config.GetValue("", "session_expires"); expiresStr != "" {
+		if SessionExpires, err = strconv.Atoi(expiresStr); err != nil || SessionExpires <= 0 {
+			log.Printf("No correct session_expires: %s", expiresStr)
+			os.Exit(1)
+		}
+	}
+
+	passCodeInts := []struct {
+		key   string
+		value *int
+		def   int
+	}{
+		{"min_length", &PassCodeMinLength, 8},
+		{"min_classes", &PassCodeMinClasses, 3},
+		{"bcrypt_cost", &PassCodeBcryptCost, 10},
+		{"max_failures", &LoginMaxFailures, 5},
+		{"lockout", &LoginLockout, 900},
+	}
+	for _, item := range passCodeInts {
+		*item.value = item.def
+		if str, _ := config.GetValue("passcode", item.key); str != "" {
+			if *item.value, err = strconv.Atoi(str); err != nil || *item.value < 0 {
+				log.Printf("No correct passcode %s: %s", item.key, str)
+				os.Exit(1)
+			}
+		}
+	}
+	if PassCodeBcryptCost < 4 {
+		PassCodeBcryptCost = 4
+	}
+	if PassCodeBcryptCost > 31 {
+		log.Printf("No correct passcode bcrypt_cost: %d", PassCodeBcryptCost)
+		os.Exit(1)
+	}
+
+	if TOTPIssuer, _ = config.GetValue("totp", "issuer"); TOTPIssuer == "" {
+		TOTPIssuer = "Instafig"
+	}
+	if enforceAdmin, _ := config.GetValue("totp", "enforce_admin"); enforceAdmin == "on" {
+		TOTPEnforceAdmin = true
+	}
 
 	SqliteDir, _ = config.GetValue("sqlite", "dir")
 	if SqliteDir, err = filepath.Abs(SqliteDir); err != nil {
@@ -150,6 +241,16 @@ func init() {
 	NodeType, _ = config.GetValue("node", "type")
 	NodeAddr, _ = config.GetValue("node", "node_addr")
 	NodeAuth, _ = config.GetValue("node", "node_auth")
+	if SessionSignKey == "" {
+		if SessionSignKey, err = loadSessionSignKey(filepath.Join(SqliteDir, SESSION_SIGN_KEY_FILE)); err != nil {
+			log.Printf("Failed to load session sign key: %s", err.Error())
+			os.Exit(1)
+		}
+	}
+	if SessionSignKey == NodeAuth {
+		log.Println("session_sign_key must not be same as node_auth")
+		os.Exit(1)
+	}
 	if !IsMasterNode() {
 		MasterAddr, _ = config.GetValue("node", "master_addr")
 	}
@@ -173,17 +274,121 @@ func init() {
 		} else {
 			DataExpires = -1
 		}
+	} else {
+		// master node uses it to find slave nodes which stop checking in
+		DataExpires = -1
+		if expiresStr, _ := config.GetValue("node", "data_expires"); expiresStr != "" {
+			if DataExpires, err = strconv.Atoi(expiresStr); err != nil {
+				log.Printf("No correct expires: %s - %s", expiresStr, err.Error())
+				os.Exit(1)
+			}
+		}
 	}
 
 	if statisticEnable, _ := config.GetValue("statistic", "enable"); statisticEnable == "on" {
 		StatisticEnable = true
+		if StatisticBackend, _ = config.GetValue("statistic", "backend"); StatisticBackend == "" {
+			StatisticBackend = "influx"
+		}
+		if StatisticBackend != "influx" && StatisticBackend != "local" {
+			log.Println("No correct statistic backend: ", StatisticBackend)
+			os.Exit(1)
+		}
+		StatisticRetention = 30
+		if retention, _ := config.GetValue("statistic", "retention_days"); retention != "" {
+			if StatisticRetention, err = strconv.Atoi(retention); err != nil {
+				log.Println("retention_days is not number: ", retention)
+				os.Exit(1)
+			}
+		}
 		InfluxDB, _ = config.GetValue("statistic", "influx_db")
 		InfluxURL, _ = config.GetValue("statistic", "influx_url")
 		InfluxUser, _ = config.GetValue("statistic", "influx_user")
 		InfluxPassword, _ = config.GetValue("statistic", "influx_password")
-		batchCount, _ := config.GetValue("statistic", "influx_batch_points_count")
-		if InfluxBatchPointsCount, err = strconv.Atoi(batchCount); err != nil {
-			log.Println("influx_batch_point_count is not number: ", batchCount)
+		if StatisticBackend == "influx" {
+			batchCount, _ := config.GetValue("statistic", "influx_batch_points_count")
+			if InfluxBatchPointsCount, err = strconv.Atoi(batchCount); err != nil {
+				log.Println("influx_batch_point_count is not number: ", batchCount)
+				os.Exit(1)
+			}
+		}
+	}
+
+	if smtpEnable, _ := config.GetValue("smtp", "enable"); smtpEnable == "on" {
+		SMTPEnable = true
+		SMTPAddr, _ = config.GetValue("smtp", "addr")
+		SMTPUser, _ = config.GetValue("smtp", "user")
+		SMTPPassword, _ = config.GetValue("smtp", "password")
+		SMTPFrom, _ = config.GetValue("smtp", "from")
+		digestWindow, _ := config.GetValue("smtp", "digest_window")
+		if SMTPDigestWindow, err = strconv.Atoi(digestWindow); err != nil {
+			log.Println("digest_window is not number: ", digestWindow)
+			os.Exit(1)
+		}
+	}
+
+	if ldapEnable, _ := config.GetValue("ldap", "enable"); ldapEnable == "on" {
+		LDAPEnable = true
+		LDAPURL, _ = config.GetValue("ldap", "url")
+		if allowInsecure, _ := config.GetValue("ldap", "allow_insecure"); allowInsecure == "on" {
+			LDAPAllowInsecure = true
+		}
+		if strings.HasPrefix(LDAPURL, "ldap://") {
+			if !LDAPAllowInsecure {
+				log.Println("ldap url must be ldaps:// as passcodes are sent in clear text with ldap://, set allow_insecure=on to use it anyway")
+				os.Exit(1)
+			}
+			log.Println("WARNING: passcodes are sent to ldap server in clear text with ldap://")
+		}
+		LDAPBindDN, _ = config.GetValue("ldap", "bind_dn")
+		LDAPBindPassword, _ = config.GetValue("ldap", "bind_password")
+		LDAPBaseDN, _ = config.GetValue("ldap", "base_dn")
+		if LDAPUserAttr, _ = config.GetValue("ldap", "user_attr"); LDAPUserAttr == "" {
+			LDAPUserAttr = "uid"
+		}
+		if LDAPGroupAttr, _ = config.GetValue("ldap", "group_attr"); LDAPGroupAttr == "" {
+			LDAPGroupAttr = "memberOf"
+		}
+		groupRoles, _ := config.GetValue("ldap", "group_roles")
+		if LDAPGroupRoles, err = parseGroupRoles(groupRoles); err != nil {
+			log.Println("No correct ldap group_roles: ", err.Error())
+			os.Exit(1)
+		}
+		LDAPDefaultRole, _ = config.GetValue("ldap", "default_role")
+		if LDAPDefaultRole != "" && !isRole(LDAPDefaultRole) {
+			log.Println("No correct ldap default_role: ", LDAPDefaultRole)
+			os.Exit(1)
+		}
+	}
+
+	if oidcEnable, _ := config.GetValue("oidc", "enable"); oidcEnable == "on" {
+		OIDCEnable = true
+		OIDCIssuer, _ = config.GetValue("oidc", "issuer")
+		OIDCIssuer = strings.TrimRight(OIDCIssuer, "/")
+		OIDCClientID, _ = config.GetValue("oidc", "client_id")
+		OIDCClientSecret, _ = config.GetValue("oidc", "client_secret")
+		OIDCRedirectURL, _ = config.GetValue("oidc", "redirect_url")
+		if OIDCScopes, _ = config.GetValue("oidc", "scopes"); OIDCScopes == "" {
+			OIDCScopes = "openid profile email"
+		}
+		if OIDCNameClaim, _ = config.GetValue("oidc", "name_claim"); OIDCNameClaim == "" {
+			OIDCNameClaim = "preferred_username"
+		}
+		if OIDCGroupsClaim, _ = config.GetValue("oidc", "groups_claim"); OIDCGroupsClaim == "" {
+			OIDCGroupsClaim = "groups"
+		}
+		groupRoles, _ := config.GetValue("oidc", "group_roles")
+		if OIDCGroupRoles, err = parseGroupRoles(groupRoles); err != nil {
+			log.Println("No correct oidc group_roles: ", err.Error())
+			os.Exit(1)
+		}
+		OIDCDefaultRole, _ = config.GetValue("oidc", "default_role")
+		if OIDCDefaultRole != "" && !isRole(OIDCDefaultRole) {
+			log.Println("No correct oidc default_role: ", OIDCDefaultRole)
+			os.Exit(1)
+		}
+		if OIDCIssuer == "" || OIDCClientID == "" || OIDCRedirectURL == "" {
+			log.Println("oidc issuer, client_id and redirect_url are required")
 			os.Exit(1)
 		}
 	}
@@ -196,6 +401,57 @@ func init() {
 	}
 }
 
+func isRole(role string) bool {
+	return role == "admin" || role == "member" || role == "viewer"
+}
+
+// parses "group1:role1;group2:role2", groups may be ldap dns containing ',' and ':'
+func parseGroupRoles(str string) (map[string]string, error) {
+	res := make(map[string]string)
+	for _, item := range strings.Split(str, ";") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		i := strings.LastIndex(item, ":")
+		if i <= 0 {
+			return nil, fmt.Errorf("bad group role: %s", item)
+		}
+		group, role := strings.TrimSpace(item[:i]), strings.TrimSpace(item[i+1:])
+		if !isRole(role) {
+			return nil, fmt.Errorf("unknown role: %s", role)
+		}
+		res[group] = role
+	}
+
+	return res, nil
+}
+
+// generates a random key on first start, so login cookies keep valid over restarts
+func loadSessionSignKey(keyFile string) (string, error) {
+	data, err := ioutil.ReadFile(keyFile)
+	if err == nil && len(data) > 0 {
+		return string(data), nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return "", err
+	}
+	key := hex.EncodeToString(b)
+	if err = exec.Command("mkdir", "-p", filepath.Dir(keyFile)).Run(); err != nil {
+		return "", err
+	}
+	if err = ioutil.WriteFile(keyFile, []byte(key), 0600); err != nil {
+		return "", err
+	}
+	log.Printf("session_sign_key is not set, generated one in %s, nodes must share it to share login sessions", keyFile)
+
+	return key, nil
+}
+
 func IsMasterNode() bool {
 	return NodeType == "master"
 }
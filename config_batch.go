@@ -0,0 +1,258 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const CONFIG_BATCH_MAX_SIZE = 100
+
+type configStatusData struct {
+	Key          string `json:"key"`
+	Status       int    `json:"status"`
+	LastUpdateId string `json:"last_update_id"`
+}
+
+// configs of batch must belong to the app or its envs, message and ref of batch are
+// used by configs having none
+type batchUpdateConfigsData struct {
+	AppKey   string              `json:"app_key" binding:"required"`
+	Creates  []*newConfigData    `json:"creates"`
+	Updates  []*updateConfigData `json:"updates"`
+	Statuses []*configStatusData `json:"statuses"`
+	Message  string              `json:"message"`
+	Ref      string              `json:"ref"`
+}
+
+// creates, updates and status changes of configs are all verified first and then
+// saved with one data version, so clients never see part of them
+func BatchUpdateConfigs(c *gin.Context) {
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	data := &batchUpdateConfigsData{}
+	if err := c.BindJSON(data); err != nil {
+		Error(c, BAD_POST_DATA, err.Error())
+		return
+	}
+
+	batchId := utils.GenerateKey()
+	oldConfigs, configs, errCode, err := genBatchConfigs(data, batchId, getOpUserKey(c))
+	if err != nil {
+		Error(c, errCode, err.Error())
+		return
+	}
+
+	res := map[string]interface{}{"last_update_ids": map[string]string{}}
+	if len(configs) == 0 {
+		Success(c, res)
+		return
+	}
+
+	if err = updateConfigs(configs, getOpUserKey(c), nil); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	setAuditLog(c, AUDIT_ACTION_CONFIG_BATCH, batchId, oldConfigs, configs)
+
+	lastUpdateIds := make(map[string]string, len(configs))
+	for _, config := range configs {
+		lastUpdateIds[config.Key] = config.LastUpdateId
+	}
+	res["batch_id"] = batchId
+	res["last_update_ids"] = lastUpdateIds
+	if failedNodes := syncData2SlaveIfNeed(&configBatchData{AppKey: data.AppKey, Configs: configs}, getOpUserKey(c)); len(failedNodes) > 0 {
+		res["failed_nodes"] = failedNodes
+	}
+
+	Success(c, res)
+}
+
+// configs to save and old ones of updated configs, unchanged configs are left out,
+// error code is only meaningful when err is not nil
+func genBatchConfigs(data *batchUpdateConfigsData, batchId, userKey string) ([]*models.Config, []*models.Config, int, error) {
+	size := len(data.Creates) + len(data.Updates) + len(data.Statuses)
+	if size == 0 {
+		return nil, nil, BAD_REQUEST, fmt.Errorf("no config in batch")
+	}
+	if size > CONFIG_BATCH_MAX_SIZE {
+		return nil, nil, BAD_REQUEST, fmt.Errorf("too many configs in batch, must not be more than %d", CONFIG_BATCH_MAX_SIZE)
+	}
+	if err := verifyConfigUpdateMessage(data.Message, data.Ref); err != nil {
+		return nil, nil, BAD_REQUEST, err
+	}
+
+	updates := data.Updates
+	for _, status := range data.Statuses {
+		oldConfig := memConfRawConfigs[status.Key]
+		if oldConfig == nil {
+			return nil, nil, BAD_REQUEST, fmt.Errorf("config key not exists: " + status.Key)
+		}
+		updates = append(updates, &updateConfigData{
+			Key:          oldConfig.Key,
+			K:            oldConfig.K,
+			V:            oldConfig.V,
+			VType:        oldConfig.VType,
+			Des:          oldConfig.Des,
+			Status:       status.Status,
+			LastUpdateId: status.LastUpdateId,
+		})
+	}
+
+	var oldConfigs, configs []*models.Config
+	// nested items are not checked by binding, and configs of batch must not clash with each other
+	updatedKeys := make(map[string]bool)
+	newNames := make(map[string]bool)
+	for _, create := range data.Creates {
+		if create.AppKey == "" {
+			create.AppKey = data.AppKey
+		}
+		if create.AppKey != data.AppKey {
+			return nil, nil, BAD_REQUEST, fmt.Errorf("config [%s] is not of app [%s]", create.K, data.AppKey)
+		}
+		if strings.TrimSpace(create.K) == "" {
+			return nil, nil, BAD_REQUEST, fmt.Errorf("config k required")
+		}
+		setBatchConfigMessage(&create.Message, &create.Ref, data)
+		if err := verifyNewConfigData(create); err != nil {
+			return nil, nil, BAD_REQUEST, err
+		}
+
+		configsKey := create.AppKey
+		if create.Env != "" {
+			configsKey = create.Env
+		}
+		name := configsKey + ":" + create.K
+		if newNames[name] {
+			return nil, nil, BAD_REQUEST, fmt.Errorf("config [%s] appears more than once in batch", create.K)
+		}
+		newNames[name] = true
+
+		config := genNewConfig(create, userKey)
+		config.UpdateBatchId = batchId
+		configs = append(configs, config)
+	}
+
+	for _, update := range updates {
+		if update.Key == "" || strings.TrimSpace(update.K) == "" {
+			return nil, nil, BAD_REQUEST, fmt.Errorf("config key and k required")
+		}
+		setBatchConfigMessage(&update.Message, &update.Ref, data)
+		if err := verifyUpdateConfigData(update); err != nil {
+			return nil, nil, BAD_REQUEST, err
+		}
+
+		oldConfig := memConfRawConfigs[update.Key]
+		if oldConfig.AppKey != data.AppKey {
+			return nil, nil, BAD_REQUEST, fmt.Errorf("config [%s] is not of app [%s]", oldConfig.K, data.AppKey)
+		}
+		if updatedKeys[update.Key] {
+			return nil, nil, BAD_REQUEST, fmt.Errorf("config [%s] appears more than once in batch", oldConfig.K)
+		}
+		updatedKeys[update.Key] = true
+		if errCode, err := checkUpdateConflict(oldConfig.LastUpdateId, update.LastUpdateId, "last_update_id", "config ["+oldConfig.K+"]"); err != nil {
+			return nil, nil, errCode, err
+		}
+		if !isConfigChangedByUpdateData(oldConfig, update) {
+			continue
+		}
+
+		if oldConfig.K != update.K {
+			name := getConfigsKey(oldConfig) + ":" + update.K
+			if newNames[name] {
+				return nil, nil, BAD_REQUEST, fmt.Errorf("config [%s] appears more than once in batch", update.K)
+			}
+			newNames[name] = true
+		}
+
+		config := genUpdatedConfig(update)
+		config.UpdateBatchId = batchId
+		oldConfigs = append(oldConfigs, oldConfig)
+		configs = append(configs, config)
+	}
+
+	return oldConfigs, configs, 0, nil
+}
+
+func setBatchConfigMessage(message, ref *string, data *batchUpdateConfigsData) {
+	if *message == "" {
+		*message = data.Message
+	}
+	if *ref == "" {
+		*ref = data.Ref
+	}
+}
+
+// configs of batch are saved in one session with one data version
+func updateConfigs(configs []*models.Config, userKey string, newDataVersion *models.DataVersion) (err error) {
+	if newDataVersion == nil {
+		newDataVersion = genNewDataVersion(memConfDataVersion)
+	}
+
+	s := models.NewSession()
+	defer s.Close()
+	if err = s.Begin(); err != nil {
+		return
+	}
+
+	for _, config := range configs {
+		if _, err = updateConfig(config, userKey, newDataVersion, s); err != nil {
+			s.Rollback()
+			return
+		}
+	}
+
+	node, err := models.GetNodeByURL(s, conf.ClientAddr)
+	if err != nil {
+		s.Rollback()
+		return err
+	}
+
+	if err = s.Commit(); err != nil {
+		s.Rollback()
+		return
+	}
+
+	// all configs of batch are of one app, so are the apps depending on it
+	var toUpdateApps []*models.App
+	if app := memConfApps[configs[0].AppKey]; app != nil && app.Type == models.APP_TYPE_TEMPLATE {
+		toUpdateApps = getTemplateDependentApps(app.Key)
+	}
+	for _, config := range configs {
+		if config.Env == "" {
+			// reload app's data sign
+			updateMemConf(config, newDataVersion, node, toUpdateApps)
+		} else {
+			updateMemConf(config, newDataVersion, node)
+		}
+	}
+
+	if conf.IsMasterNode() {
+		go triggerConfigsWebHooks(configs, newDataVersion.Version)
+	}
+
+	return
+}
+
+// updateConfig does not trigger webhooks for configs saved in a shared session
+func triggerConfigsWebHooks(configs []*models.Config, dataVersion int) {
+	for _, config := range configs {
+		history, err := models.GetConfigUpdateHistoryById(nil, config.LastUpdateId)
+		if err != nil || history == nil {
+			continue
+		}
+
+		app := &models.App{Key: config.Key, Name: config.Key}
+		if !isSysConfType(config.AppKey) {
+			memConfMux.RLock()
+			app = memConfApps[config.AppKey]
+			memConfMux.RUnlock()
+		}
+		TriggerWebHooks(history, app, dataVersion)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchUpdateConfigs(t *testing.T) {
+	err := _clearModelData()
+	assert.True(t, err == nil, "must correctly clear data")
+	loadAllData()
+	initNodeData()
+
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	user, app, config, err := initOneConfig("rahuahua", "iconfreecn", models.APP_TYPE_REAL, "config1", "1", models.CONF_V_TYPE_INT)
+	assert.True(t, err == nil, "must correctly add new config")
+	config2, err := newConfigWithNewConfigData(&newConfigData{AppKey: app.Key, K: "config2", V: "a", VType: models.CONF_V_TYPE_STRING}, user.Key)
+	assert.True(t, err == nil, "must correctly add new config")
+
+	data := &batchUpdateConfigsData{
+		AppKey: app.Key,
+		Creates: []*newConfigData{
+			{K: "config3", V: "2", VType: models.CONF_V_TYPE_INT},
+		},
+		Updates: []*updateConfigData{
+			{Key: config.Key, K: "config1", V: "3", VType: models.CONF_V_TYPE_INT, LastUpdateId: config.LastUpdateId, Message: "bump config1"},
+		},
+		Statuses: []*configStatusData{
+			{Key: config2.Key, Status: models.CONF_STATUS_INACTIVE, LastUpdateId: config2.LastUpdateId},
+		},
+		Message: "release 1.2",
+		Ref:     "OPS-7",
+	}
+
+	bad := *data
+	bad.Creates = []*newConfigData{{K: "config3", V: "2", VType: models.CONF_V_TYPE_INT}, {K: "config3", V: "4", VType: models.CONF_V_TYPE_INT}}
+	_, _, errCode, err := genBatchConfigs(&bad, "batch", user.Key)
+	assert.True(t, err != nil && errCode == BAD_REQUEST, "config must not be created twice in batch")
+
+	bad = *data
+	bad.Updates = []*updateConfigData{{Key: config.Key, K: "config1", V: "3", VType: models.CONF_V_TYPE_INT, LastUpdateId: "stale"}}
+	_, _, errCode, err = genBatchConfigs(&bad, "batch", user.Key)
+	assert.True(t, err != nil && errCode == UPDATE_CONFLICT)
+	bad.Updates[0].LastUpdateId = ""
+	_, _, errCode, err = genBatchConfigs(&bad, "batch", user.Key)
+	assert.True(t, err != nil && errCode == BAD_REQUEST, "configs posted without last update id must be rejected")
+
+	bad = *data
+	bad.Creates = []*newConfigData{{AppKey: "other-app", K: "config3", V: "2", VType: models.CONF_V_TYPE_INT}}
+	_, _, errCode, err = genBatchConfigs(&bad, "batch", user.Key)
+	assert.True(t, err != nil && errCode == BAD_REQUEST, "configs of other apps must be rejected")
+
+	batchId := utils.GenerateKey()
+	oldConfigs, configs, _, err := genBatchConfigs(data, batchId, user.Key)
+	assert.True(t, err == nil && len(oldConfigs) == 2 && len(configs) == 3)
+
+	node1 := *memConfNodes[conf.ClientAddr]
+	err = updateConfigs(configs, user.Key, nil)
+	assert.True(t, err == nil, "must correctly update configs of batch")
+	node2 := memConfNodes[conf.ClientAddr]
+	assert.True(t, node2.DataVersion.Version == node1.DataVersion.Version+1, "batch must use one data version")
+
+	assert.True(t, memConfRawConfigs[config.Key].V == "3")
+	assert.True(t, memConfRawConfigs[config2.Key].Status == models.CONF_STATUS_INACTIVE)
+	assert.True(t, len(memConfAppConfigs[app.Key]) == 3)
+	assert.True(t, memConfApps[app.Key].KeyCount == 3)
+
+	history, err := models.GetConfigUpdateHistoryById(nil, memConfRawConfigs[config.Key].LastUpdateId)
+	assert.True(t, err == nil && history.BatchId == batchId && history.Message == "bump config1" && history.Ref == "OPS-7")
+	history, err = models.GetConfigUpdateHistoryById(nil, memConfRawConfigs[config2.Key].LastUpdateId)
+	assert.True(t, err == nil && history.Kind == models.CONFIG_UPDATE_KIND_HIDE && history.Message == "release 1.2")
+
+	_clearModelData()
+}
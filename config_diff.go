@@ -0,0 +1,264 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	CONFIG_DIFF_ADDED          = "added"
+	CONFIG_DIFF_REMOVED        = "removed"
+	CONFIG_DIFF_CHANGED_VALUE  = "changed_value"
+	CONFIG_DIFF_CHANGED_TYPE   = "changed_type"
+	CONFIG_DIFF_CHANGED_STATUS = "changed_status"
+
+	CODE_EXPR_DIFF_ADDED   = "added"
+	CODE_EXPR_DIFF_REMOVED = "removed"
+	CODE_EXPR_DIFF_CHANGED = "changed"
+)
+
+type configSnapshot struct {
+	K      string `json:"k"`
+	V      string `json:"v"`
+	VType  string `json:"v_type"`
+	Status int    `json:"status"`
+}
+
+type configDiff struct {
+	K        string          `json:"k"`
+	Kinds    []string        `json:"kinds"`
+	From     *configSnapshot `json:"from"`
+	To       *configSnapshot `json:"to"`
+	CodeDiff []*codeExprDiff `json:"code_diff,omitempty"`
+}
+
+// path is in the form of "cond-values[1].value.default-value", empty for the whole value
+type codeExprDiff struct {
+	Path   string      `json:"path"`
+	Change string      `json:"change"`
+	From   interface{} `json:"from"`
+	To     interface{} `json:"to"`
+}
+
+func DiffApps(c *gin.Context) {
+	fromApp, toApp := c.Query("from_app"), c.Query("to_app")
+	fromEnv, toEnv := c.Query("from_env"), c.Query("to_env")
+
+	memConfMux.RLock()
+	defer memConfMux.RUnlock()
+
+	for _, item := range [][2]string{{fromApp, fromEnv}, {toApp, toEnv}} {
+		if memConfApps[item[0]] == nil {
+			Error(c, BAD_REQUEST, "app key not exists: "+item[0])
+			return
+		}
+		if env := memConfEnvs[item[1]]; item[1] != "" && (env == nil || env.AppKey != item[0]) {
+			Error(c, BAD_REQUEST, "app env not exists: "+item[1])
+			return
+		}
+	}
+
+	from := getMemConfigSnapshots(fromApp, fromEnv)
+	to := getMemConfigSnapshots(toApp, toEnv)
+
+	Success(c, diffConfigSnapshots(from, to))
+}
+
+func DiffAppHistory(c *gin.Context) {
+	appKey, env := c.Param("app_key"), c.Query("env")
+
+	fromUTC, err := strconv.Atoi(c.Query("from_utc"))
+	if err != nil {
+		Error(c, BAD_REQUEST, "from_utc not number")
+		return
+	}
+	toUTC := utils.GetNowSecond()
+	if c.Query("to_utc") != "" {
+		if toUTC, err = strconv.Atoi(c.Query("to_utc")); err != nil {
+			Error(c, BAD_REQUEST, "to_utc not number")
+			return
+		}
+	}
+	if fromUTC > toUTC {
+		Error(c, BAD_REQUEST, "from_utc is bigger than to_utc")
+		return
+	}
+
+	histories, err := models.GetAppConfigUpdateHistoryUntil(nil, appKey, env, toUTC)
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	from := replayConfigUpdateHistory(histories, fromUTC)
+	to := replayConfigUpdateHistory(histories, toUTC)
+
+	Success(c, diffConfigSnapshots(from, to))
+}
+
+// caller must hold memConfMux's read lock
+func getMemConfigSnapshots(appKey, env string) map[string]*configSnapshot {
+	configsKey := appKey
+	if env != "" {
+		configsKey = env
+	}
+
+	res := make(map[string]*configSnapshot)
+	for _, config := range memConfAppConfigs[configsKey] {
+		raw := memConfRawConfigs[config.Key]
+		res[raw.K] = &configSnapshot{
+			K:      raw.K,
+			V:      raw.V,
+			VType:  raw.VType,
+			Status: raw.Status,
+		}
+	}
+
+	return res
+}
+
+// rebuild configs at the given time, histories must be in the order they happened
+func replayConfigUpdateHistory(histories []*models.ConfigUpdateHistory, utc int) map[string]*configSnapshot {
+	configs := make(map[string]*configSnapshot)
+	for _, history := range histories {
+		if history.CreatedUTC > utc {
+			break
+		}
+
+		config := configs[history.ConfigKey]
+		if config == nil {
+			config = &configSnapshot{Status: models.CONF_STATUS_ACTIVE}
+		}
+		config.K = history.K
+		config.V = history.NewV
+		config.VType = history.NewVType
+
+		switch history.Kind {
+		case models.CONFIG_UPDATE_KIND_NEW, models.CONFIG_UPDATE_KIND_RECOVER:
+			config.Status = models.CONF_STATUS_ACTIVE
+		case models.CONFIG_UPDATE_KIND_HIDE:
+			config.Status = models.CONF_STATUS_INACTIVE
+		case models.CONFIG_UPDATE_KIND_DELETE:
+			delete(configs, history.ConfigKey)
+			continue
+		}
+		configs[history.ConfigKey] = config
+	}
+
+	res := make(map[string]*configSnapshot)
+	for _, config := range configs {
+		res[config.K] = config
+	}
+
+	return res
+}
+
+func diffConfigSnapshots(from, to map[string]*configSnapshot) []*configDiff {
+	var ks []string
+	for k := range from {
+		ks = append(ks, k)
+	}
+	for k := range to {
+		if from[k] == nil {
+			ks = append(ks, k)
+		}
+	}
+	sort.Strings(ks)
+
+	diffs := make([]*configDiff, 0)
+	for _, k := range ks {
+		diff := &configDiff{K: k, From: from[k], To: to[k]}
+		switch {
+		case diff.From == nil:
+			diff.Kinds = []string{CONFIG_DIFF_ADDED}
+		case diff.To == nil:
+			diff.Kinds = []string{CONFIG_DIFF_REMOVED}
+		default:
+			if diff.From.V != diff.To.V {
+				diff.Kinds = append(diff.Kinds, CONFIG_DIFF_CHANGED_VALUE)
+			}
+			if diff.From.VType != diff.To.VType {
+				diff.Kinds = append(diff.Kinds, CONFIG_DIFF_CHANGED_TYPE)
+			}
+			if diff.From.Status != diff.To.Status {
+				diff.Kinds = append(diff.Kinds, CONFIG_DIFF_CHANGED_STATUS)
+			}
+			if len(diff.Kinds) == 0 {
+				continue
+			}
+			if diff.From.V != diff.To.V && diff.From.VType == models.CONF_V_TYPE_CODE && diff.To.VType == models.CONF_V_TYPE_CODE {
+				diff.CodeDiff = diffCodeConfig(diff.From.V, diff.To.V)
+			}
+		}
+		diffs = append(diffs, diff)
+	}
+
+	return diffs
+}
+
+// expression-level diff of code configs in plain data form, conditions and values are compared as a whole
+// unless they are cond-values themselves
+func diffCodeConfig(fromV, toV string) []*codeExprDiff {
+	var from, to interface{}
+	json.Unmarshal([]byte(fromV), &from)
+	json.Unmarshal([]byte(toV), &to)
+
+	return diffCodeValue("", from, to, make([]*codeExprDiff, 0))
+}
+
+func diffCodeValue(path string, from, to interface{}, diffs []*codeExprDiff) []*codeExprDiff {
+	fromCondValues, fromOk := from.(map[string]interface{})
+	toCondValues, toOk := to.(map[string]interface{})
+	if fromOk && toOk {
+		_, fromOk = fromCondValues["cond-values"]
+		_, toOk = toCondValues["cond-values"]
+	}
+	if !fromOk || !toOk {
+		if !reflect.DeepEqual(from, to) {
+			diffs = append(diffs, &codeExprDiff{Path: path, Change: CODE_EXPR_DIFF_CHANGED, From: from, To: to})
+		}
+		return diffs
+	}
+
+	fromConds, _ := fromCondValues["cond-values"].([]interface{})
+	toConds, _ := toCondValues["cond-values"].([]interface{})
+	for ix := 0; ix < len(fromConds) || ix < len(toConds); ix++ {
+		condPath := joinCodeExprPath(path, fmt.Sprintf("cond-values[%d]", ix))
+		if ix >= len(fromConds) {
+			diffs = append(diffs, &codeExprDiff{Path: condPath, Change: CODE_EXPR_DIFF_ADDED, To: toConds[ix]})
+			continue
+		}
+		if ix >= len(toConds) {
+			diffs = append(diffs, &codeExprDiff{Path: condPath, Change: CODE_EXPR_DIFF_REMOVED, From: fromConds[ix]})
+			continue
+		}
+
+		fromCond, _ := fromConds[ix].(map[string]interface{})
+		toCond, _ := toConds[ix].(map[string]interface{})
+		if !reflect.DeepEqual(fromCond["condition"], toCond["condition"]) {
+			diffs = append(diffs, &codeExprDiff{
+				Path:   joinCodeExprPath(condPath, "condition"),
+				Change: CODE_EXPR_DIFF_CHANGED,
+				From:   fromCond["condition"],
+				To:     toCond["condition"],
+			})
+		}
+		diffs = diffCodeValue(joinCodeExprPath(condPath, "value"), fromCond["value"], toCond["value"], diffs)
+	}
+
+	return diffCodeValue(joinCodeExprPath(path, "default-value"), fromCondValues["default-value"], toCondValues["default-value"], diffs)
+}
+
+func joinCodeExprPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Instafig/Instafig/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffConfigSnapshots(t *testing.T) {
+	from := map[string]*configSnapshot{
+		"a": {K: "a", V: "1", VType: models.CONF_V_TYPE_INT, Status: models.CONF_STATUS_ACTIVE},
+		"b": {K: "b", V: "1", VType: models.CONF_V_TYPE_INT, Status: models.CONF_STATUS_ACTIVE},
+		"c": {K: "c", V: "1", VType: models.CONF_V_TYPE_INT, Status: models.CONF_STATUS_ACTIVE},
+		"d": {K: "d", V: "x", VType: models.CONF_V_TYPE_STRING, Status: models.CONF_STATUS_ACTIVE},
+	}
+	to := map[string]*configSnapshot{
+		"b": {K: "b", V: "1.5", VType: models.CONF_V_TYPE_FLOAT, Status: models.CONF_STATUS_ACTIVE},
+		"c": {K: "c", V: "1", VType: models.CONF_V_TYPE_INT, Status: models.CONF_STATUS_INACTIVE},
+		"d": {K: "d", V: "x", VType: models.CONF_V_TYPE_STRING, Status: models.CONF_STATUS_ACTIVE},
+		"e": {K: "e", V: "x", VType: models.CONF_V_TYPE_STRING, Status: models.CONF_STATUS_ACTIVE},
+	}
+
+	diffs := diffConfigSnapshots(from, to)
+	assert.True(t, len(diffs) == 4, "unchanged config must be skipped")
+	assert.True(t, diffs[0].K == "a" && diffs[0].Kinds[0] == CONFIG_DIFF_REMOVED)
+	assert.True(t, diffs[1].K == "b" && len(diffs[1].Kinds) == 2)
+	assert.True(t, diffs[1].Kinds[0] == CONFIG_DIFF_CHANGED_VALUE && diffs[1].Kinds[1] == CONFIG_DIFF_CHANGED_TYPE)
+	assert.True(t, diffs[2].K == "c" && len(diffs[2].Kinds) == 1 && diffs[2].Kinds[0] == CONFIG_DIFF_CHANGED_STATUS)
+	assert.True(t, diffs[3].K == "e" && diffs[3].Kinds[0] == CONFIG_DIFF_ADDED && diffs[3].From == nil)
+}
+
+func TestDiffCodeConfig(t *testing.T) {
+	from := `{"cond-values": [
+                 {"condition": {"func": "str=", "arguments": [{"symbol": "LANG"}, "zh"]}, "value": 1},
+                 {"condition": {"func": "str=", "arguments": [{"symbol": "LANG"}, "en"]},
+                  "value": {"cond-values": [{"condition": true, "value": 2}], "default-value": 3}}
+             ],
+             "default-value": 4}`
+	to := `{"cond-values": [
+                 {"condition": {"func": "str=", "arguments": [{"symbol": "LANG"}, "ja"]}, "value": 1},
+                 {"condition": {"func": "str=", "arguments": [{"symbol": "LANG"}, "en"]},
+                  "value": {"cond-values": [{"condition": true, "value": 5}], "default-value": 3}},
+                 {"condition": false, "value": 6}
+             ],
+             "default-value": 4}`
+
+	diffs := diffCodeConfig(from, to)
+	assert.True(t, len(diffs) == 3)
+	assert.True(t, diffs[0].Path == "cond-values[0].condition" && diffs[0].Change == CODE_EXPR_DIFF_CHANGED)
+	assert.True(t, diffs[1].Path == "cond-values[1].value.cond-values[0].value" && diffs[1].From == float64(2) && diffs[1].To == float64(5))
+	assert.True(t, diffs[2].Path == "cond-values[2]" && diffs[2].Change == CODE_EXPR_DIFF_ADDED)
+
+	diffs = diffCodeConfig(to, `"plain"`)
+	assert.True(t, len(diffs) == 1 && diffs[0].Path == "")
+
+	assert.True(t, len(diffCodeConfig(from, from)) == 0)
+}
+
+func TestReplayConfigUpdateHistory(t *testing.T) {
+	histories := []*models.ConfigUpdateHistory{
+		{ConfigKey: "1", Kind: models.CONFIG_UPDATE_KIND_NEW, K: "a", NewV: "1", NewVType: models.CONF_V_TYPE_INT, CreatedUTC: 10},
+		{ConfigKey: "2", Kind: models.CONFIG_UPDATE_KIND_NEW, K: "b", NewV: "x", NewVType: models.CONF_V_TYPE_STRING, CreatedUTC: 10},
+		{ConfigKey: "1", Kind: models.CONFIG_UPDATE_KIND_UPDATE, K: "a2", NewV: "2", NewVType: models.CONF_V_TYPE_INT, CreatedUTC: 20},
+		{ConfigKey: "2", Kind: models.CONFIG_UPDATE_KIND_HIDE, K: "b", NewV: "x", NewVType: models.CONF_V_TYPE_STRING, CreatedUTC: 30},
+	}
+
+	configs := replayConfigUpdateHistory(histories, 5)
+	assert.True(t, len(configs) == 0)
+
+	configs = replayConfigUpdateHistory(histories, 20)
+	assert.True(t, len(configs) == 2 && configs["a2"].V == "2" && configs["b"].Status == models.CONF_STATUS_ACTIVE)
+
+	configs = replayConfigUpdateHistory(histories, 30)
+	assert.True(t, configs["b"].Status == models.CONF_STATUS_INACTIVE)
+
+	diffs := diffConfigSnapshots(replayConfigUpdateHistory(histories, 10), configs)
+	assert.True(t, len(diffs) == 3)
+}
@@ -0,0 +1,383 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// rejects config writes during active config freezes of global or the app written,
+// must be after OpAuth and ConfWriteCheck
+func ConfigFreezeCheck(c *gin.Context) {
+	memConfMux.RLock()
+	user := memConfUsers[getOpUserKey(c)]
+	memConfMux.RUnlock()
+	if user != nil && user.FreezeOverride {
+		return
+	}
+
+	freeze := getActiveConfigFreeze(getConfigWriteAppKey(c), utils.GetNowSecond())
+	if freeze != nil {
+		Error(c, CONFIG_FROZEN, configFrozenMsg(freeze))
+		c.Abort()
+	}
+}
+
+func configFrozenMsg(freeze *models.ConfigFreeze) string {
+	scope := "all configs are"
+	if freeze.AppKey != "" {
+		scope = "configs of app are"
+	}
+
+	return fmt.Sprintf("%s frozen until %s: %s", scope, time.Unix(int64(freeze.EndUTC), 0).UTC().Format(time.RFC3339), freeze.Reason)
+}
+
+// app written by the request, peeked from "app_key" or config "key" of request body,
+// empty if unknown so that only global freezes apply
+func getConfigWriteAppKey(c *gin.Context) string {
+	bs, err := ioutil.ReadAll(c.Request.Body)
+	c.Request.Body.Close()
+	// handlers bind the body later
+	c.Request.Body = ioutil.NopCloser(bytes.NewReader(bs))
+	if err != nil {
+		return ""
+	}
+
+	var data struct {
+		AppKey string `json:"app_key"`
+		Key    string `json:"key"`
+	}
+	if err := json.Unmarshal(bs, &data); err != nil {
+		return ""
+	}
+	if data.AppKey != "" {
+		return data.AppKey
+	}
+
+	memConfMux.RLock()
+	defer memConfMux.RUnlock()
+	if config := memConfRawConfigs[data.Key]; config != nil {
+		return config.AppKey
+	}
+
+	return ""
+}
+
+// the active freeze of global or app ending last, nil if none
+func getActiveConfigFreeze(appKey string, now int) *models.ConfigFreeze {
+	memConfMux.RLock()
+	defer memConfMux.RUnlock()
+
+	var res *models.ConfigFreeze
+	for _, freeze := range memConfConfigFreezes {
+		if !isConfigFreezeActive(freeze, now) || (freeze.AppKey != "" && freeze.AppKey != appKey) {
+			continue
+		}
+		if res == nil || freeze.EndUTC > res.EndUTC {
+			res = freeze
+		}
+	}
+
+	return res
+}
+
+func isConfigFreezeActive(freeze *models.ConfigFreeze, now int) bool {
+	return freeze.Status == models.CONFIG_FREEZE_STATUS_ACTIVE && freeze.StartUTC <= now && now < freeze.EndUTC
+}
+
+type configFreezesByStartUTC []*models.ConfigFreeze
+
+func (freezes configFreezesByStartUTC) Len() int { return len(freezes) }
+func (freezes configFreezesByStartUTC) Swap(i, j int) {
+	freezes[i], freezes[j] = freezes[j], freezes[i]
+}
+func (freezes configFreezesByStartUTC) Less(i, j int) bool {
+	return freezes[i].StartUTC < freezes[j].StartUTC
+}
+
+// active and upcoming freezes, "app_key" limits to global ones and ones of the app,
+// "all=true" also returns ended and cancelled ones
+func GetConfigFreezes(c *gin.Context) {
+	appKey := c.Query("app_key")
+	all := c.Query("all") == "true"
+	now := utils.GetNowSecond()
+	res := make([]*models.ConfigFreeze, 0)
+
+	memConfMux.RLock()
+	for _, freeze := range memConfConfigFreezes {
+		if appKey != "" && freeze.AppKey != "" && freeze.AppKey != appKey {
+			continue
+		}
+		if !all && (freeze.Status != models.CONFIG_FREEZE_STATUS_ACTIVE || freeze.EndUTC <= now) {
+			continue
+		}
+		res = append(res, freeze)
+	}
+	memConfMux.RUnlock()
+
+	sort.Sort(configFreezesByStartUTC(res))
+	Success(c, res)
+}
+
+type configFreezeData struct {
+	AppKey   string `json:"app_key"` // empty for global freeze
+	StartUTC int    `json:"start_utc" binding:"required"`
+	EndUTC   int    `json:"end_utc" binding:"required"`
+	Reason   string `json:"reason" binding:"required"`
+}
+
+func NewConfigFreeze(c *gin.Context) {
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	data := &configFreezeData{}
+	if err := c.BindJSON(data); err != nil {
+		Error(c, BAD_POST_DATA, err.Error())
+		return
+	}
+
+	if !configFreezeManageCheck(c) {
+		return
+	}
+	if err := verifyConfigFreezeData(data); err != nil {
+		Error(c, BAD_REQUEST, err.Error())
+		return
+	}
+
+	freeze := &models.ConfigFreeze{
+		Key:        utils.GenerateKey(),
+		AppKey:     data.AppKey,
+		StartUTC:   data.StartUTC,
+		EndUTC:     data.EndUTC,
+		Reason:     data.Reason,
+		Status:     models.CONFIG_FREEZE_STATUS_ACTIVE,
+		CreatorKey: getOpUserKey(c),
+		CreatedUTC: utils.GetNowSecond(),
+	}
+	if _, err := updateConfigFreeze(freeze, nil); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	setAuditLog(c, AUDIT_ACTION_CONFIG_FREEZE_NEW, freeze.Key, nil, freeze)
+
+	res := map[string]interface{}{"key": freeze.Key}
+	if failedNodes := syncData2SlaveIfNeed(freeze, getOpUserKey(c)); len(failedNodes) > 0 {
+		res["failed_nodes"] = failedNodes
+	}
+	Success(c, res)
+}
+
+// changes time or reason of freeze, app of freeze can not be changed
+func UpdateConfigFreeze(c *gin.Context) {
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	var data struct {
+		configFreezeData
+		Key string `json:"key" binding:"required"`
+	}
+	if err := c.BindJSON(&data); err != nil {
+		Error(c, BAD_POST_DATA, err.Error())
+		return
+	}
+
+	if !configFreezeManageCheck(c) {
+		return
+	}
+
+	memConfMux.RLock()
+	oldFreeze := memConfConfigFreezes[data.Key]
+	memConfMux.RUnlock()
+	if oldFreeze == nil || oldFreeze.Status != models.CONFIG_FREEZE_STATUS_ACTIVE {
+		Error(c, BAD_REQUEST, "config freeze not exists: "+data.Key)
+		return
+	}
+	data.AppKey = oldFreeze.AppKey
+	if err := verifyConfigFreezeData(&data.configFreezeData); err != nil {
+		Error(c, BAD_REQUEST, err.Error())
+		return
+	}
+
+	freeze := *oldFreeze
+	freeze.StartUTC = data.StartUTC
+	freeze.EndUTC = data.EndUTC
+	freeze.Reason = data.Reason
+	if _, err := updateConfigFreeze(&freeze, nil); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	setAuditLog(c, AUDIT_ACTION_CONFIG_FREEZE_UPDATE, freeze.Key, oldFreeze, &freeze)
+
+	failedNodes := syncData2SlaveIfNeed(&freeze, getOpUserKey(c))
+	if len(failedNodes) > 0 {
+		Success(c, map[string]interface{}{"failed_nodes": failedNodes})
+	} else {
+		Success(c, nil)
+	}
+}
+
+func CancelConfigFreeze(c *gin.Context) {
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	if !configFreezeManageCheck(c) {
+		return
+	}
+
+	memConfMux.RLock()
+	oldFreeze := memConfConfigFreezes[c.Param("key")]
+	memConfMux.RUnlock()
+	if oldFreeze == nil {
+		Error(c, BAD_REQUEST, "config freeze not exists: "+c.Param("key"))
+		return
+	}
+	if oldFreeze.Status == models.CONFIG_FREEZE_STATUS_CANCELLED {
+		Success(c, nil)
+		return
+	}
+
+	freeze := *oldFreeze
+	freeze.Status = models.CONFIG_FREEZE_STATUS_CANCELLED
+	if _, err := updateConfigFreeze(&freeze, nil); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	setAuditLog(c, AUDIT_ACTION_CONFIG_FREEZE_CANCEL, freeze.Key, oldFreeze, &freeze)
+
+	failedNodes := syncData2SlaveIfNeed(&freeze, getOpUserKey(c))
+	if len(failedNodes) > 0 {
+		Success(c, map[string]interface{}{"failed_nodes": failedNodes})
+	} else {
+		Success(c, nil)
+	}
+}
+
+// only admins manage freezes
+func configFreezeManageCheck(c *gin.Context) bool {
+	memConfMux.RLock()
+	opUser := memConfUsers[getOpUserKey(c)]
+	memConfMux.RUnlock()
+	if !isAdminUser(opUser) {
+		Error(c, NOT_PERMITTED, "can not manage config freezes as current user is not admin")
+		return false
+	}
+
+	return true
+}
+
+func verifyConfigFreezeData(data *configFreezeData) error {
+	if data.AppKey != "" {
+		memConfMux.RLock()
+		app := memConfApps[data.AppKey]
+		memConfMux.RUnlock()
+		if app == nil {
+			return fmt.Errorf("app not exists: %s", data.AppKey)
+		}
+	}
+	if data.EndUTC <= data.StartUTC {
+		return fmt.Errorf("end_utc must be after start_utc")
+	}
+	if data.EndUTC <= utils.GetNowSecond() {
+		return fmt.Errorf("end_utc must be in future")
+	}
+	if strings.TrimSpace(data.Reason) == "" {
+		return fmt.Errorf("config freeze reason required")
+	}
+
+	return nil
+}
+
+func updateConfigFreeze(freeze *models.ConfigFreeze, newDataVersion *models.DataVersion) (*models.ConfigFreeze, error) {
+	s := models.NewSession()
+	defer s.Close()
+	if err := s.Begin(); err != nil {
+		s.Rollback()
+		return nil, err
+	}
+
+	node := *memConfNodes[conf.ClientAddr]
+	oldFreeze := memConfConfigFreezes[freeze.Key]
+
+	if newDataVersion == nil {
+		newDataVersion = genNewDataVersion(memConfDataVersion)
+	}
+	if err := updateNodeDataVersion(s, &node, newDataVersion); err != nil {
+		s.Rollback()
+		return nil, err
+	}
+
+	if oldFreeze == nil {
+		if err := models.InsertRow(s, freeze); err != nil {
+			s.Rollback()
+			return nil, err
+		}
+	} else {
+		if err := models.UpdateDBModel(s, freeze); err != nil {
+			s.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := s.Commit(); err != nil {
+		s.Rollback()
+		return nil, err
+	}
+
+	updateMemConf(freeze, newDataVersion, &node)
+
+	return freeze, nil
+}
+
+// grants or takes back writing configs during config freezes
+func UpdateUserFreezeOverride(c *gin.Context) {
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	var data struct {
+		UserKey        string `json:"user_key" binding:"required"`
+		FreezeOverride bool   `json:"freeze_override"`
+	}
+	if err := c.BindJSON(&data); err != nil {
+		Error(c, BAD_POST_DATA, err.Error())
+		return
+	}
+
+	if !configFreezeManageCheck(c) {
+		return
+	}
+
+	oldUser := memConfUsers[data.UserKey]
+	if oldUser == nil {
+		Error(c, USER_NOT_EXIST, "user not exists: "+data.UserKey)
+		return
+	}
+	if oldUser.FreezeOverride == data.FreezeOverride {
+		Success(c, nil)
+		return
+	}
+
+	user := *oldUser
+	user.FreezeOverride = data.FreezeOverride
+	if _, err := updateUser(&user, nil); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	setAuditLog(c, AUDIT_ACTION_USER_FREEZE_OVERRIDE, user.Key, oldUser, &user)
+
+	go TriggerUserWebHooks(WEBHOOK_EVENT_USER_UPDATE, &user, getOpUserKey(c))
+	failedNodes := syncData2SlaveIfNeed(&user, getOpUserKey(c))
+	if len(failedNodes) > 0 {
+		Success(c, map[string]interface{}{"failed_nodes": failedNodes})
+	} else {
+		Success(c, nil)
+	}
+}
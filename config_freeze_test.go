@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigFreeze(t *testing.T) {
+	err := _clearModelData()
+	assert.True(t, err == nil, "must correctly clear data")
+	loadAllData()
+	initNodeData()
+
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	now := utils.GetNowSecond()
+	assert.True(t, verifyConfigFreezeData(&configFreezeData{StartUTC: now, EndUTC: now + 60, Reason: "release"}) == nil)
+	assert.True(t, verifyConfigFreezeData(&configFreezeData{StartUTC: now, EndUTC: now, Reason: "release"}) != nil)
+	assert.True(t, verifyConfigFreezeData(&configFreezeData{StartUTC: now - 60, EndUTC: now - 1, Reason: "release"}) != nil)
+	assert.True(t, verifyConfigFreezeData(&configFreezeData{StartUTC: now, EndUTC: now + 60, Reason: " "}) != nil)
+	assert.True(t, verifyConfigFreezeData(&configFreezeData{AppKey: "no-app", StartUTC: now, EndUTC: now + 60, Reason: "release"}) != nil)
+
+	appFreeze := &models.ConfigFreeze{
+		Key:      utils.GenerateKey(),
+		AppKey:   "app1",
+		StartUTC: now - 10,
+		EndUTC:   now + 60,
+		Reason:   "app release",
+		Status:   models.CONFIG_FREEZE_STATUS_ACTIVE,
+	}
+	_, err = updateConfigFreeze(appFreeze, nil)
+	assert.True(t, err == nil, "must correctly add config freeze")
+
+	upcomingFreeze := &models.ConfigFreeze{
+		Key:      utils.GenerateKey(),
+		StartUTC: now + 30,
+		EndUTC:   now + 90,
+		Reason:   "global release",
+		Status:   models.CONFIG_FREEZE_STATUS_ACTIVE,
+	}
+	_, err = updateConfigFreeze(upcomingFreeze, nil)
+	assert.True(t, err == nil, "must correctly add config freeze")
+
+	freezes, err := models.GetAllConfigFreezes(nil)
+	assert.True(t, err == nil && len(freezes) == 2)
+
+	assert.True(t, getActiveConfigFreeze("app1", now).Key == appFreeze.Key)
+	assert.True(t, getActiveConfigFreeze("app2", now) == nil, "freeze of other app must not apply")
+	assert.True(t, getActiveConfigFreeze("app2", now+30).Key == upcomingFreeze.Key, "global freeze must apply to all apps")
+	assert.True(t, getActiveConfigFreeze("app1", now+30).Key == upcomingFreeze.Key, "freeze ending last must be returned")
+	assert.True(t, getActiveConfigFreeze("app1", now+90) == nil, "ended freeze must not apply")
+
+	cancelled := *appFreeze
+	cancelled.Status = models.CONFIG_FREEZE_STATUS_CANCELLED
+	_, err = updateConfigFreeze(&cancelled, nil)
+	assert.True(t, err == nil)
+	assert.True(t, getActiveConfigFreeze("app1", now) == nil, "cancelled freeze must not apply")
+
+	_clearModelData()
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/Instafig/Instafig/models"
+)
+
+// supported subset of JSON Schema for config values: type(integer, number, string, boolean),
+// minimum/maximum for numbers, minLength/maxLength/pattern for strings and enum for all
+type configSchema struct {
+	Type      string        `json:"type"`
+	Minimum   *float64      `json:"minimum"`
+	Maximum   *float64      `json:"maximum"`
+	Enum      []interface{} `json:"enum"`
+	Pattern   string        `json:"pattern"`
+	MinLength *int          `json:"minLength"`
+	MaxLength *int          `json:"maxLength"`
+
+	patternRegexp *regexp.Regexp
+}
+
+const (
+	CONF_SCHEMA_TYPE_INTEGER = "integer"
+	CONF_SCHEMA_TYPE_NUMBER  = "number"
+	CONF_SCHEMA_TYPE_STRING  = "string"
+	CONF_SCHEMA_TYPE_BOOLEAN = "boolean"
+)
+
+var supportedConfigSchemaKeywords = map[string]bool{
+	"type":      true,
+	"minimum":   true,
+	"maximum":   true,
+	"enum":      true,
+	"pattern":   true,
+	"minLength": true,
+	"maxLength": true,
+	"$schema":   true,
+	"title":     true,
+}
+
+func parseConfigSchema(str string) (*configSchema, error) {
+	var keywords map[string]interface{}
+	if err := json.Unmarshal([]byte(str), &keywords); err != nil {
+		return nil, fmt.Errorf("schema must be a json object: %s", err.Error())
+	}
+	for k := range keywords {
+		if !supportedConfigSchemaKeywords[k] {
+			return nil, fmt.Errorf("unsupported schema keyword: %s", k)
+		}
+	}
+
+	schema := &configSchema{}
+	if err := json.Unmarshal([]byte(str), schema); err != nil {
+		return nil, fmt.Errorf("bad schema format: %s", err.Error())
+	}
+
+	switch schema.Type {
+	case "", CONF_SCHEMA_TYPE_INTEGER, CONF_SCHEMA_TYPE_NUMBER, CONF_SCHEMA_TYPE_STRING, CONF_SCHEMA_TYPE_BOOLEAN:
+	default:
+		return nil, fmt.Errorf("unsupported schema type: %s", schema.Type)
+	}
+
+	if schema.Minimum != nil && schema.Maximum != nil && *schema.Minimum > *schema.Maximum {
+		return nil, fmt.Errorf("schema minimum is bigger than maximum")
+	}
+	if schema.MinLength != nil && schema.MaxLength != nil && *schema.MinLength > *schema.MaxLength {
+		return nil, fmt.Errorf("schema minLength is bigger than maxLength")
+	}
+
+	if schema.Pattern != "" {
+		var err error
+		if schema.patternRegexp, err = regexp.Compile(schema.Pattern); err != nil {
+			return nil, fmt.Errorf("bad schema pattern: %s", err.Error())
+		}
+	}
+
+	return schema, nil
+}
+
+// value must be one of: string, float64, bool, as decoded by encoding/json
+func (schema *configSchema) validate(value interface{}) error {
+	switch schema.Type {
+	case CONF_SCHEMA_TYPE_INTEGER:
+		f, ok := value.(float64)
+		if !ok || f != math.Trunc(f) {
+			return fmt.Errorf("value %v is not integer", value)
+		}
+	case CONF_SCHEMA_TYPE_NUMBER:
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("value %v is not number", value)
+		}
+	case CONF_SCHEMA_TYPE_STRING:
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("value %v is not string", value)
+		}
+	case CONF_SCHEMA_TYPE_BOOLEAN:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("value %v is not boolean", value)
+		}
+	}
+
+	switch val := value.(type) {
+	case float64:
+		if schema.Minimum != nil && val < *schema.Minimum {
+			return fmt.Errorf("value %v is less than minimum %v", val, *schema.Minimum)
+		}
+		if schema.Maximum != nil && val > *schema.Maximum {
+			return fmt.Errorf("value %v is bigger than maximum %v", val, *schema.Maximum)
+		}
+	case string:
+		length := utf8.RuneCountInString(val)
+		if schema.MinLength != nil && length < *schema.MinLength {
+			return fmt.Errorf("value [%s] is shorter than minLength %d", val, *schema.MinLength)
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			return fmt.Errorf("value [%s] is longer than maxLength %d", val, *schema.MaxLength)
+		}
+		if schema.patternRegexp != nil && !schema.patternRegexp.MatchString(val) {
+			return fmt.Errorf("value [%s] does not match pattern %s", val, schema.Pattern)
+		}
+	}
+
+	if len(schema.Enum) > 0 {
+		for _, e := range schema.Enum {
+			if reflect.DeepEqual(e, value) {
+				return nil
+			}
+		}
+		return fmt.Errorf("value %v is not in enum %v", value, schema.Enum)
+	}
+
+	return nil
+}
+
+// collect all static branch values of a code config in plain data form,
+// values which are only known after evaluation (func call, symbol) are ignored
+func collectCodeBranchValues(data interface{}, values []interface{}) []interface{} {
+	switch val := data.(type) {
+	case nil:
+		return values
+	case map[string]interface{}:
+		conds, ok := val["cond-values"]
+		if !ok {
+			return values
+		}
+		if conds, ok := conds.([]interface{}); ok {
+			for _, cond := range conds {
+				if cond, ok := cond.(map[string]interface{}); ok {
+					values = collectCodeBranchValues(cond["value"], values)
+				}
+			}
+		}
+		return collectCodeBranchValues(val["default-value"], values)
+	case []interface{}:
+		return values
+	default:
+		return append(values, val)
+	}
+}
+
+func verifyConfigValueSchema(vType, v, schemaStr string) error {
+	if schemaStr == "" {
+		return nil
+	}
+
+	schema, err := parseConfigSchema(schemaStr)
+	if err != nil {
+		return err
+	}
+
+	switch vType {
+	case models.CONF_V_TYPE_INT, models.CONF_V_TYPE_FLOAT:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("config value not number")
+		}
+		if err = schema.validate(f); err != nil {
+			return fmt.Errorf("config value violates schema: %s", err.Error())
+		}
+	case models.CONF_V_TYPE_STRING:
+		if err = schema.validate(v); err != nil {
+			return fmt.Errorf("config value violates schema: %s", err.Error())
+		}
+	case models.CONF_V_TYPE_CODE:
+		var data interface{}
+		if err = json.Unmarshal([]byte(v), &data); err != nil {
+			return fmt.Errorf("bad code config value: %s", err.Error())
+		}
+		for _, val := range collectCodeBranchValues(data, nil) {
+			if err = schema.validate(val); err != nil {
+				return fmt.Errorf("config branch value violates schema: %s", err.Error())
+			}
+		}
+	default:
+		return fmt.Errorf("schema is not supported for %s config", vType)
+	}
+
+	return nil
+}
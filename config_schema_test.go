@@ -0,0 +1,83 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Instafig/Instafig/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConfigSchema(t *testing.T) {
+	_, err := parseConfigSchema(`{"type": "integer", "minimum": 1, "maximum": 10}`)
+	assert.True(t, err == nil)
+
+	_, err = parseConfigSchema(`{"type": "string", "enum": ["a", "b"], "pattern": "^[a-z]+$"}`)
+	assert.True(t, err == nil)
+
+	_, err = parseConfigSchema(`not json`)
+	assert.True(t, err != nil)
+
+	_, err = parseConfigSchema(`{"type": "object"}`)
+	assert.True(t, err != nil, "object type is not supported")
+
+	_, err = parseConfigSchema(`{"properties": {}}`)
+	assert.True(t, err != nil, "unknown keyword must be rejected")
+
+	_, err = parseConfigSchema(`{"minimum": 10, "maximum": 1}`)
+	assert.True(t, err != nil)
+
+	_, err = parseConfigSchema(`{"pattern": "(["}`)
+	assert.True(t, err != nil)
+}
+
+func TestVerifyConfigValueSchema(t *testing.T) {
+	intSchema := `{"type": "integer", "minimum": 1, "maximum": 10}`
+	assert.True(t, verifyConfigValueSchema(models.CONF_V_TYPE_INT, "5", intSchema) == nil)
+	assert.True(t, verifyConfigValueSchema(models.CONF_V_TYPE_INT, "0", intSchema) != nil)
+	assert.True(t, verifyConfigValueSchema(models.CONF_V_TYPE_INT, "11", intSchema) != nil)
+	assert.True(t, verifyConfigValueSchema(models.CONF_V_TYPE_FLOAT, "1.5", intSchema) != nil)
+	assert.True(t, verifyConfigValueSchema(models.CONF_V_TYPE_FLOAT, "1.5", `{"type": "number", "maximum": 2}`) == nil)
+
+	strSchema := `{"type": "string", "enum": ["red", "green"]}`
+	assert.True(t, verifyConfigValueSchema(models.CONF_V_TYPE_STRING, "red", strSchema) == nil)
+	assert.True(t, verifyConfigValueSchema(models.CONF_V_TYPE_STRING, "blue", strSchema) != nil)
+	assert.True(t, verifyConfigValueSchema(models.CONF_V_TYPE_STRING, "abc", `{"pattern": "^a", "maxLength": 3}`) == nil)
+	assert.True(t, verifyConfigValueSchema(models.CONF_V_TYPE_STRING, "abcd", `{"pattern": "^a", "maxLength": 3}`) != nil)
+	assert.True(t, verifyConfigValueSchema(models.CONF_V_TYPE_STRING, "bcd", `{"pattern": "^a"}`) != nil)
+
+	assert.True(t, verifyConfigValueSchema(models.CONF_V_TYPE_TEMPLATE, "app", strSchema) != nil)
+	assert.True(t, verifyConfigValueSchema(models.CONF_V_TYPE_TEMPLATE, "app", "") == nil)
+
+	code := `{"cond-values": [
+                 {"condition": {"func": "str=", "arguments": [{"symbol": "LANG"}, "zh"]}, "value": "red"},
+                 {"condition": {"func": "str=", "arguments": [{"symbol": "LANG"}, "en"]}, "value": "green"}
+             ],
+             "default-value": "blue"}`
+	assert.True(t, verifyConfigValueSchema(models.CONF_V_TYPE_CODE, code, strSchema) != nil, "default value violates schema")
+	assert.True(t, verifyConfigValueSchema(models.CONF_V_TYPE_CODE, code, `{"type": "string"}`) == nil)
+	assert.True(t, verifyConfigValueSchema(models.CONF_V_TYPE_CODE, code, `{"type": "integer"}`) != nil)
+}
+
+func TestCollectCodeBranchValues(t *testing.T) {
+	data := map[string]interface{}{
+		"cond-values": []interface{}{
+			map[string]interface{}{"condition": true, "value": float64(1)},
+			map[string]interface{}{
+				"condition": false,
+				"value": map[string]interface{}{
+					"cond-values":   []interface{}{map[string]interface{}{"condition": true, "value": float64(2)}},
+					"default-value": float64(3),
+				},
+			},
+			map[string]interface{}{"condition": true, "value": map[string]interface{}{"symbol": "LANG"}},
+		},
+		"default-value": nil,
+	}
+
+	values := collectCodeBranchValues(data, nil)
+	assert.True(t, len(values) == 3)
+	assert.True(t, values[0] == float64(1) && values[1] == float64(2) && values[2] == float64(3))
+
+	assert.True(t, len(collectCodeBranchValues("abc", nil)) == 1)
+	assert.True(t, len(collectCodeBranchValues([]interface{}{"abc"}, nil)) == 0)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-version"
 	"github.com/zhemao/glisp/interpreter"
@@ -260,6 +261,8 @@ func EvalDynValToSexp(code *DynVal, cdata *ClientData) (glisp.Sexp, error) {
 
 	SetClientData(env, cdata)
 	//dval := NewDynValFromString(code, env)
+	now := time.Now()
+	defer func() { glispEvalDuration.Observe(time.Since(now).Seconds()) }()
 	return code.Execute(env)
 }
 
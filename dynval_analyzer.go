@@ -0,0 +1,304 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/Instafig/Instafig/models"
+)
+
+const (
+	CODE_ISSUE_LEVEL_ERROR   = "error"
+	CODE_ISSUE_LEVEL_WARNING = "warning"
+
+	CODE_ISSUE_KIND_SYNTAX             = "syntax"
+	CODE_ISSUE_KIND_SCHEMA             = "schema"
+	CODE_ISSUE_KIND_UNREACHABLE_BRANCH = "unreachable_branch"
+	CODE_ISSUE_KIND_MIXED_VALUE_TYPES  = "mixed_value_types"
+	CODE_ISSUE_KIND_NO_DEFAULT_VALUE   = "no_default_value"
+	CODE_ISSUE_KIND_UNSEEN_SYMBOL      = "unseen_symbol"
+)
+
+type codeConfigIssue struct {
+	Level string `json:"level"`
+	Kind  string `json:"kind"`
+	Path  string `json:"path"`
+	Msg   string `json:"msg"`
+}
+
+// check config value without saving it, for code config the cond-values structure is analyzed
+func checkConfigValue(appKey, vType, v, schema string) []*codeConfigIssue {
+	issues := make([]*codeConfigIssue, 0)
+
+	if !models.IsValidConfValueType(vType) {
+		return append(issues, &codeConfigIssue{
+			Level: CODE_ISSUE_LEVEL_ERROR,
+			Kind:  CODE_ISSUE_KIND_SYNTAX,
+			Msg:   "unknown conf type: " + vType,
+		})
+	}
+
+	// same checks as saving config, so a valid check means config can be saved
+	memConfMux.RLock()
+	err := verifyConfigValue(appKey, vType, v)
+	memConfMux.RUnlock()
+	if err != nil {
+		return append(issues, &codeConfigIssue{
+			Level: CODE_ISSUE_LEVEL_ERROR,
+			Kind:  CODE_ISSUE_KIND_SYNTAX,
+			Msg:   err.Error(),
+		})
+	}
+
+	if err := verifyConfigValueSchema(vType, v, schema); err != nil {
+		issues = append(issues, &codeConfigIssue{
+			Level: CODE_ISSUE_LEVEL_ERROR,
+			Kind:  CODE_ISSUE_KIND_SCHEMA,
+			Msg:   err.Error(),
+		})
+	}
+
+	if vType == models.CONF_V_TYPE_CODE {
+		var data interface{}
+		json.Unmarshal([]byte(v), &data)
+		issues = append(issues, analyzeCodeConfig(data, appKey)...)
+	}
+
+	return issues
+}
+
+// analyze code config in cond-values plain data form, see condValuesToPlainData
+func analyzeCodeConfig(data interface{}, appKey string) []*codeConfigIssue {
+	issues := make([]*codeConfigIssue, 0)
+
+	condValues, ok := data.(map[string]interface{})
+	if !ok {
+		return issues
+	}
+	if _, ok = condValues["cond-values"]; !ok {
+		return issues
+	}
+
+	issues = analyzeCondValues(condValues, "", appKey, issues)
+
+	typs := map[string]bool{}
+	for _, val := range collectCodeBranchValues(data, nil) {
+		typs[plainValueTypeName(val)] = true
+	}
+	if len(typs) > 1 {
+		var names []string
+		for typ := range typs {
+			names = append(names, typ)
+		}
+		sort.Strings(names)
+		issues = append(issues, &codeConfigIssue{
+			Level: CODE_ISSUE_LEVEL_WARNING,
+			Kind:  CODE_ISSUE_KIND_MIXED_VALUE_TYPES,
+			Msg:   "branch values have mixed types: " + strings.Join(names, ", "),
+		})
+	}
+
+	return issues
+}
+
+func analyzeCondValues(condValues map[string]interface{}, path string, appKey string, issues []*codeConfigIssue) []*codeConfigIssue {
+	conds, _ := condValues["cond-values"].([]interface{})
+
+	alwaysTrueIdx := -1
+	for idx, cond := range conds {
+		condPath := fmt.Sprintf("%scond-values[%d]", path, idx)
+		item, ok := cond.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if alwaysTrueIdx >= 0 {
+			issues = append(issues, &codeConfigIssue{
+				Level: CODE_ISSUE_LEVEL_WARNING,
+				Kind:  CODE_ISSUE_KIND_UNREACHABLE_BRANCH,
+				Path:  condPath,
+				Msg:   fmt.Sprintf("branch is unreachable as condition of branch %d is always true", alwaysTrueIdx),
+			})
+		} else if isAlwaysTrueCondition(item["condition"]) {
+			alwaysTrueIdx = idx
+		}
+
+		for _, symbol := range collectConditionSymbols(item["condition"], nil) {
+			if !isClientSymbolSeen(symbol, appKey) {
+				issues = append(issues, &codeConfigIssue{
+					Level: CODE_ISSUE_LEVEL_WARNING,
+					Kind:  CODE_ISSUE_KIND_UNSEEN_SYMBOL,
+					Path:  condPath + ".condition",
+					Msg:   fmt.Sprintf("no client has ever sent a value for symbol %s", symbol),
+				})
+			}
+		}
+
+		if sub, ok := item["value"].(map[string]interface{}); ok {
+			if _, ok = sub["cond-values"]; ok {
+				issues = analyzeCondValues(sub, condPath+".value.", appKey, issues)
+			}
+		}
+	}
+
+	dft, hasDefault := condValues["default-value"]
+	hasDefault = hasDefault && dft != nil
+	switch {
+	case alwaysTrueIdx >= 0 && hasDefault:
+		issues = append(issues, &codeConfigIssue{
+			Level: CODE_ISSUE_LEVEL_WARNING,
+			Kind:  CODE_ISSUE_KIND_UNREACHABLE_BRANCH,
+			Path:  path + "default-value",
+			Msg:   fmt.Sprintf("default value is unreachable as condition of branch %d is always true", alwaysTrueIdx),
+		})
+	case alwaysTrueIdx < 0 && !hasDefault:
+		issues = append(issues, &codeConfigIssue{
+			Level: CODE_ISSUE_LEVEL_WARNING,
+			Kind:  CODE_ISSUE_KIND_NO_DEFAULT_VALUE,
+			Path:  path + "default-value",
+			Msg:   "no default value, clients will get null if no condition matches",
+		})
+	}
+
+	if sub, ok := dft.(map[string]interface{}); ok {
+		if _, ok = sub["cond-values"]; ok {
+			issues = analyzeCondValues(sub, path+"default-value.", appKey, issues)
+		}
+	}
+
+	return issues
+}
+
+// truthiness follows glisp: false, null and integer 0 are false, all other constants are true
+func isAlwaysTrueCondition(cond interface{}) bool {
+	switch val := cond.(type) {
+	case bool:
+		return val
+	case float64:
+		return val != 0
+	case string:
+		return true
+	case map[string]interface{}:
+		fn, _ := val["func"].(string)
+		args, _ := val["arguments"].([]interface{})
+		switch fn {
+		case "and":
+			for _, arg := range args {
+				if !isAlwaysTrueCondition(arg) {
+					return false
+				}
+			}
+			return len(args) > 0
+		case "or":
+			for _, arg := range args {
+				if isAlwaysTrueCondition(arg) {
+					return true
+				}
+			}
+		case "not":
+			return len(args) == 1 && isAlwaysFalseCondition(args[0])
+		}
+	}
+
+	return false
+}
+
+func isAlwaysFalseCondition(cond interface{}) bool {
+	switch val := cond.(type) {
+	case nil:
+		return true
+	case bool:
+		return !val
+	case float64:
+		return val == 0
+	case map[string]interface{}:
+		fn, _ := val["func"].(string)
+		args, _ := val["arguments"].([]interface{})
+		switch fn {
+		case "and":
+			for _, arg := range args {
+				if isAlwaysFalseCondition(arg) {
+					return true
+				}
+			}
+		case "or":
+			for _, arg := range args {
+				if !isAlwaysFalseCondition(arg) {
+					return false
+				}
+			}
+			return len(args) > 0
+		case "not":
+			return len(args) == 1 && isAlwaysTrueCondition(args[0])
+		}
+	}
+
+	return false
+}
+
+func collectConditionSymbols(cond interface{}, symbols []string) []string {
+	switch val := cond.(type) {
+	case map[string]interface{}:
+		if symbol, ok := val["symbol"].(string); ok {
+			for _, s := range symbols {
+				if s == symbol {
+					return symbols
+				}
+			}
+			return append(symbols, symbol)
+		}
+		if args, ok := val["arguments"].([]interface{}); ok {
+			for _, arg := range args {
+				symbols = collectConditionSymbols(arg, symbols)
+			}
+		}
+	case []interface{}:
+		for _, item := range val {
+			symbols = collectConditionSymbols(item, symbols)
+		}
+	}
+
+	return symbols
+}
+
+// symbols not recorded in client_request_data are always treated as seen
+func isClientSymbolSeen(symbol, appKey string) bool {
+	memConfClientMux.RLock()
+	defer memConfClientMux.RUnlock()
+
+	switch symbol {
+	case GLISP_SYMBOL_TYPE_LANG:
+		return len(memConfClientLang) > 0
+	case GLISP_SYMBOL_TYPE_OS_TYPE:
+		return len(memConfClientOSType) > 0
+	case GLISP_SYMBOL_TYPE_OS_VERSION:
+		return len(memConfClientOSV) > 0
+	case GLISP_SYMBOL_TYPE_TIMEZONE:
+		return len(memConfClientTimezone) > 0
+	case GLISP_SYMBOL_TYPE_NETWORK:
+		return len(memConfClientNetwork) > 0
+	case GLISP_SYMBOL_TYPE_APP_VERSION:
+		return len(memConfClientAppVersion[appKey]) > 0
+	}
+
+	return true
+}
+
+func plainValueTypeName(val interface{}) string {
+	switch v := val.(type) {
+	case string:
+		return models.CONF_V_TYPE_STRING
+	case float64:
+		if v == math.Trunc(v) {
+			return models.CONF_V_TYPE_INT
+		}
+		return models.CONF_V_TYPE_FLOAT
+	case bool:
+		return "bool"
+	default:
+		return "unknown"
+	}
+}
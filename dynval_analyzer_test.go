@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Instafig/Instafig/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func _issueKinds(issues []*codeConfigIssue) map[string]int {
+	kinds := map[string]int{}
+	for _, issue := range issues {
+		kinds[issue.Kind]++
+	}
+	return kinds
+}
+
+func _analyzeCode(t *testing.T, code, appKey string) []*codeConfigIssue {
+	var data interface{}
+	assert.True(t, json.Unmarshal([]byte(code), &data) == nil)
+	return analyzeCodeConfig(data, appKey)
+}
+
+func TestAnalyzeCodeConfig(t *testing.T) {
+	fillMemClientRequestData([]*models.ClientReqeustData{
+		{AppKey: "app", Symbol: GLISP_SYMBOL_TYPE_LANG, Value: "zh"},
+	})
+	defer loadAllData()
+
+	code := `{"cond-values": [
+                 {"condition": {"func": "str=", "arguments": [{"symbol": "LANG"}, "zh"]}, "value": 1},
+                 {"condition": {"func": "str=", "arguments": [{"symbol": "LANG"}, "en"]}, "value": 2.5}
+             ],
+             "default-value": 3}`
+	kinds := _issueKinds(_analyzeCode(t, code, "app"))
+	assert.True(t, len(kinds) == 1 && kinds[CODE_ISSUE_KIND_MIXED_VALUE_TYPES] == 1, "int and float branches")
+
+	code = `{"cond-values": [
+                 {"condition": {"func": "or", "arguments": [false, true]}, "value": "a"},
+                 {"condition": {"func": "str=", "arguments": [{"symbol": "LANG"}, "en"]}, "value": "b"}
+             ],
+             "default-value": "c"}`
+	issues := _analyzeCode(t, code, "app")
+	kinds = _issueKinds(issues)
+	assert.True(t, kinds[CODE_ISSUE_KIND_UNREACHABLE_BRANCH] == 2, "second branch and default value")
+	assert.True(t, issues[0].Path == "cond-values[1]")
+
+	code = `{"cond-values": [
+                 {"condition": {"func": "str=", "arguments": [{"symbol": "NETWORK"}, "wifi"]}, "value": "a"},
+                 {"condition": 0, "value": "b"},
+                 {"condition": true, "value": {
+                     "cond-values": [{"condition": {"func": "not", "arguments": [false]}, "value": "c"}],
+                     "default-value": "d"}}
+             ]}`
+	kinds = _issueKinds(_analyzeCode(t, code, "app"))
+	assert.True(t, kinds[CODE_ISSUE_KIND_UNSEEN_SYMBOL] == 1)
+	assert.True(t, kinds[CODE_ISSUE_KIND_UNREACHABLE_BRANCH] == 1, "nested default value")
+	assert.True(t, kinds[CODE_ISSUE_KIND_NO_DEFAULT_VALUE] == 0, "last branch is always true")
+
+	code = `{"cond-values": [{"condition": {"func": "str=", "arguments": [{"symbol": "LANG"}, "en"]}, "value": true}]}`
+	kinds = _issueKinds(_analyzeCode(t, code, "app"))
+	assert.True(t, len(kinds) == 1 && kinds[CODE_ISSUE_KIND_NO_DEFAULT_VALUE] == 1)
+
+	assert.True(t, len(_analyzeCode(t, `"abc"`, "app")) == 0)
+}
+
+func TestCheckConfigValue(t *testing.T) {
+	issues := checkConfigValue("app", models.CONF_V_TYPE_CODE, `{"cond-values": `, "")
+	assert.True(t, len(issues) == 1 && issues[0].Kind == CODE_ISSUE_KIND_SYNTAX)
+
+	issues = checkConfigValue("app", models.CONF_V_TYPE_INT, "11", `{"maximum": 10}`)
+	assert.True(t, len(issues) == 1 && issues[0].Level == CODE_ISSUE_LEVEL_ERROR && issues[0].Kind == CODE_ISSUE_KIND_SCHEMA)
+
+	issues = checkConfigValue("app", models.CONF_V_TYPE_INT, "abc", "")
+	assert.True(t, len(issues) == 1 && issues[0].Kind == CODE_ISSUE_KIND_SYNTAX, "int value must be checked as saving config")
+
+	issues = checkConfigValue("app", models.CONF_V_TYPE_FLOAT, "1.2.3", "")
+	assert.True(t, len(issues) == 1 && issues[0].Kind == CODE_ISSUE_KIND_SYNTAX)
+
+	issues = checkConfigValue("app", "bad", "1", "")
+	assert.True(t, len(issues) == 1 && issues[0].Level == CODE_ISSUE_LEVEL_ERROR)
+
+	assert.True(t, len(checkConfigValue("app", models.CONF_V_TYPE_STRING, "abc", "")) == 0)
+}
@@ -181,8 +181,10 @@ func newGLisp() *glisp.Glisp {
 func getGLispEnv() (env *glisp.Glisp) {
 	select {
 	case env = <-glispEnvBuffer:
+		glispEnvPoolCounter.Inc("hit")
 		return
 	default:
+		glispEnvPoolCounter.Inc("miss")
 		env = newGLisp()
 	}
 	return
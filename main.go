@@ -81,24 +81,48 @@ func main() {
 	// client api
 	clientAPIGroup := ginIns.Group("/client")
 	{
-		clientAPIGroup.GET("/config", StatisticHandler, ClientConf)
+		clientAPIGroup.GET("/config", MetricsHandler, StatisticHandler, ClientConf)
 	}
 	// compatible with old awconfig
-	ginIns.GET("/conf", StatisticHandler, ClientConf)
+	ginIns.GET("/conf", MetricsHandler, StatisticHandler, ClientConf)
+
+	// prometheus metrics, label values have app keys so scrapers must use api token
+	ginIns.GET("/metrics", OpAuth, MetricsExport)
 
 	// op api
-	opAPIGroup := ginIns.Group("/op")
+	opAPIGroup := ginIns.Group("/op", AuditLogHandler)
 	{
 		opAPIGroup.POST("/login", Login)
+		opAPIGroup.GET("/auth/providers", GetAuthProviders)
+		if conf.OIDCEnable {
+			opAPIGroup.GET("/auth/oidc/login", InitUserCheck, OIDCLogin)
+			opAPIGroup.GET("/auth/oidc/callback", InitUserCheck, OIDCCallback)
+			opAPIGroup.POST("/auth/oidc/confirm", InitUserCheck, OIDCConfirm)
+		}
 		opAPIGroup.POST("/logout", OpAuth, Logout)
+		opAPIGroup.GET("/sessions", OpAuth, GetOpSessions)
+		opAPIGroup.DELETE("/session/:key", OpAuth, RevokeOpSession)
+		opAPIGroup.GET("/user/sessions/:user_key", OpAuth, GetUserOpSessions)
+		opAPIGroup.DELETE("/user/sessions/:user_key", OpAuth, RevokeUserOpSessions)
 
 		opAPIGroup.GET("/users/:page/:count", InitUserCheck, OpAuth, GetUsers)
 		opAPIGroup.POST("/user", OpAuth, ConfWriteCheck, NewUser, UpdateMasterLastDataUpdateUTC)
 		opAPIGroup.PUT("/user", OpAuth, ConfWriteCheck, UpdateUser, UpdateMasterLastDataUpdateUTC)
 		opAPIGroup.PUT("/user/status", OpAuth, ConfWriteCheck, UpdateUserStatus, UpdateMasterLastDataUpdateUTC)
+		opAPIGroup.PUT("/user/freeze-override", OpAuth, ConfWriteCheck, UpdateUserFreezeOverride, UpdateMasterLastDataUpdateUTC)
 		opAPIGroup.PUT("/user/passcode", OpAuth, ConfWriteCheck, UpdateUserPassCode, UpdateMasterLastDataUpdateUTC)
+		opAPIGroup.POST("/user/passcode/reset", OpAuth, ConfWriteCheck, ResetUserPassCode)
+		opAPIGroup.POST("/user/passcode/reset/confirm", ConfWriteCheck, ConfirmUserPassCodeReset, UpdateMasterLastDataUpdateUTC)
 		opAPIGroup.POST("/user/init", ConfWriteCheck, InitUser, UpdateMasterLastDataUpdateUTC)
 		opAPIGroup.GET("/user/info", OpAuth, GetLoginUserInfo)
+		opAPIGroup.POST("/user/totp/enroll", OpAuth, EnrollTOTP)
+		opAPIGroup.POST("/user/totp/confirm", OpAuth, ConfirmTOTP, UpdateMasterLastDataUpdateUTC)
+		opAPIGroup.POST("/user/totp/disable", OpAuth, DisableTOTP, UpdateMasterLastDataUpdateUTC)
+		opAPIGroup.POST("/user/totp/recovery-codes", OpAuth, RenewRecoveryCodes, UpdateMasterLastDataUpdateUTC)
+
+		opAPIGroup.GET("/tokens", OpAuth, GetAPITokens)
+		opAPIGroup.POST("/token", OpAuth, ConfWriteCheck, NewAPIToken, UpdateMasterLastDataUpdateUTC)
+		opAPIGroup.DELETE("/token/:key", OpAuth, ConfWriteCheck, RevokeAPIToken, UpdateMasterLastDataUpdateUTC)
 
 		opAPIGroup.GET("/apps/user/:user_key", OpAuth, GetApps)
 		opAPIGroup.GET("/apps/all/:page/:count", OpAuth, GetAllApps)
@@ -109,29 +133,56 @@ func main() {
 		}
 		opAPIGroup.POST("/app", OpAuth, ConfWriteCheck, NewApp, UpdateMasterLastDataUpdateUTC)
 		opAPIGroup.PUT("/app", OpAuth, ConfWriteCheck, UpdateApp, UpdateMasterLastDataUpdateUTC)
-		opAPIGroup.POST("/app/clone", OpAuth, ConfWriteCheck, CloneAppConfigs, UpdateMasterLastDataUpdateUTC)
+		opAPIGroup.POST("/app/clone", OpAuth, ConfWriteCheck, ConfigFreezeCheck, CloneAppConfigs, UpdateMasterLastDataUpdateUTC)
+
+		opAPIGroup.GET("/apps/envs/:app_key", OpAuth, GetAppEnvs)
+		opAPIGroup.POST("/app/env", OpAuth, ConfWriteCheck, NewAppEnv, UpdateMasterLastDataUpdateUTC)
+		opAPIGroup.PUT("/app/env", OpAuth, ConfWriteCheck, UpdateAppEnv, UpdateMasterLastDataUpdateUTC)
+		opAPIGroup.POST("/app/env/promote", OpAuth, ConfWriteCheck, ConfigFreezeCheck, PromoteAppEnvConfigs, UpdateMasterLastDataUpdateUTC)
 
 		opAPIGroup.GET("/webhooks/global", OpAuth, GetGlobalWebHooks)
 		opAPIGroup.GET("/webhooks/app/:app_key", OpAuth, GetAppWebHooks)
 		opAPIGroup.POST("/webhook", OpAuth, ConfWriteCheck, NewWebHook, UpdateMasterLastDataUpdateUTC)
 		opAPIGroup.PUT("/webhook", OpAuth, ConfWriteCheck, UpdateWebHook)
+		opAPIGroup.POST("/webhook/preview", OpAuth, PreviewWebHookTemplate)
+		opAPIGroup.DELETE("/webhook/:key", OpAuth, ConfWriteCheck, DeleteWebHook, UpdateMasterLastDataUpdateUTC)
+		if conf.IsMasterNode() {
+			// webhooks are only delivered by master node
+			opAPIGroup.GET("/webhook/deliveries/:hook_key/:page/:count", OpAuth, GetWebHookDeliveries)
+			opAPIGroup.POST("/webhook/redeliver", OpAuth, RedeliverWebHook)
+		}
 
 		opAPIGroup.GET("/configs/:app_key", OpAuth, GetConfigs)
-		opAPIGroup.POST("/config", OpAuth, ConfWriteCheck, NewConfig, UpdateMasterLastDataUpdateUTC)
-		opAPIGroup.PUT("/config", OpAuth, ConfWriteCheck, UpdateConfig, UpdateMasterLastDataUpdateUTC)
+		opAPIGroup.POST("/config", OpAuth, ConfWriteCheck, ConfigFreezeCheck, NewConfig, UpdateMasterLastDataUpdateUTC)
+		opAPIGroup.PUT("/config", OpAuth, ConfWriteCheck, ConfigFreezeCheck, UpdateConfig, UpdateMasterLastDataUpdateUTC)
+		opAPIGroup.POST("/configs/batch", OpAuth, ConfWriteCheck, ConfigFreezeCheck, BatchUpdateConfigs, UpdateMasterLastDataUpdateUTC)
 		opAPIGroup.GET("/config/history/:config_key", OpAuth, GetConfigUpdateHistory)
 		opAPIGroup.GET("/config/apphistory/:app_key/:page/:count", OpAuth, GetAppConfigUpdateHistory)
 		opAPIGroup.GET("/config/userhistory/:user_key/:page/:count", OpAuth, GetConfigUpdateHistoryOfUser)
+		opAPIGroup.GET("/config/searchhistory/:page/:count", OpAuth, SearchConfigUpdateHistory)
+		opAPIGroup.GET("/config/freezes", OpAuth, GetConfigFreezes)
+		opAPIGroup.POST("/config/freeze", OpAuth, ConfWriteCheck, NewConfigFreeze, UpdateMasterLastDataUpdateUTC)
+		opAPIGroup.PUT("/config/freeze", OpAuth, ConfWriteCheck, UpdateConfigFreeze, UpdateMasterLastDataUpdateUTC)
+		opAPIGroup.DELETE("/config/freeze/:key", OpAuth, ConfWriteCheck, CancelConfigFreeze, UpdateMasterLastDataUpdateUTC)
 		opAPIGroup.GET("/config/by/:config_key", OpAuth, GetConfigByKey)
+		opAPIGroup.POST("/config/check", OpAuth, CheckConfig)
+		opAPIGroup.GET("/config/diff/apps", OpAuth, DiffApps)
+		opAPIGroup.GET("/config/diff/history/:app_key", OpAuth, DiffAppHistory)
 
 		opAPIGroup.GET("/nodes", OpAuth, GetNodes)
 
+		opAPIGroup.GET("/audit-logs/:page/:count", OpAuth, GetAuditLogs)
+
 		opAPIGroup.GET("/client/params/:symbol", OpAuth, GetClientSymbols)
 
-		// for statistics
-		opAPIGroup.GET("/stat/latest-config-device-count/:app_key", OpAuth, StatCheck, GetDeviceCountOfAppLatestConfig)
-		opAPIGroup.GET("/stat/app-config-response/:app_key", OpAuth, StatCheck, GetAppConfigResponseData)
-		opAPIGroup.GET("/stat/node-config-response/:node_url", OpAuth, StatCheck, GetNodeConfigResponseData)
+		// for statistics, local backend keeps data of all nodes on master only
+		if conf.IsMasterNode() || conf.StatisticBackend != STATISTIC_BACKEND_LOCAL {
+			opAPIGroup.GET("/stat/latest-config-device-count/:app_key", OpAuth, StatCheck, GetDeviceCountOfAppLatestConfig)
+			opAPIGroup.GET("/stat/app-config-response/:app_key", OpAuth, StatCheck, GetAppConfigResponseData)
+			opAPIGroup.GET("/stat/node-config-response/:node_url", OpAuth, StatCheck, GetNodeConfigResponseData)
+			opAPIGroup.GET("/stat/app-config-breakdown/:app_key/:dimension", OpAuth, StatCheck, GetAppConfigBreakdownData)
+			opAPIGroup.GET("/stat/config-value-distribution/:app_key", OpAuth, StatCheck, GetConfigValueDistribution)
+		}
 	}
 
 	ginInsNode := gin.New()
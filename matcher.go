@@ -17,6 +17,8 @@ type ClientData struct {
 	DataSign   string `json:"data_sign"`
 	TimeZone   string `json:"timezone"`
 	NetWork    string `json:"network"`
+
+	CodeValues map[string]interface{} `json:"-"` // evaluated values of code configs returned to client, for statistic
 }
 
 type Config struct {
@@ -67,7 +69,38 @@ func transConfig(m *models.Config) *Config {
 	return config
 }
 
-func getMatchConf(matchData *ClientData, configs []*Config) map[string]interface{} {
+// code configs which serve keys of the app, configs of apps nearer on the template chain win
+func getAppServedCodeConfigs(appKey string) map[string]*Config {
+	res := map[string]*Config{}
+	served := map[string]bool{}
+	visited := map[string]bool{}
+	for key := appKey; key != "" && !visited[key]; key = getAppParentKey(key) {
+		visited[key] = true
+		for _, config := range getAppMemConfig(key) {
+			if config.Status != models.CONF_STATUS_ACTIVE || served[config.K] {
+				continue
+			}
+			served[config.K] = true
+			if config.VType == models.CONF_V_TYPE_CODE {
+				res[config.K] = config
+			}
+		}
+	}
+
+	return res
+}
+
+func setClientCodeValues(clientData *ClientData, appKey string, configs map[string]interface{}) {
+	clientData.CodeValues = map[string]interface{}{}
+	for k := range getAppServedCodeConfigs(appKey) {
+		if v, ok := configs[k]; ok {
+			clientData.CodeValues[k] = v
+		}
+	}
+}
+
+// visited holds the apps on the current template chain to guard against reference cycles
+func getMatchConf(matchData *ClientData, configs []*Config, visited map[string]bool) map[string]interface{} {
 	res := make(map[string]interface{}, 0)
 	for _, config := range configs {
 		if config.Status != models.CONF_STATUS_ACTIVE {
@@ -77,7 +110,7 @@ func getMatchConf(matchData *ClientData, configs []*Config) map[string]interface
 		case models.CONF_V_TYPE_CODE:
 			res[config.K], _ = EvalDynVal(config.V.(*DynVal), matchData)
 		case models.CONF_V_TYPE_TEMPLATE:
-			res[config.K] = getAppMatchConf(config.V.(string), matchData)
+			res[config.K] = getAppMatchConfWithVisited(config.V.(string), matchData, visited)
 		default:
 			res[config.K] = config.V
 		}
@@ -86,7 +119,7 @@ func getMatchConf(matchData *ClientData, configs []*Config) map[string]interface
 	return res
 }
 
-func getMatchConfWithKey(matchData *ClientData, configs []*Config, key string) map[string]interface{} {
+func getMatchConfWithKey(matchData *ClientData, configs []*Config, key string, visited map[string]bool) map[string]interface{} {
 	res := make(map[string]interface{}, 0)
 	for _, config := range configs {
 		if config.Status != models.CONF_STATUS_ACTIVE || config.K != key {
@@ -96,7 +129,7 @@ func getMatchConfWithKey(matchData *ClientData, configs []*Config, key string) m
 		case models.CONF_V_TYPE_CODE:
 			res[config.K], _ = EvalDynVal(config.V.(*DynVal), matchData)
 		case models.CONF_V_TYPE_TEMPLATE:
-			res[config.K] = getAppMatchConfWithKey(config.V.(string), matchData, key)
+			res[config.K] = getAppMatchConfWithKeyVisited(config.V.(string), matchData, key, visited)
 		default:
 			res[config.K] = config.V
 		}
@@ -108,20 +141,55 @@ func getMatchConfWithKey(matchData *ClientData, configs []*Config, key string) m
 	return res
 }
 
+// appKey may also be an app env key, configs of parent templates are merged with local keys overriding
 func getAppMatchConf(appKey string, clientData *ClientData) map[string]interface{} {
+	return getAppMatchConfWithVisited(appKey, clientData, map[string]bool{})
+}
+
+func getAppMatchConfWithVisited(appKey string, clientData *ClientData, visited map[string]bool) map[string]interface{} {
+	if visited[appKey] {
+		return map[string]interface{}{}
+	}
+	visited[appKey] = true
+	defer delete(visited, appKey)
+
+	res := map[string]interface{}{}
+	if parentKey := getAppParentKey(appKey); parentKey != "" {
+		res = getAppMatchConfWithVisited(parentKey, clientData, visited)
+	}
+
 	appConfigs := getAppMemConfig(appKey)
 	if appConfigs == nil {
-		return map[string]interface{}{}
+		return res
 	}
 
-	return getMatchConf(clientData, appConfigs)
+	for k, v := range getMatchConf(clientData, appConfigs, visited) {
+		res[k] = v
+	}
+
+	return res
 }
 
 func getAppMatchConfWithKey(appKey string, clientData *ClientData, key string) map[string]interface{} {
-	appConfigs := getAppMemConfig(appKey)
-	if appConfigs == nil {
+	return getAppMatchConfWithKeyVisited(appKey, clientData, key, map[string]bool{})
+}
+
+func getAppMatchConfWithKeyVisited(appKey string, clientData *ClientData, key string, visited map[string]bool) map[string]interface{} {
+	if visited[appKey] {
 		return map[string]interface{}{}
 	}
+	visited[appKey] = true
+	defer delete(visited, appKey)
+
+	if appConfigs := getAppMemConfig(appKey); appConfigs != nil {
+		if res := getMatchConfWithKey(clientData, appConfigs, key, visited); len(res) > 0 {
+			return res
+		}
+	}
+
+	if parentKey := getAppParentKey(appKey); parentKey != "" {
+		return getAppMatchConfWithKeyVisited(parentKey, clientData, key, visited)
+	}
 
-	return getMatchConfWithKey(clientData, appConfigs, key)
+	return map[string]interface{}{}
 }
@@ -59,7 +59,7 @@ func TestMatch(t *testing.T) {
 	webHooks := []*models.WebHook{}
 
 	_clearModelData()
-	fillMemConfData(users, apps, webHooks, configs, nil, nil)
+	fillMemConfData(users, apps, nil, webHooks, nil, nil, configs, nil, nil)
 
 	res := getAppMatchConf("app1", clientData)
 	assert.True(t, res["time_out"].(int) == 1)
@@ -138,7 +138,7 @@ func TestTemplateConf(t *testing.T) {
 	webHooks := []*models.WebHook{}
 
 	_clearModelData()
-	fillMemConfData(users, apps, webHooks, configs, nil, nil)
+	fillMemConfData(users, apps, nil, webHooks, nil, nil, configs, nil, nil)
 
 	res := getAppMatchConf("app1", clientData)
 	mapConf := res["template_conf"]
@@ -2,22 +2,30 @@ package main
 
 import (
 	"log"
+	"sort"
 	"sync"
 
+	"github.com/Instafig/Instafig/conf"
 	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
 )
 
 var (
-	memConfUsers          map[string]*models.User
-	memConfUsersByName    map[string]*models.User
-	memConfApps           map[string]*models.App
-	memConfAppsByName     map[string]*models.App
-	memConfGlobalWebHooks []*models.WebHook
-	memConfAppWebHooks    map[string][]*models.WebHook
-	memConfRawConfigs     map[string]*models.Config
-	memConfAppConfigs     map[string][]*Config
-	memConfNodes          map[string]*models.Node
-	memConfDataVersion    *models.DataVersion
+	memConfUsers           map[string]*models.User
+	memConfUsersByName     map[string]*models.User
+	memConfApps            map[string]*models.App
+	memConfAppsByName      map[string]*models.App
+	memConfGlobalWebHooks  []*models.WebHook
+	memConfAppWebHooks     map[string][]*models.WebHook
+	memConfRawConfigs      map[string]*models.Config
+	memConfAppConfigs      map[string][]*Config // key is app key, or env key for configs of app env
+	memConfEnvs            map[string]*models.AppEnv
+	memConfAppEnvs         map[string][]*models.AppEnv // ordered by seq
+	memConfNodes           map[string]*models.Node
+	memConfAPITokens       map[string]*models.APIToken
+	memConfAPITokensByHash map[string]*models.APIToken
+	memConfConfigFreezes   map[string]*models.ConfigFreeze
+	memConfDataVersion     *models.DataVersion
 
 	memConfClientLang       map[string]bool
 	memConfClientOSV        map[string]bool
@@ -41,11 +49,26 @@ func loadAllData() {
 		log.Panicf("Failed to load app info: %s", err.Error())
 	}
 
+	appEnvs, err := models.GetAllAppEnvs(nil)
+	if err != nil {
+		log.Panicf("Failed to load app env info: %s", err.Error())
+	}
+
 	webHooks, err := models.GetAllWebHooks(nil)
 	if err != nil {
 		log.Panicf("Failed to load webHook info: %s", err.Error())
 	}
 
+	apiTokens, err := models.GetAllAPITokens(nil)
+	if err != nil {
+		log.Panicf("Failed to load api token info: %s", err.Error())
+	}
+
+	configFreezes, err := models.GetAllConfigFreezes(nil)
+	if err != nil {
+		log.Panicf("Failed to load config freeze info: %s", err.Error())
+	}
+
 	configs, err := models.GetAllConfig(nil)
 	if err != nil {
 		log.Panicf("Failed to load config info: %s", err.Error())
@@ -66,14 +89,43 @@ func loadAllData() {
 		log.Panicf("Failed to load client request info: %s", err.Error())
 	}
 
-	fillMemConfData(users, apps, webHooks, configs, nodes, dataVersion)
+	if conf.IsMasterNode() {
+		if err = fillMissingUpdateIds(apps, webHooks); err != nil {
+			log.Panicf("Failed to fill update ids: %s", err.Error())
+		}
+	}
+
+	fillMemConfData(users, apps, appEnvs, webHooks, apiTokens, configFreezes, configs, nodes, dataVersion)
 	fillMemClientRequestData(clientParams)
 }
 
+// apps and webHooks stored before update ids were added have none, clients can not
+// update them without one. only master writes them as updates are done with master
+func fillMissingUpdateIds(apps []*models.App, webHooks []*models.WebHook) error {
+	for _, app := range apps {
+		if app.InfoUpdateId == "" {
+			app.InfoUpdateId = utils.GenerateKey()
+			if err := models.UpdateDBModel(nil, app); err != nil {
+				return err
+			}
+		}
+	}
+	for _, webHook := range webHooks {
+		if webHook.LastUpdateId == "" {
+			webHook.LastUpdateId = utils.GenerateKey()
+			if err := models.UpdateDBModel(nil, webHook); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func fillMemConfData(
-	users []*models.User, apps []*models.App,
-	webHooks []*models.WebHook, configs []*models.Config,
-	nodes []*models.Node, dataVersion *models.DataVersion) {
+	users []*models.User, apps []*models.App, appEnvs []*models.AppEnv,
+	webHooks []*models.WebHook, apiTokens []*models.APIToken, configFreezes []*models.ConfigFreeze,
+	configs []*models.Config, nodes []*models.Node, dataVersion *models.DataVersion) {
 	memConfMux.Lock()
 	defer memConfMux.Unlock()
 
@@ -83,8 +135,13 @@ func fillMemConfData(
 	memConfAppsByName = make(map[string]*models.App)
 	memConfRawConfigs = make(map[string]*models.Config)
 	memConfAppConfigs = make(map[string][]*Config)
+	memConfEnvs = make(map[string]*models.AppEnv)
+	memConfAppEnvs = make(map[string][]*models.AppEnv)
 	memConfNodes = make(map[string]*models.Node)
 	memConfAppWebHooks = make(map[string][]*models.WebHook)
+	memConfAPITokens = make(map[string]*models.APIToken)
+	memConfAPITokensByHash = make(map[string]*models.APIToken)
+	memConfConfigFreezes = make(map[string]*models.ConfigFreeze)
 	memConfDataVersion = dataVersion
 
 	for _, user := range users {
@@ -98,6 +155,11 @@ func fillMemConfData(
 		memConfAppConfigs[app.Key] = make([]*Config, 0)
 	}
 
+	for _, env := range appEnvs {
+		setMemConfAppEnv(env)
+		memConfAppConfigs[env.Key] = make([]*Config, 0)
+	}
+
 	for _, hook := range webHooks {
 		switch hook.Scope {
 		case models.WEBHOOK_SCOPE_GLOBAL:
@@ -107,9 +169,19 @@ func fillMemConfData(
 		}
 	}
 
+	for _, apiToken := range apiTokens {
+		memConfAPITokens[apiToken.Key] = apiToken
+		memConfAPITokensByHash[apiToken.Hash] = apiToken
+	}
+
+	for _, freeze := range configFreezes {
+		memConfConfigFreezes[freeze.Key] = freeze
+	}
+
 	for _, config := range configs {
 		memConfRawConfigs[config.Key] = config
-		memConfAppConfigs[config.AppKey] = append(memConfAppConfigs[config.AppKey], transConfig(config))
+		configsKey := getConfigsKey(config)
+		memConfAppConfigs[configsKey] = append(memConfAppConfigs[configsKey], transConfig(config))
 	}
 
 	for _, node := range nodes {
@@ -149,6 +221,84 @@ func fillMemClientRequestData(clientParams []*models.ClientReqeustData) {
 	}
 }
 
+// key of memConfAppConfigs for the config
+func getConfigsKey(config *models.Config) string {
+	if config.Env != "" {
+		return config.Env
+	}
+	return config.AppKey
+}
+
+// caller must hold memConfMux's write lock
+func setMemConfAppEnv(env *models.AppEnv) {
+	memConfEnvs[env.Key] = env
+
+	envs := make([]*models.AppEnv, 0, len(memConfAppEnvs[env.AppKey])+1)
+	for _, _env := range memConfAppEnvs[env.AppKey] {
+		if _env.Key != env.Key {
+			envs = append(envs, _env)
+		}
+	}
+	envs = append(envs, env)
+	sort.Stable(appEnvsBySeq(envs))
+	memConfAppEnvs[env.AppKey] = envs
+}
+
+type appEnvsBySeq []*models.AppEnv
+
+func (envs appEnvsBySeq) Len() int           { return len(envs) }
+func (envs appEnvsBySeq) Swap(i, j int)      { envs[i], envs[j] = envs[j], envs[i] }
+func (envs appEnvsBySeq) Less(i, j int) bool { return envs[i].Seq < envs[j].Seq }
+
+// data sign of app or app env, caller must hold memConfMux's read lock
+func getMemConfDataSign(configsKey string) string {
+	if env := memConfEnvs[configsKey]; env != nil {
+		return env.DataSign
+	}
+	if app := memConfApps[configsKey]; app != nil {
+		return app.DataSign
+	}
+	return ""
+}
+
+// parent template of app or app env
+func getAppParentKey(configsKey string) string {
+	memConfMux.RLock()
+	defer memConfMux.RUnlock()
+
+	if env := memConfEnvs[configsKey]; env != nil {
+		configsKey = env.AppKey
+	}
+	if app := memConfApps[configsKey]; app != nil {
+		return app.ParentKey
+	}
+	return ""
+}
+
+// caller must hold memConfMux's write lock
+func setMemConfDataSign(apps []*models.App, dataSign string) {
+	for _, app := range apps {
+		app.DataSign = dataSign
+		for _, env := range memConfAppEnvs[app.Key] {
+			env.DataSign = dataSign
+		}
+	}
+}
+
+// read only, DO NOT change field value
+func getAppEnvByName(appKey, name string) *models.AppEnv {
+	memConfMux.RLock()
+	defer memConfMux.RUnlock()
+
+	for _, env := range memConfAppEnvs[appKey] {
+		if env.Name == name {
+			return env
+		}
+	}
+
+	return nil
+}
+
 // read only, DO NOT change field value
 func getAppMemConfig(appKey string) []*Config {
 	memConfMux.RLock()
@@ -177,28 +327,42 @@ func updateMemConf(i interface{}, newDataVersion *models.DataVersion, node *mode
 		}
 		memConfApps[m.Key] = m
 		memConfAppsByName[m.Name] = m
+		if len(auxData) > 0 {
+			setMemConfDataSign(auxData[0].([]*models.App), m.DataSign)
+		}
+
+	case *models.AppEnv:
+		if memConfEnvs[m.Key] == nil {
+			memConfAppConfigs[m.Key] = make([]*Config, 0)
+		}
+		setMemConfAppEnv(m)
 
 	case *models.Config:
 		isSysConf := isSysConfType(m.AppKey)
-		if !isSysConf && len(auxData) > 0 {
+		if m.Env != "" {
+			env, err := models.GetAppEnvByKey(nil, m.Env)
+			if err != nil || env == nil {
+				panic("Failed to load app env info from db")
+			}
+			setMemConfAppEnv(env)
+		} else if !isSysConf && len(auxData) > 0 {
 			toUpdateApps := auxData[0].([]*models.App)
 			app, err := models.GetAppByKey(nil, m.AppKey)
 			if err != nil {
 				panic("Failed to load app info from db")
 			}
 			memConfApps[m.AppKey] = app
-			for _, _app := range toUpdateApps {
-				_app.DataSign = app.DataSign
-			}
+			setMemConfDataSign(toUpdateApps, app.DataSign)
 		}
 
+		configsKey := getConfigsKey(m)
 		oldConfig := memConfRawConfigs[m.Key]
 		if oldConfig == nil {
-			memConfAppConfigs[m.AppKey] = append(memConfAppConfigs[m.AppKey], transConfig(m))
+			memConfAppConfigs[configsKey] = append(memConfAppConfigs[configsKey], transConfig(m))
 		} else {
-			for ix, _config := range memConfAppConfigs[m.AppKey] {
+			for ix, _config := range memConfAppConfigs[configsKey] {
 				if m.Key == _config.Key {
-					memConfAppConfigs[m.AppKey][ix] = transConfig(m)
+					memConfAppConfigs[configsKey][ix] = transConfig(m)
 					break
 				}
 			}
@@ -222,6 +386,20 @@ func updateMemConf(i interface{}, newDataVersion *models.DataVersion, node *mode
 				memConfAppWebHooks[m.AppKey][oldHookIdx] = m
 			}
 		}
+
+	case *models.APIToken:
+		memConfAPITokens[m.Key] = m
+		memConfAPITokensByHash[m.Hash] = m
+
+	case *models.ConfigFreeze:
+		memConfConfigFreezes[m.Key] = m
+
+	case *deleteWebHookData:
+		if m.Hook.Scope == models.WEBHOOK_SCOPE_GLOBAL {
+			memConfGlobalWebHooks = removeWebHook(memConfGlobalWebHooks, m.Hook.Key)
+		} else if m.Hook.Scope == models.WEBHOOK_SCOPE_APP {
+			memConfAppWebHooks[m.Hook.AppKey] = removeWebHook(memConfAppWebHooks[m.Hook.AppKey], m.Hook.Key)
+		}
 	}
 
 	memConfDataVersion = newDataVersion
@@ -229,3 +407,13 @@ func updateMemConf(i interface{}, newDataVersion *models.DataVersion, node *mode
 		memConfNodes[node.URL] = node
 	}
 }
+
+func removeWebHook(hooks []*models.WebHook, key string) []*models.WebHook {
+	res := make([]*models.WebHook, 0, len(hooks))
+	for _, hook := range hooks {
+		if hook.Key != key {
+			res = append(res, hook)
+		}
+	}
+	return res
+}
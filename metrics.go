@@ -0,0 +1,265 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	METRICS_UNKNOWN_APP = "unknown"
+)
+
+var (
+	clientRequestCounter = newMetricCounterVec(
+		"instafig_client_requests_total",
+		"Count of client config requests.",
+		"app", "node", "status")
+	clientRequestErrorCounter = newMetricCounterVec(
+		"instafig_client_request_errors_total",
+		"Count of failed client config requests by service error code.",
+		"app", "node", "error_code")
+	clientRequestDuration = newMetricHistogramVec(
+		"instafig_client_request_duration_seconds",
+		"Latency of client config requests.",
+		[]float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		"app", "node")
+	glispEvalDuration = newMetricHistogramVec(
+		"instafig_glisp_eval_duration_seconds",
+		"Latency of evaluating code configs.",
+		[]float64{.0001, .00025, .0005, .001, .0025, .005, .01, .025, .05, .1})
+	glispEnvPoolCounter = newMetricCounterVec(
+		"instafig_glisp_env_pool_total",
+		"Count of getting glisp envs from the pool, a miss creates a new env.",
+		"result")
+	webHookDeliveryCounter = newMetricCounterVec(
+		"instafig_webhook_deliveries_total",
+		"Count of webhook delivery attempts by outcome.",
+		"event", "result")
+)
+
+// series of one metric, keyed by joined label values
+type metricCounterVec struct {
+	name   string
+	help   string
+	labels []string
+
+	mux    sync.Mutex
+	values map[string]float64
+}
+
+func newMetricCounterVec(name, help string, labels ...string) *metricCounterVec {
+	return &metricCounterVec{
+		name:   name,
+		help:   help,
+		labels: labels,
+		values: make(map[string]float64),
+	}
+}
+
+func (m *metricCounterVec) Inc(labelValues ...string) {
+	m.Add(1, labelValues...)
+}
+
+func (m *metricCounterVec) Add(v float64, labelValues ...string) {
+	key := strings.Join(labelValues, "\xff")
+	m.mux.Lock()
+	m.values[key] += v
+	m.mux.Unlock()
+}
+
+func (m *metricCounterVec) write(w io.Writer) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", m.name, m.help, m.name)
+	for _, key := range sortedMetricKeys(m.values) {
+		fmt.Fprintf(w, "%s%s %s\n", m.name, formatMetricLabels(m.labels, key, ""), formatMetricValue(m.values[key]))
+	}
+}
+
+type metricHistogram struct {
+	counts []uint64 // count of each bucket, not cumulative
+	sum    float64
+	count  uint64
+}
+
+type metricHistogramVec struct {
+	name    string
+	help    string
+	labels  []string
+	buckets []float64
+
+	mux    sync.Mutex
+	values map[string]*metricHistogram
+}
+
+func newMetricHistogramVec(name, help string, buckets []float64, labels ...string) *metricHistogramVec {
+	return &metricHistogramVec{
+		name:    name,
+		help:    help,
+		labels:  labels,
+		buckets: buckets,
+		values:  make(map[string]*metricHistogram),
+	}
+}
+
+func (m *metricHistogramVec) Observe(v float64, labelValues ...string) {
+	key := strings.Join(labelValues, "\xff")
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	h := m.values[key]
+	if h == nil {
+		h = &metricHistogram{counts: make([]uint64, len(m.buckets))}
+		m.values[key] = h
+	}
+	for ix, bucket := range m.buckets {
+		if v <= bucket {
+			h.counts[ix]++
+			break
+		}
+	}
+	h.sum += v
+	h.count++
+}
+
+func (m *metricHistogramVec) write(w io.Writer) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", m.name, m.help, m.name)
+	keys := make([]string, 0, len(m.values))
+	for key := range m.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		h := m.values[key]
+		var cumulative uint64
+		for ix, bucket := range m.buckets {
+			cumulative += h.counts[ix]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", m.name, formatMetricLabels(m.labels, key, formatMetricValue(bucket)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", m.name, formatMetricLabels(m.labels, key, "+Inf"), h.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", m.name, formatMetricLabels(m.labels, key, ""), formatMetricValue(h.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", m.name, formatMetricLabels(m.labels, key, ""), h.count)
+	}
+}
+
+func sortedMetricKeys(values map[string]float64) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// le is the bucket label of histograms, empty for none
+func formatMetricLabels(labels []string, key, le string) string {
+	var pairs []string
+	if len(labels) > 0 {
+		for ix, value := range strings.Split(key, "\xff") {
+			pairs = append(pairs, fmt.Sprintf(`%s="%s"`, labels[ix], escapeMetricLabelValue(value)))
+		}
+	}
+	if le != "" {
+		pairs = append(pairs, fmt.Sprintf(`le="%s"`, le))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func escapeMetricLabelValue(v string) string {
+	v = strings.Replace(v, `\`, `\\`, -1)
+	v = strings.Replace(v, `"`, `\"`, -1)
+	return strings.Replace(v, "\n", `\n`, -1)
+}
+
+func formatMetricValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// app keys from clients are only used as label values when the app exists
+func getMetricsAppLabel(appKey string) string {
+	memConfMux.RLock()
+	defer memConfMux.RUnlock()
+
+	if memConfApps[appKey] == nil {
+		return METRICS_UNKNOWN_APP
+	}
+	return appKey
+}
+
+func MetricsHandler(c *gin.Context) {
+	now := time.Now()
+	c.Next()
+
+	app := METRICS_UNKNOWN_APP
+	if clientData := getClientData(c); clientData != nil {
+		app = getMetricsAppLabel(clientData.AppKey)
+	}
+
+	status := "ok"
+	if !getServiceStatus(c) {
+		status = "error"
+		clientRequestErrorCounter.Inc(app, conf.ClientAddr, getServiceErrorCode(c))
+	}
+	clientRequestCounter.Inc(app, conf.ClientAddr, status)
+	clientRequestDuration.Observe(time.Since(now).Seconds(), app, conf.ClientAddr)
+}
+
+// data versions slave nodes are behind, from the view of this node
+func writeSlaveSyncLagMetrics(w io.Writer) {
+	memConfMux.RLock()
+	defer memConfMux.RUnlock()
+
+	name := "instafig_slave_data_version_lag"
+	fmt.Fprintf(w, "# HELP %s Data versions each slave node is behind master.\n# TYPE %s gauge\n", name, name)
+	urls := make([]string, 0, len(memConfNodes))
+	for url, node := range memConfNodes {
+		if node.Type != models.NODE_TYPE_MASTER && node.DataVersion != nil {
+			urls = append(urls, url)
+		}
+	}
+	sort.Strings(urls)
+	for _, url := range urls {
+		lag := memConfDataVersion.Version - memConfNodes[url].DataVersion.Version
+		fmt.Fprintf(w, "%s%s %d\n", name, formatMetricLabels([]string{"node"}, url, ""), lag)
+	}
+
+	name = "instafig_data_version"
+	fmt.Fprintf(w, "# HELP %s Data version of this node.\n# TYPE %s gauge\n", name, name)
+	fmt.Fprintf(w, "%s%s %d\n", name, formatMetricLabels([]string{"node"}, conf.ClientAddr, ""), memConfDataVersion.Version)
+}
+
+func writeMetrics(w io.Writer) {
+	clientRequestCounter.write(w)
+	clientRequestErrorCounter.write(w)
+	clientRequestDuration.write(w)
+	glispEvalDuration.write(w)
+	glispEnvPoolCounter.write(w)
+	webHookDeliveryCounter.write(w)
+	writeSlaveSyncLagMetrics(w)
+}
+
+// metrics in prometheus text format, scrapers authenticate with "Authorization: Bearer <api token>"
+func MetricsExport(c *gin.Context) {
+	var buf bytes.Buffer
+	writeMetrics(&buf)
+	c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", buf.Bytes())
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricCounterVec(t *testing.T) {
+	m := newMetricCounterVec("test_total", "Test counter.", "app", "status")
+	m.Inc("b", "ok")
+	m.Inc("a", "ok")
+	m.Add(2, "a", "ok")
+	m.Inc("a", `err"or`)
+
+	var buf bytes.Buffer
+	m.write(&buf)
+	assert.True(t, buf.String() == `# HELP test_total Test counter.
+# TYPE test_total counter
+test_total{app="a",status="err\"or"} 1
+test_total{app="a",status="ok"} 3
+test_total{app="b",status="ok"} 1
+`, buf.String())
+}
+
+func TestMetricHistogramVec(t *testing.T) {
+	m := newMetricHistogramVec("test_seconds", "Test histogram.", []float64{.1, 1})
+	m.Observe(.05)
+	m.Observe(.5)
+	m.Observe(5)
+
+	var buf bytes.Buffer
+	m.write(&buf)
+	assert.True(t, buf.String() == `# HELP test_seconds Test histogram.
+# TYPE test_seconds histogram
+test_seconds_bucket{le="0.1"} 1
+test_seconds_bucket{le="1"} 2
+test_seconds_bucket{le="+Inf"} 3
+test_seconds_sum 5.55
+test_seconds_count 3
+`, buf.String())
+}
+
+func TestGLispMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	putGLispEnv(getGLispEnv())
+	putGLispEnv(getGLispEnv())
+	glispEnvPoolCounter.write(&buf)
+	assert.True(t, strings.Contains(buf.String(), `instafig_glisp_env_pool_total{result="hit"}`), buf.String())
+}
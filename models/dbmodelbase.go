@@ -108,9 +108,10 @@ func initDBEngine(driver, dsn string) {
 	dbEngineDefault.ShowSQL(conf.ShowSql)
 
 	if err = dbEngineDefault.Sync2(
-		&User{}, &App{},
+		&User{}, &App{}, &AppEnv{},
 		&Config{}, &ConfigUpdateHistory{},
-		&Node{}, &DataVersion{}, &WebHook{}, &ClientReqeustData{},
+		&Node{}, &DataVersion{}, &WebHook{}, &WebHookDelivery{}, &ClientReqeustData{}, &APIToken{}, &UserSession{}, &LoginFailure{}, &PassCodeReset{}, &AuditLog{}, &AuditLogSync{}, &ConfigFreeze{},
+		&StatMinute{}, &StatDevice{}, &StatDeviceValue{}, &StatCodeValue{},
 	); err != nil {
 		log.Panicf("Failed to sync db scheme: %s", err.Error())
 	}
@@ -3,6 +3,7 @@ package models
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -15,6 +16,15 @@ var (
 const (
 	USER_STATUS_ACTIVE   = 0
 	USER_STATUS_INACTIVE = -1
+
+	// empty role of users created before roles is taken as member
+	USER_ROLE_ADMIN  = "admin"
+	USER_ROLE_MEMBER = "member"
+	USER_ROLE_VIEWER = "viewer" // can not write conf data
+
+	USER_SOURCE_LOCAL = "" // passcode kept by instafig
+	USER_SOURCE_LDAP  = "ldap"
+	USER_SOURCE_OIDC  = "oidc"
 )
 
 type User struct {
@@ -25,8 +35,20 @@ type User struct {
 	CreatedUTC int    `xorm:"created_utc INT " json:"created_utc"`
 	AuxInfo    string `xorm:"aux_info TEXT" json:"aux_info"`
 	Status     int    `xorm:"status INT" json:"status"`
+	Role       string `xorm:"role TEXT " json:"role"`
+	Source     string `xorm:"source TEXT " json:"source"`
+	ExternalId string `xorm:"external_id TEXT " json:"external_id"` // user id in ldap/oidc provider
+
+	PassCodeVersion int `xorm:"pass_code_version INT " json:"pass_code_version"` // increased when user changes passcode, not when it is rehashed
+
+	FreezeOverride bool `xorm:"freeze_override BOOL " json:"freeze_override"` // can write configs during config freezes
+
+	// synced to slave nodes for login, must be cleared before returned by op api
+	TOTPSecret    string `xorm:"totp_secret TEXT " json:"totp_secret"`       // base32, empty if totp not enabled
+	RecoveryCodes string `xorm:"recovery_codes TEXT " json:"recovery_codes"` // json array of hashes of unused codes
 
 	CreatorName string `xorm:"-" json:"creator_name"`
+	TOTPEnabled bool   `xorm:"-" json:"totp_enabled"`
 }
 
 func (*User) TableName() string {
@@ -73,6 +95,242 @@ func GetUserCount(s *Session) (int, error) {
 	return int(count), err
 }
 
+const (
+	API_TOKEN_TYPE_PERSONAL = "personal"
+	API_TOKEN_TYPE_SERVICE  = "service"
+
+	API_TOKEN_SCOPE_ALL  = "all"
+	API_TOKEN_SCOPE_READ = "read"
+
+	API_TOKEN_STATUS_ACTIVE  = 0
+	API_TOKEN_STATUS_REVOKED = -1
+)
+
+// token for machine access to op api on behalf of its user, only the hash of the token is stored
+type APIToken struct {
+	Key        string `xorm:"key TEXT PK " json:"key"`
+	UserKey    string `xorm:"user_key TEXT INDEX" json:"user_key"`
+	Name       string `xorm:"name TEXT " json:"name"`
+	Type       string `xorm:"type TEXT " json:"type"`
+	Scope      string `xorm:"scope TEXT " json:"scope"`
+	Hash       string `xorm:"hash TEXT UNIQUE" json:"hash"`
+	Prefix     string `xorm:"prefix TEXT " json:"prefix"`          // head of the token to tell tokens apart
+	ExpiresUTC int    `xorm:"expires_utc INT " json:"expires_utc"` // 0 for never
+	Status     int    `xorm:"status INT " json:"status"`
+	CreatorKey string `xorm:"creator_key TEXT " json:"creator_key"`
+	CreatedUTC int    `xorm:"created_utc INT " json:"created_utc"`
+}
+
+func (*APIToken) TableName() string {
+	return "api_token"
+}
+
+func (m *APIToken) UniqueCond() (string, []interface{}) {
+	return "key=?", []interface{}{m.Key}
+}
+
+func GetAllAPITokens(s *Session) ([]*APIToken, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	var res []*APIToken
+	if err := s.Find(&res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+const (
+	CONFIG_FREEZE_STATUS_ACTIVE    = 0
+	CONFIG_FREEZE_STATUS_CANCELLED = -1
+)
+
+// window in which configs can only be written by users with freeze override
+type ConfigFreeze struct {
+	Key        string `xorm:"key TEXT PK " json:"key"`
+	AppKey     string `xorm:"app_key TEXT INDEX" json:"app_key"` // empty for global freeze
+	StartUTC   int    `xorm:"start_utc INT " json:"start_utc"`
+	EndUTC     int    `xorm:"end_utc INT " json:"end_utc"`
+	Reason     string `xorm:"reason TEXT " json:"reason"`
+	Status     int    `xorm:"status INT " json:"status"`
+	CreatorKey string `xorm:"creator_key TEXT " json:"creator_key"`
+	CreatedUTC int    `xorm:"created_utc INT " json:"created_utc"`
+}
+
+func (*ConfigFreeze) TableName() string {
+	return "config_freeze"
+}
+
+func (m *ConfigFreeze) UniqueCond() (string, []interface{}) {
+	return "key=?", []interface{}{m.Key}
+}
+
+func GetAllConfigFreezes(s *Session) ([]*ConfigFreeze, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	var res []*ConfigFreeze
+	if err := s.Find(&res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+const (
+	USER_SESSION_STATUS_ACTIVE  = 0
+	USER_SESSION_STATUS_REVOKED = -1
+)
+
+// op login session, sessions are kept by master node and slave nodes query master for them
+type UserSession struct {
+	Key             string `xorm:"key TEXT PK " json:"key"`
+	UserKey         string `xorm:"user_key TEXT INDEX" json:"user_key"`
+	PassCodeVersion int    `xorm:"pass_code_version INT " json:"-"` // sessions are invalid after user passcode changed
+	IP              string `xorm:"ip TEXT " json:"ip"`
+	UserAgent       string `xorm:"user_agent TEXT " json:"user_agent"`
+	Status          int    `xorm:"status INT " json:"status"`
+	CreatedUTC      int    `xorm:"created_utc INT " json:"created_utc"`
+	ExpiresUTC      int    `xorm:"expires_utc INT INDEX" json:"expires_utc"`
+
+	Current bool `xorm:"-" json:"current"`
+}
+
+func (*UserSession) TableName() string {
+	return "user_session"
+}
+
+func (m *UserSession) UniqueCond() (string, []interface{}) {
+	return "key=?", []interface{}{m.Key}
+}
+
+func GetUserSessionByKey(s *Session, key string) (*UserSession, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	res := &UserSession{}
+	if has, err := s.Where("key=?", key).Get(res); !has || err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// sessions of user not revoked or expired at utc
+func GetActiveUserSessions(s *Session, userKey string, utc int) ([]*UserSession, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	var res []*UserSession
+	err := s.
+		Where("user_key=? and status=? and expires_utc>?", userKey, USER_SESSION_STATUS_ACTIVE, utc).
+		OrderBy("created_utc desc").
+		Find(&res)
+
+	return res, err
+}
+
+// revokes all sessions of user except the one of exceptKey
+func RevokeUserSessions(s *Session, userKey, exceptKey string) error {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	_, err := s.Exec("UPDATE user_session SET status=? WHERE user_key=? AND key<>?", USER_SESSION_STATUS_REVOKED, userKey, exceptKey)
+	return err
+}
+
+func DeleteExpiredUserSessions(s *Session, utc int) error {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	_, err := s.Exec("DELETE FROM user_session WHERE expires_utc<=?", utc)
+	return err
+}
+
+// failed logins of user with any node, kept by master node which slave nodes ask for lockout
+type LoginFailure struct {
+	UserKey        string `xorm:"user_key TEXT PK " json:"user_key"`
+	Count          int    `xorm:"count INT " json:"count"`
+	LastFailedUTC  int    `xorm:"last_failed_utc INT " json:"last_failed_utc"`
+	LockedUntilUTC int    `xorm:"locked_until_utc INT " json:"locked_until_utc"`
+}
+
+func (*LoginFailure) TableName() string {
+	return "login_failure"
+}
+
+func (m *LoginFailure) UniqueCond() (string, []interface{}) {
+	return "user_key=?", []interface{}{m.UserKey}
+}
+
+func GetLoginFailure(s *Session, userKey string) (*LoginFailure, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	res := &LoginFailure{}
+	if has, err := s.Where("user_key=?", userKey).Get(res); !has || err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func DeleteLoginFailure(s *Session, userKey string) error {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	_, err := s.Exec("DELETE FROM login_failure WHERE user_key=?", userKey)
+	return err
+}
+
+// passcode reset issued by admin, kept by master node only as passcodes are only updated by master
+type PassCodeReset struct {
+	Hash       string `xorm:"hash TEXT PK " json:"-"` // hash of reset token
+	UserKey    string `xorm:"user_key TEXT INDEX" json:"user_key"`
+	CreatorKey string `xorm:"creator_key TEXT " json:"creator_key"`
+	CreatedUTC int    `xorm:"created_utc INT " json:"created_utc"`
+	ExpiresUTC int    `xorm:"expires_utc INT " json:"expires_utc"`
+}
+
+func (*PassCodeReset) TableName() string {
+	return "pass_code_reset"
+}
+
+func (m *PassCodeReset) UniqueCond() (string, []interface{}) {
+	return "hash=?", []interface{}{m.Hash}
+}
+
+func GetPassCodeResetByHash(s *Session, hash string) (*PassCodeReset, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	res := &PassCodeReset{}
+	if has, err := s.Where("hash=?", hash).Get(res); !has || err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func DeletePassCodeResetsOfUser(s *Session, userKey string) error {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	_, err := s.Exec("DELETE FROM pass_code_reset WHERE user_key=?", userKey)
+	return err
+}
+
 const (
 	APP_TYPE_TEMPLATE = "template"
 	APP_TYPE_REAL     = "real"
@@ -90,6 +348,8 @@ type App struct {
 	KeyCount      int    `xorm:"key_count INT " json:"key_count"`
 	UpdateTimes   int    `xorm:"update_times INT " json:"update_times"`
 	AuxInfo       string `xorm:"aux_info TEXT" json:"aux_info"`
+	ParentKey     string `xorm:"parent_key TEXT " json:"parent_key"`         // template app whose configs are merged into this app
+	InfoUpdateId  string `xorm:"info_update_id TEXT " json:"info_update_id"` // changes when app itself is updated, LastUpdateId is of configs
 
 	UserName       string               `xorm:"-" json:"creator_name"`
 	LastUpdateInfo *ConfigUpdateHistory `xorm:"-" json:"last_update_info"`
@@ -209,9 +469,14 @@ type Config struct {
 	UpdateTimes  int    `xorm:"update_times INT " json:"update_times"`
 	Des          string `xorm:"des TEXT " json:"des"`
 	Status       int    `xorm:"status INT" json:"status"`
+	Schema       string `xorm:"schema TEXT " json:"schema"`           // optional json schema to constrain V
+	Env          string `xorm:"env TEXT INDEX default ''" json:"env"` // app env key, empty for app's default env
 
 	CreatorName    string               `xorm:"-" json:"creator_name"`
 	LastUpdateInfo *ConfigUpdateHistory `xorm:"-" json:"last_update_info"`
+	UpdateBatchId  string               `xorm:"-" json:"update_batch_id,omitempty"` // carried to history of a batch update
+	UpdateMessage  string               `xorm:"-" json:"update_message,omitempty"`  // carried to history of the update
+	UpdateRef      string               `xorm:"-" json:"update_ref,omitempty"`
 }
 
 func (*Config) TableName() string {
@@ -235,13 +500,65 @@ func GetAllConfig(s *Session) ([]*Config, error) {
 	return res, nil
 }
 
+// configs of app's default env
 func GetConfigsByAppKey(s *Session, appKey string) ([]*Config, error) {
+	return GetConfigsByAppEnv(s, appKey, "")
+}
+
+func GetConfigsByAppEnv(s *Session, appKey, env string) ([]*Config, error) {
 	if s == nil {
 		s = newAutoCloseModelsSession()
 	}
 
 	var res []*Config
-	if err := s.Where("app_key=?", appKey).OrderBy("k").Find(&res); err != nil {
+	if err := s.Where("app_key=? and env=?", appKey, env).OrderBy("k").Find(&res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// an app env has its own configs and data sign, configs are promoted from an env to the next one
+// ordered by Seq, app's default env is always the last one
+type AppEnv struct {
+	Key        string `xorm:"key TEXT PK " json:"key"`
+	AppKey     string `xorm:"app_key TEXT INDEX" json:"app_key"`
+	Name       string `xorm:"name TEXT not NULL" json:"name"`
+	Seq        int    `xorm:"seq INT " json:"seq"`
+	DataSign   string `xorm:"data_sign TEXT " json:"data_sign"`
+	CreatorKey string `xorm:"creator_key TEXT " json:"creator_key"`
+	CreatedUTC int    `xorm:"created_utc INT " json:"created_utc"`
+	AuxInfo    string `xorm:"aux_info TEXT" json:"aux_info"`
+}
+
+func (*AppEnv) TableName() string {
+	return "app_env"
+}
+
+func (m *AppEnv) UniqueCond() (string, []interface{}) {
+	return "key=?", []interface{}{m.Key}
+}
+
+func GetAllAppEnvs(s *Session) ([]*AppEnv, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	var res []*AppEnv
+	if err := s.OrderBy("seq").Find(&res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func GetAppEnvByKey(s *Session, key string) (*AppEnv, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	res := &AppEnv{}
+	if has, err := s.Where("key=?", key).Get(res); !has || err != nil {
 		return nil, err
 	}
 
@@ -379,6 +696,11 @@ type ConfigUpdateHistory struct {
 	OldVType   string `xorm:"old_v_type TEXT " json:"old_v_type"`
 	NewV       string `xorm:"new_v TEXT " json:"new_v"`
 	NewVType   string `xorm:"new_v_type TEXT " json:"new_v_type"`
+	OldSchema  string `xorm:"old_schema TEXT " json:"old_schema"`
+	NewSchema  string `xorm:"new_schema TEXT " json:"new_schema"`
+	BatchId    string `xorm:"batch_id TEXT INDEX" json:"batch_id"` // shared by histories of one batch update
+	Message    string `xorm:"message TEXT " json:"message"`        // why the config is changed
+	Ref        string `xorm:"ref TEXT INDEX" json:"ref"`           // external reference such as ticket id
 	UserKey    string `xorm:"user_key TEXT INDEX" json:"user_key"`
 	CreatedUTC int    `xorm:"created_utc INT " json:"created_utc"`
 
@@ -447,6 +769,22 @@ func GetAppConfigUpdateHistoryCount(s *Session, appKey string) (int, error) {
 	return int(count), err
 }
 
+// histories of configs of the app env until the given time, in the order they happened
+func GetAppConfigUpdateHistoryUntil(s *Session, appKey, env string, utc int) ([]*ConfigUpdateHistory, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	var res []*ConfigUpdateHistory
+	err := s.
+		Table("config_update_history").
+		Join("INNER", "config", "config.key=config_update_history.config_key").
+		Where("config.app_key=? and config.env=? and config_update_history.created_utc<=?", appKey, env, utc).
+		OrderBy("config_update_history.created_utc asc, config_update_history.rowid asc").
+		Find(&res)
+	return res, err
+}
+
 func GetConfigUpdateHistoryOfUser(s *Session, userKey string, page, count int) ([]*ConfigUpdateHistory, error) {
 	if s == nil {
 		s = newAutoCloseModelsSession()
@@ -466,6 +804,48 @@ func GetConfigUpdateHistoryCountOfUser(s *Session, userKey string) (int, error)
 	return int(count), err
 }
 
+// histories whose message contains q or whose ref is q, of all apps if appKey is empty
+func SearchConfigUpdateHistory(s *Session, q, appKey string, page, count int) ([]*ConfigUpdateHistory, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	var res []*ConfigUpdateHistory
+	cond, args := searchConfigUpdateHistoryCond(q, appKey)
+	err := s.
+		Table("config_update_history").
+		Join("INNER", "config", "config.key=config_update_history.config_key").
+		Where(cond, args...).
+		OrderBy("config_update_history.created_utc desc").
+		Limit(count, (page-1)*count).
+		Find(&res)
+	return res, err
+}
+
+func SearchConfigUpdateHistoryCount(s *Session, q, appKey string) (int, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	cond, args := searchConfigUpdateHistoryCond(q, appKey)
+	count, err := s.
+		Join("INNER", "config", "config.key=config_update_history.config_key").
+		Where(cond, args...).
+		Count(&ConfigUpdateHistory{})
+	return int(count), err
+}
+
+func searchConfigUpdateHistoryCond(q, appKey string) (string, []interface{}) {
+	cond := "(instr(lower(config_update_history.message), ?)>0 or config_update_history.ref=?)"
+	args := []interface{}{strings.ToLower(q), q}
+	if appKey != "" {
+		cond += " and config.app_key=?"
+		args = append(args, appKey)
+	}
+
+	return cond, args
+}
+
 func GetAllConfigUpdateHistory(s *Session) ([]*ConfigUpdateHistory, error) {
 	if s == nil {
 		s = newAutoCloseModelsSession()
@@ -477,12 +857,162 @@ func GetAllConfigUpdateHistory(s *Session) ([]*ConfigUpdateHistory, error) {
 	return res, err
 }
 
+// op actions of users, written by the node handling the request and replicated by master node
+type AuditLog struct {
+	Id          string `xorm:"id PK TEXT " json:"id"`
+	UserKey     string `xorm:"user_key TEXT INDEX" json:"user_key"` // empty if not login, e.g. failed logins
+	UserName    string `xorm:"user_name TEXT " json:"user_name"`
+	APITokenKey string `xorm:"api_token_key TEXT " json:"api_token_key"` // empty if not requested with api token
+	Action      string `xorm:"action TEXT INDEX" json:"action"`
+	TargetType  string `xorm:"target_type TEXT INDEX" json:"target_type"`
+	TargetKey   string `xorm:"target_key TEXT INDEX" json:"target_key"`
+	Before      string `xorm:"before TEXT " json:"before"` // json of target, empty for new targets
+	After       string `xorm:"after TEXT " json:"after"`
+	ErrorCode   string `xorm:"error_code TEXT " json:"error_code"` // empty if action succeeded
+	IP          string `xorm:"ip TEXT " json:"ip"`
+	NodeURL     string `xorm:"node_url TEXT " json:"node_url"`
+	CreatedUTC  int    `xorm:"created_utc INT INDEX" json:"created_utc"`
+}
+
+func (*AuditLog) TableName() string {
+	return "audit_log"
+}
+
+func (m *AuditLog) UniqueCond() (string, []interface{}) {
+	return "id=?", []interface{}{m.Id}
+}
+
+// empty fields are not filtered
+type AuditLogFilter struct {
+	UserKey    string
+	Action     string
+	TargetType string
+	TargetKey  string
+	FromUTC    int
+	ToUTC      int
+}
+
+func (f *AuditLogFilter) cond() (string, []interface{}) {
+	conds := []string{"1=1"}
+	var args []interface{}
+	if f.UserKey != "" {
+		conds = append(conds, "user_key=?")
+		args = append(args, f.UserKey)
+	}
+	if f.Action != "" {
+		conds = append(conds, "action=?")
+		args = append(args, f.Action)
+	}
+	if f.TargetType != "" {
+		conds = append(conds, "target_type=?")
+		args = append(args, f.TargetType)
+	}
+	if f.TargetKey != "" {
+		conds = append(conds, "target_key=?")
+		args = append(args, f.TargetKey)
+	}
+	if f.FromUTC > 0 {
+		conds = append(conds, "created_utc>=?")
+		args = append(args, f.FromUTC)
+	}
+	if f.ToUTC > 0 {
+		conds = append(conds, "created_utc<=?")
+		args = append(args, f.ToUTC)
+	}
+
+	return strings.Join(conds, " and "), args
+}
+
+func GetAuditLogs(s *Session, filter *AuditLogFilter, page, count int) ([]*AuditLog, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	cond, args := filter.cond()
+	var res []*AuditLog
+	err := s.
+		Where(cond, args...).
+		OrderBy("created_utc desc, rowid desc").
+		Limit(count, (page-1)*count).
+		Find(&res)
+	return res, err
+}
+
+func GetAuditLogCount(s *Session, filter *AuditLogFilter) (int, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	cond, args := filter.cond()
+	count, err := s.Where(cond, args...).Count(&AuditLog{})
+	return int(count), err
+}
+
+func GetAuditLogById(s *Session, id string) (*AuditLog, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	res := &AuditLog{}
+	if has, err := s.Where("id=?", id).Get(res); !has || err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func GetAllAuditLogs(s *Session) ([]*AuditLog, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	var res []*AuditLog
+	err := s.Find(&res)
+
+	return res, err
+}
+
+// audit log waiting to be sent to another node, master node sends audit logs to slaves and slaves send theirs to master.
+// not cleared by ClearModeData, audit logs of slave node not sent to master yet are kept over resync
+type AuditLogSync struct {
+	Key          string `xorm:"key TEXT PK " json:"key"`
+	NodeURL      string `xorm:"node_url TEXT INDEX" json:"node_url"` // empty for master node
+	AuditLog     string `xorm:"audit_log TEXT " json:"audit_log"`    // json of audit log
+	Attempts     int    `xorm:"attempts INT" json:"attempts"`
+	Error        string `xorm:"error TEXT " json:"error"`
+	NextRetryUTC int    `xorm:"next_retry_utc INT INDEX" json:"next_retry_utc"`
+	CreatedUTC   int    `xorm:"created_utc INT" json:"created_utc"`
+}
+
+func (*AuditLogSync) TableName() string {
+	return "audit_log_sync"
+}
+
+func (m *AuditLogSync) UniqueCond() (string, []interface{}) {
+	return "key=?", []interface{}{m.Key}
+}
+
+// audit log syncs which should be sent before the given time
+func GetAuditLogSyncsToSend(s *Session, utc, limit int) ([]*AuditLogSync, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	var res []*AuditLogSync
+	err := s.
+		Where("next_retry_utc<=?", utc).
+		OrderBy("next_retry_utc asc").
+		Limit(limit).
+		Find(&res)
+	return res, err
+}
+
 func ClearModeData(s *Session) error {
 	if s == nil {
 		s = newAutoCloseModelsSession()
 	}
 
-	sql := "delete from user; delete from app; delete from config; delete from node;update data_version set version=0;delete from config_update_history; delete from web_hook;"
+	sql := "delete from user; delete from app; delete from config; delete from node;update data_version set version=0;delete from config_update_history; delete from web_hook; delete from app_env; delete from web_hook_delivery; delete from api_token; delete from user_session; delete from login_failure; delete from pass_code_reset; delete from audit_log; delete from config_freeze;"
 	_, err := s.Exec(sql)
 
 	return err
@@ -498,8 +1028,10 @@ const (
 	WEBHOOK_STATUS_INACTIVE = 0
 	WEBHOOK_STATUS_ACTIVE   = 1
 
-	WEBHOOK_TARGET_PUBU  = "pubu"
-	WEBHOOK_TARGET_SLACK = "slack"
+	WEBHOOK_TARGET_PUBU    = "pubu"
+	WEBHOOK_TARGET_SLACK   = "slack"
+	WEBHOOK_TARGET_GENERIC = "generic"
+	WEBHOOK_TARGET_EMAIL   = "email"
 )
 
 type WebHook struct {
@@ -510,7 +1042,18 @@ type WebHook struct {
 	URL      string `xorm:"url TEXT " json:"url"`
 	AuthType int    `xorm:"auth_type TEXT " json:"auth_type"`
 	AuthInfo string `xorm:"auth_info TEXT " json:"auth_info"`
+	Secret   string `xorm:"secret TEXT " json:"secret"`
 	Status   int    `xorm:"status INT" json:"status"`
+	Template string `xorm:"template TEXT " json:"template"` // text/template of messages, empty for the default ones
+
+	Recipients []string `xorm:"recipients TEXT " json:"recipients"` // mail addresses of email target
+
+	// filters, empty for all
+	Events      []string `xorm:"events TEXT " json:"events"`
+	Kinds       []string `xorm:"kinds TEXT " json:"kinds"` // kinds of config update
+	KeyPrefixes []string `xorm:"key_prefixes TEXT " json:"key_prefixes"`
+
+	LastUpdateId string `xorm:"last_update_id TEXT " json:"last_update_id"` // changes on every update
 }
 
 func (*WebHook) TableName() string {
@@ -560,6 +1103,104 @@ func GetWebHooksByAppKey(s *Session, appKey string) ([]*WebHook, error) {
 	return res, nil
 }
 
+const (
+	WEBHOOK_DELIVERY_STATUS_PENDING = 0
+	WEBHOOK_DELIVERY_STATUS_SUCCESS = 1
+	WEBHOOK_DELIVERY_STATUS_DEAD    = 2
+)
+
+// delivery of one webhook event, only kept on the master node which sends them
+type WebHookDelivery struct {
+	Key            string `xorm:"key TEXT PK " json:"key"`
+	HookKey        string `xorm:"hook_key TEXT INDEX" json:"hook_key"`
+	Event          string `xorm:"event TEXT " json:"event"`
+	Payload        string `xorm:"payload TEXT " json:"payload"`
+	Status         int    `xorm:"status INT INDEX" json:"status"`
+	Attempts       int    `xorm:"attempts INT" json:"attempts"`
+	NextRetryUTC   int    `xorm:"next_retry_utc INT INDEX" json:"next_retry_utc"`
+	StatusCode     int    `xorm:"status_code INT" json:"status_code"`
+	LatencyMs      int    `xorm:"latency_ms INT" json:"latency_ms"`
+	Error          string `xorm:"error TEXT " json:"error"`
+	LastAttemptUTC int    `xorm:"last_attempt_utc INT" json:"last_attempt_utc"`
+	CreatedUTC     int    `xorm:"created_utc INT INDEX" json:"created_utc"`
+}
+
+func (*WebHookDelivery) TableName() string {
+	return "web_hook_delivery"
+}
+
+func (m *WebHookDelivery) UniqueCond() (string, []interface{}) {
+	return "key=?", []interface{}{m.Key}
+}
+
+func GetWebHookDeliveryByKey(s *Session, key string) (*WebHookDelivery, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	res := &WebHookDelivery{}
+	if has, err := s.Where("key=?", key).Get(res); !has || err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func GetWebHookDeliveries(s *Session, hookKey string, page, count int) ([]*WebHookDelivery, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	var res []*WebHookDelivery
+	err := s.
+		Where("hook_key=?", hookKey).
+		OrderBy("created_utc desc").
+		Limit(count, (page-1)*count).
+		Find(&res)
+	return res, err
+}
+
+func GetWebHookDeliveryCount(s *Session, hookKey string) (int, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	count, err := s.Where("hook_key=?", hookKey).Count(&WebHookDelivery{})
+	return int(count), err
+}
+
+// pending deliveries of the hook which should be sent before the given time, and those never tried
+// created after fromUTC, which are still waiting in the digest window. deliveries in retry backoff are excluded
+func GetPendingWebHookDeliveriesOfHook(s *Session, hookKey string, utc, fromUTC, limit int) ([]*WebHookDelivery, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	var res []*WebHookDelivery
+	err := s.
+		Where("hook_key=? and status=? and (next_retry_utc<=? or (attempts=0 and created_utc>=?))",
+			hookKey, WEBHOOK_DELIVERY_STATUS_PENDING, utc, fromUTC).
+		OrderBy("created_utc asc").
+		Limit(limit).
+		Find(&res)
+	return res, err
+}
+
+// pending deliveries which should be sent before the given time
+func GetWebHookDeliveriesToSend(s *Session, utc, limit int) ([]*WebHookDelivery, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	var res []*WebHookDelivery
+	err := s.
+		Where("status=? and next_retry_utc<=?", WEBHOOK_DELIVERY_STATUS_PENDING, utc).
+		OrderBy("next_retry_utc asc").
+		Limit(limit).
+		Find(&res)
+	return res, err
+}
+
 type ClientReqeustData struct {
 	AppKey string `xorm:"app_key TEXT UNIQUE(uix_client_request_data)" json:"app_key"`
 	Symbol string `xorm:"symbol TEXT UNIQUE(uix_client_request_data)" json:"symbol"`
@@ -582,3 +1223,337 @@ func GetAllClientRequestData(s *Session) ([]*ClientReqeustData, error) {
 
 	return res, nil
 }
+
+// per minute aggregation of client requests, used by the local statistic backend
+type StatMinute struct {
+	Minute      int    `xorm:"minute INT UNIQUE(uix_stat_minute) INDEX" json:"minute"`
+	AppKey      string `xorm:"app_key TEXT UNIQUE(uix_stat_minute)" json:"app_key"`
+	Node        string `xorm:"node TEXT UNIQUE(uix_stat_minute)" json:"node"`
+	OSType      string `xorm:"os_type TEXT UNIQUE(uix_stat_minute)" json:"os_type"`
+	AppVersion  string `xorm:"app_version TEXT UNIQUE(uix_stat_minute)" json:"app_version"`
+	Lang        string `xorm:"lang TEXT UNIQUE(uix_stat_minute)" json:"lang"`
+	Network     string `xorm:"network TEXT UNIQUE(uix_stat_minute)" json:"network"`
+	Count       int    `xorm:"count INT" json:"count"`
+	ErrorCount  int    `xorm:"error_count INT" json:"error_count"`
+	RespTimeSum int    `xorm:"resp_time_sum INT" json:"resp_time_sum"` // micro second
+}
+
+func (*StatMinute) TableName() string {
+	return "stat_minute"
+}
+
+func (m *StatMinute) UniqueCond() (string, []interface{}) {
+	return "minute=? and app_key=? and node=? and os_type=? and app_version=? and lang=? and network=?",
+		[]interface{}{m.Minute, m.AppKey, m.Node, m.OSType, m.AppVersion, m.Lang, m.Network}
+}
+
+// add counters of rows to the stored ones
+func AddStatMinutes(s *Session, rows []*StatMinute) (err error) {
+	var _s *Session
+
+	if s == nil {
+		_s = NewSession()
+		defer _s.Close()
+
+		if err = _s.Begin(); err != nil {
+			return err
+		}
+	} else {
+		_s = s
+	}
+
+	for _, row := range rows {
+		if err = addStatMinute(_s, row); err != nil {
+			break
+		}
+	}
+
+	if s == nil {
+		if err != nil {
+			_s.Rollback()
+		} else {
+			err = _s.Commit()
+		}
+	}
+
+	return
+}
+
+func addStatMinute(s *Session, row *StatMinute) error {
+	whereStr, whereArgs := row.UniqueCond()
+	args := append([]interface{}{row.Count, row.ErrorCount, row.RespTimeSum}, whereArgs...)
+	res, err := s.Exec(
+		"UPDATE stat_minute SET count=count+?, error_count=error_count+?, resp_time_sum=resp_time_sum+? WHERE "+whereStr,
+		args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil || affected > 0 {
+		return err
+	}
+	_, err = s.AllCols().InsertOne(row)
+
+	return err
+}
+
+type StatSummary struct {
+	Bucket      int
+	Count       int
+	RespTimeSum int
+}
+
+// summaries grouped by buckets of unit seconds from start, col is app_key or node
+func GetStatSummaries(s *Session, col, value string, start, end, unit int) ([]*StatSummary, error) {
+	if col != "app_key" && col != "node" {
+		return nil, fmt.Errorf("unsupported stat column: %s", col)
+	}
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	rows, err := s.Query(
+		"SELECT (minute-?)/? AS bucket, SUM(count) AS count, SUM(resp_time_sum) AS resp_time_sum FROM stat_minute "+
+			"WHERE "+col+"=? AND minute>=? AND minute<=? GROUP BY bucket ORDER BY bucket",
+		start, unit, value, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*StatSummary, 0, len(rows))
+	for _, row := range rows {
+		summary := &StatSummary{}
+		summary.Bucket, _ = strconv.Atoi(string(row["bucket"]))
+		summary.Count, _ = strconv.Atoi(string(row["count"]))
+		summary.RespTimeSum, _ = strconv.Atoi(string(row["resp_time_sum"]))
+		res = append(res, summary)
+	}
+
+	return res, nil
+}
+
+type StatBreakdown struct {
+	Value       string
+	Count       int
+	RespTimeSum int
+}
+
+// summaries of the app grouped by col, which is os_type, app_version, lang or network
+func GetStatBreakdowns(s *Session, appKey, col string, start, end int) ([]*StatBreakdown, error) {
+	if col != "os_type" && col != "app_version" && col != "lang" && col != "network" {
+		return nil, fmt.Errorf("unsupported stat column: %s", col)
+	}
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	rows, err := s.Query(
+		"SELECT "+col+" AS value, SUM(count) AS count, SUM(resp_time_sum) AS resp_time_sum FROM stat_minute "+
+			"WHERE app_key=? AND minute>=? AND minute<=? GROUP BY "+col+" ORDER BY count DESC",
+		appKey, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*StatBreakdown, 0, len(rows))
+	for _, row := range rows {
+		breakdown := &StatBreakdown{Value: string(row["value"])}
+		breakdown.Count, _ = strconv.Atoi(string(row["count"]))
+		breakdown.RespTimeSum, _ = strconv.Atoi(string(row["resp_time_sum"]))
+		res = append(res, breakdown)
+	}
+
+	return res, nil
+}
+
+// latest time one device of an app requests configs, used to count devices
+type StatDevice struct {
+	AppKey      string `xorm:"app_key TEXT UNIQUE(uix_stat_device)" json:"app_key"`
+	DeviceId    string `xorm:"device_id TEXT UNIQUE(uix_stat_device)" json:"device_id"`
+	LastSeenUTC int    `xorm:"last_seen_utc INT INDEX" json:"last_seen_utc"`
+}
+
+func (*StatDevice) TableName() string {
+	return "stat_device"
+}
+
+func (m *StatDevice) UniqueCond() (string, []interface{}) {
+	return "app_key=? and device_id=?", []interface{}{m.AppKey, m.DeviceId}
+}
+
+func UpsertStatDevices(s *Session, devices []*StatDevice) (err error) {
+	var _s *Session
+
+	if s == nil {
+		_s = NewSession()
+		defer _s.Close()
+
+		if err = _s.Begin(); err != nil {
+			return err
+		}
+	} else {
+		_s = s
+	}
+
+	for _, device := range devices {
+		_, err = _s.Exec(
+			"INSERT OR REPLACE INTO stat_device(app_key, device_id, last_seen_utc) "+
+				"SELECT ?, ?, MAX(?, IFNULL((SELECT last_seen_utc FROM stat_device WHERE app_key=? AND device_id=?), 0))",
+			device.AppKey, device.DeviceId, device.LastSeenUTC, device.AppKey, device.DeviceId)
+		if err != nil {
+			break
+		}
+	}
+
+	if s == nil {
+		if err != nil {
+			_s.Rollback()
+		} else {
+			err = _s.Commit()
+		}
+	}
+
+	return
+}
+
+func GetStatDeviceCount(s *Session, appKey string, since int) (int, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	count, err := s.Where("app_key=? and last_seen_utc>=?", appKey, since).Count(&StatDevice{})
+	return int(count), err
+}
+
+func DeleteStatDataBefore(s *Session, utc int) error {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	if _, err := s.Exec("DELETE FROM stat_minute WHERE minute<?", utc); err != nil {
+		return err
+	}
+	if _, err := s.Exec("DELETE FROM stat_device WHERE last_seen_utc<?", utc); err != nil {
+		return err
+	}
+	if _, err := s.Exec("DELETE FROM stat_device_value WHERE last_seen_utc<?", utc); err != nil {
+		return err
+	}
+	_, err := s.Exec(
+		"DELETE FROM stat_code_value WHERE NOT EXISTS " +
+			"(SELECT 1 FROM stat_device_value d WHERE d.app_key=stat_code_value.app_key AND d.k=stat_code_value.k AND d.hash=stat_code_value.hash)")
+	return err
+}
+
+// latest evaluated value of one code config on one device
+type StatDeviceValue struct {
+	AppKey      string `xorm:"app_key TEXT UNIQUE(uix_stat_device_value)" json:"app_key"`
+	K           string `xorm:"k TEXT UNIQUE(uix_stat_device_value)" json:"k"`
+	DeviceId    string `xorm:"device_id TEXT UNIQUE(uix_stat_device_value)" json:"device_id"`
+	Hash        string `xorm:"hash TEXT" json:"hash"`
+	LastSeenUTC int    `xorm:"last_seen_utc INT INDEX" json:"last_seen_utc"`
+}
+
+func (*StatDeviceValue) TableName() string {
+	return "stat_device_value"
+}
+
+func (m *StatDeviceValue) UniqueCond() (string, []interface{}) {
+	return "app_key=? and k=? and device_id=?", []interface{}{m.AppKey, m.K, m.DeviceId}
+}
+
+// value text of one evaluated value hash
+type StatCodeValue struct {
+	AppKey string `xorm:"app_key TEXT UNIQUE(uix_stat_code_value)" json:"app_key"`
+	K      string `xorm:"k TEXT UNIQUE(uix_stat_code_value)" json:"k"`
+	Hash   string `xorm:"hash TEXT UNIQUE(uix_stat_code_value)" json:"hash"`
+	Value  string `xorm:"value TEXT" json:"value"`
+}
+
+func (*StatCodeValue) TableName() string {
+	return "stat_code_value"
+}
+
+func (m *StatCodeValue) UniqueCond() (string, []interface{}) {
+	return "app_key=? and k=? and hash=?", []interface{}{m.AppKey, m.K, m.Hash}
+}
+
+func UpsertStatDeviceValues(s *Session, deviceValues []*StatDeviceValue, codeValues []*StatCodeValue) (err error) {
+	var _s *Session
+
+	if s == nil {
+		_s = NewSession()
+		defer _s.Close()
+
+		if err = _s.Begin(); err != nil {
+			return err
+		}
+	} else {
+		_s = s
+	}
+
+	for _, v := range deviceValues {
+		// values reported late by slave nodes must not override newer ones
+		_, err = _s.Exec(
+			"INSERT OR REPLACE INTO stat_device_value(app_key, k, device_id, hash, last_seen_utc) SELECT ?, ?, ?, ?, ? "+
+				"WHERE NOT EXISTS (SELECT 1 FROM stat_device_value WHERE app_key=? AND k=? AND device_id=? AND last_seen_utc>?)",
+			v.AppKey, v.K, v.DeviceId, v.Hash, v.LastSeenUTC, v.AppKey, v.K, v.DeviceId, v.LastSeenUTC)
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		for _, v := range codeValues {
+			_, err = _s.Exec(
+				"INSERT OR IGNORE INTO stat_code_value(app_key, k, hash, value) VALUES(?, ?, ?, ?)",
+				v.AppKey, v.K, v.Hash, v.Value)
+			if err != nil {
+				break
+			}
+		}
+	}
+
+	if s == nil {
+		if err != nil {
+			_s.Rollback()
+		} else {
+			err = _s.Commit()
+		}
+	}
+
+	return
+}
+
+type StatValueCount struct {
+	Hash        string  `json:"hash"`
+	Value       string  `json:"value"`
+	DeviceCount int     `json:"device_count"`
+	Ratio       float64 `json:"ratio"`
+}
+
+// devices grouped by value of the key they got latest, for devices whose latest request is between start and end
+func GetStatValueDistribution(s *Session, appKey, k string, start, end int) ([]*StatValueCount, error) {
+	if s == nil {
+		s = newAutoCloseModelsSession()
+	}
+
+	rows, err := s.Query(
+		"SELECT d.hash AS hash, IFNULL(v.value, '') AS value, COUNT(*) AS device_count FROM stat_device_value d "+
+			"LEFT JOIN stat_code_value v ON v.app_key=d.app_key AND v.k=d.k AND v.hash=d.hash "+
+			"WHERE d.app_key=? AND d.k=? AND d.last_seen_utc>=? AND d.last_seen_utc<=? "+
+			"GROUP BY d.hash ORDER BY device_count DESC",
+		appKey, k, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*StatValueCount, 0, len(rows))
+	for _, row := range rows {
+		count := &StatValueCount{Hash: string(row["hash"]), Value: string(row["value"])}
+		count.DeviceCount, _ = strconv.Atoi(string(row["device_count"]))
+		res = append(res, count)
+	}
+
+	return res, nil
+}
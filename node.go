@@ -19,16 +19,32 @@ import (
 )
 
 const (
-	NODE_REQUEST_TYPE_SYNCSLAVE   = "SYNCSLAVE"
-	NODE_REQUEST_TYPE_CHECKMASTER = "CHECKMASTER"
-	NODE_REQUEST_TYPE_SYNCMASTER  = "SYNCMASTER"
-
-	NODE_REQUEST_SYNC_TYPE_USER    = "USER"
-	NODE_REQUEST_SYNC_TYPE_APP     = "APP"
-	NODE_REQUEST_SYNC_TYPE_WEBHOOK = "WEBHOOK"
-	NODE_REQUEST_SYNC_TYPE_CONFIG  = "CONFIG"
-	NODE_REQUEST_SYNC_TYPE_NODE    = "NODE"
-	NODE_REQUEST_SYNC_TYPE_CLONE   = "CLONE"
+	NODE_REQUEST_TYPE_SYNCSLAVE    = "SYNCSLAVE"
+	NODE_REQUEST_TYPE_CHECKMASTER  = "CHECKMASTER"
+	NODE_REQUEST_TYPE_SYNCMASTER   = "SYNCMASTER"
+	NODE_REQUEST_TYPE_STATREPORT   = "STATREPORT"
+	NODE_REQUEST_TYPE_AUDITREPORT  = "AUDITREPORT"
+	NODE_REQUEST_TYPE_SESSION      = "SESSION"
+	NODE_REQUEST_TYPE_LOGINFAILURE = "LOGINFAILURE"
+
+	NODE_REQUEST_SYNC_TYPE_USER           = "USER"
+	NODE_REQUEST_SYNC_TYPE_APP            = "APP"
+	NODE_REQUEST_SYNC_TYPE_WEBHOOK        = "WEBHOOK"
+	NODE_REQUEST_SYNC_TYPE_WEBHOOK_DELETE = "WEBHOOK_DELETE"
+	NODE_REQUEST_SYNC_TYPE_CONFIG         = "CONFIG"
+	NODE_REQUEST_SYNC_TYPE_NODE           = "NODE"
+	NODE_REQUEST_SYNC_TYPE_CLONE          = "CLONE"
+	NODE_REQUEST_SYNC_TYPE_APP_ENV        = "APP_ENV"
+	NODE_REQUEST_SYNC_TYPE_PROMOTE        = "PROMOTE"
+	NODE_REQUEST_SYNC_TYPE_API_TOKEN      = "API_TOKEN"
+	NODE_REQUEST_SYNC_TYPE_CONFIG_FREEZE  = "CONFIG_FREEZE"
+	NODE_REQUEST_SYNC_TYPE_CONFIG_BATCH   = "CONFIG_BATCH"
+	NODE_REQUEST_SYNC_TYPE_AUDIT_LOG      = "AUDIT_LOG"
+)
+
+const (
+	// by second
+	NODE_EXPIRES_CHECK_INTERVAL = 30
 )
 
 var (
@@ -43,13 +59,17 @@ type syncDataT struct {
 }
 
 type syncAllDataT struct {
-	Nodes       map[string]*models.Node       `json:"nodes"`
-	Users       map[string]*models.User       `json:"users"`
-	Apps        map[string]*models.App        `json:"apps"`
-	WebHooks    []*models.WebHook             `json:"web_hooks"`
-	Configs     map[string]*models.Config     `json:"configs"`
-	ConfHistory []*models.ConfigUpdateHistory `json:"conf_history"`
-	DataVersion *models.DataVersion           `json:"data_version"`
+	Nodes         map[string]*models.Node         `json:"nodes"`
+	Users         map[string]*models.User         `json:"users"`
+	Apps          map[string]*models.App          `json:"apps"`
+	AppEnvs       map[string]*models.AppEnv       `json:"app_envs"`
+	WebHooks      []*models.WebHook               `json:"web_hooks"`
+	APITokens     map[string]*models.APIToken     `json:"api_tokens"`
+	ConfigFreezes map[string]*models.ConfigFreeze `json:"config_freezes"`
+	Configs       map[string]*models.Config       `json:"configs"`
+	ConfHistory   []*models.ConfigUpdateHistory   `json:"conf_history"`
+	AuditLogs     []*models.AuditLog              `json:"audit_logs"`
+	DataVersion   *models.DataVersion             `json:"data_version"`
 }
 
 type nodeRequestDataT struct {
@@ -62,6 +82,20 @@ type cloneData struct {
 	Configs []*models.Config `json:"configs"`
 }
 
+type deleteWebHookData struct {
+	Hook *models.WebHook `json:"hook"`
+}
+
+type promoteData struct {
+	AppKey  string           `json:"app_key"`
+	Configs []*models.Config `json:"configs"`
+}
+
+type configBatchData struct {
+	AppKey  string           `json:"app_key"`
+	Configs []*models.Config `json:"configs"`
+}
+
 func init() {
 	var err error
 	nodeAuthToken := jwt.New(jwt.SigningMethodHS256)
@@ -85,9 +119,53 @@ func init() {
 				go slaveCheckMaster()
 			}
 		}()
+	} else if conf.DataExpires > 0 {
+		go masterCheckSlaveExpires()
+	}
+}
+
+// webhooks are notified once when a slave node stops checking in for longer than DataExpires
+func masterCheckSlaveExpires() {
+	expiredNodes := make(map[string]bool)
+	for {
+		time.Sleep(NODE_EXPIRES_CHECK_INTERVAL * time.Second)
+
+		memConfMux.RLock()
+		nodes := getNewExpiredSlaveNodes(expiredNodes, utils.GetNowSecond()-conf.DataExpires)
+		dataVersion := memConfDataVersion.Version
+		memConfMux.RUnlock()
+
+		for _, node := range nodes {
+			TriggerNodeWebHooks(WEBHOOK_EVENT_NODE_EXPIRED, []*webHookEventNode{node}, dataVersion)
+		}
 	}
 }
 
+// slave nodes not checking in since the given time and not in expiredNodes yet, expiredNodes is updated,
+// caller must hold memConfMux's read lock
+func getNewExpiredSlaveNodes(expiredNodes map[string]bool, utc int) []*webHookEventNode {
+	var res []*webHookEventNode
+	for _, node := range memConfNodes {
+		if node.Type == models.NODE_TYPE_MASTER {
+			continue
+		}
+		if node.LastCheckUTC >= utc {
+			delete(expiredNodes, node.URL)
+			continue
+		}
+		if !expiredNodes[node.URL] {
+			expiredNodes[node.URL] = true
+			res = append(res, &webHookEventNode{
+				URL:          node.URL,
+				DataVersion:  node.DataVersion.Version,
+				LastCheckUTC: node.LastCheckUTC,
+			})
+		}
+	}
+
+	return res
+}
+
 func checkNodeValidity() {
 	nodes, err := models.GetAllNode(nil)
 	if err != nil {
@@ -209,6 +287,20 @@ func syncData2SlaveIfNeed(data interface{}, opUserKey string) []map[string]inter
 		}
 	}
 
+	if len(failedNodes) > 0 {
+		nodes := make([]*webHookEventNode, len(failedNodes))
+		for ix, failedNode := range failedNodes {
+			node := failedNode["node"].(*models.Node)
+			nodes[ix] = &webHookEventNode{
+				URL:          node.URL,
+				DataVersion:  node.DataVersion.Version,
+				LastCheckUTC: node.LastCheckUTC,
+				Error:        failedNode["err"].(string),
+			}
+		}
+		go TriggerNodeWebHooks(WEBHOOK_EVENT_NODE_SYNC_FAILED, nodes, memConfDataVersion.Version)
+	}
+
 	return failedNodes
 }
 
@@ -221,12 +313,26 @@ func syncData2Slave(node *models.Node, data interface{}, dataVer *models.DataVer
 		kind = NODE_REQUEST_SYNC_TYPE_APP
 	case *models.WebHook:
 		kind = NODE_REQUEST_SYNC_TYPE_WEBHOOK
+	case *deleteWebHookData:
+		kind = NODE_REQUEST_SYNC_TYPE_WEBHOOK_DELETE
 	case *models.Config:
 		kind = NODE_REQUEST_SYNC_TYPE_CONFIG
 	case *models.Node:
 		kind = NODE_REQUEST_SYNC_TYPE_NODE
 	case *cloneData:
 		kind = NODE_REQUEST_SYNC_TYPE_CLONE
+	case *models.AppEnv:
+		kind = NODE_REQUEST_SYNC_TYPE_APP_ENV
+	case *promoteData:
+		kind = NODE_REQUEST_SYNC_TYPE_PROMOTE
+	case *configBatchData:
+		kind = NODE_REQUEST_SYNC_TYPE_CONFIG_BATCH
+	case *models.APIToken:
+		kind = NODE_REQUEST_SYNC_TYPE_API_TOKEN
+	case *models.ConfigFreeze:
+		kind = NODE_REQUEST_SYNC_TYPE_CONFIG_FREEZE
+	case *models.AuditLog:
+		kind = NODE_REQUEST_SYNC_TYPE_AUDIT_LOG
 	default:
 		log.Panicln("unknown node data sync type: ", reflect.TypeOf(data))
 	}
@@ -245,7 +351,7 @@ func syncData2Slave(node *models.Node, data interface{}, dataVer *models.DataVer
 	}
 	_, err := nodeRequest(node.NodeURL, NODE_REQUEST_TYPE_SYNCSLAVE, reqData)
 
-	if err == nil && kind != NODE_REQUEST_SYNC_TYPE_NODE {
+	if err == nil && kind != NODE_REQUEST_SYNC_TYPE_NODE && kind != NODE_REQUEST_SYNC_TYPE_AUDIT_LOG {
 		// update slave data version here
 		dataVersionStr, _ := json.Marshal(dataVer)
 		memConfMux.Lock()
@@ -309,6 +415,9 @@ func slaveCheckMaster() error {
 
 	var users []*models.User
 	var apps []*models.App
+	var appEnvs []*models.AppEnv
+	var apiTokens []*models.APIToken
+	var configFreezes []*models.ConfigFreeze
 	var configs []*models.Config
 	var nodes []*models.Node
 
@@ -367,6 +476,16 @@ func slaveCheckMaster() error {
 		return err
 	}
 
+	toInsertModels = make([]interface{}, 0)
+	for _, env := range resData.AppEnvs {
+		toInsertModels = append(toInsertModels, env)
+		appEnvs = append(appEnvs, env)
+	}
+	if err = models.InsertMultiRows(s, toInsertModels); err != nil {
+		s.Rollback()
+		return err
+	}
+
 	toInsertModels = make([]interface{}, len(resData.WebHooks))
 	for ix, hook := range resData.WebHooks {
 		toInsertModels[ix] = hook
@@ -376,6 +495,26 @@ func slaveCheckMaster() error {
 		return err
 	}
 
+	toInsertModels = make([]interface{}, 0)
+	for _, apiToken := range resData.APITokens {
+		toInsertModels = append(toInsertModels, apiToken)
+		apiTokens = append(apiTokens, apiToken)
+	}
+	if err = models.InsertMultiRows(s, toInsertModels); err != nil {
+		s.Rollback()
+		return err
+	}
+
+	toInsertModels = make([]interface{}, 0)
+	for _, freeze := range resData.ConfigFreezes {
+		toInsertModels = append(toInsertModels, freeze)
+		configFreezes = append(configFreezes, freeze)
+	}
+	if err = models.InsertMultiRows(s, toInsertModels); err != nil {
+		s.Rollback()
+		return err
+	}
+
 	toInsertModels = make([]interface{}, 0)
 	for _, config := range resData.Configs {
 		toInsertModels = append(toInsertModels, config)
@@ -395,6 +534,15 @@ func slaveCheckMaster() error {
 		return err
 	}
 
+	toInsertModels = make([]interface{}, len(resData.AuditLogs))
+	for ix, auditLog := range resData.AuditLogs {
+		toInsertModels[ix] = auditLog
+	}
+	if err = models.InsertMultiRows(s, toInsertModels); err != nil {
+		s.Rollback()
+		return err
+	}
+
 	if err = models.UpdateDataVersion(s, resData.DataVersion); err != nil {
 		s.Rollback()
 		return err
@@ -405,7 +553,7 @@ func slaveCheckMaster() error {
 		return err
 	}
 
-	fillMemConfData(users, apps, resData.WebHooks, configs, nodes, resData.DataVersion)
+	fillMemConfData(users, apps, appEnvs, resData.WebHooks, apiTokens, configFreezes, configs, nodes, resData.DataVersion)
 
 	nodeString, _ = json.Marshal(&localNode)
 	reqData = nodeRequestDataT{
@@ -494,6 +642,14 @@ func NodeRequestHandler(c *gin.Context) {
 		handleSlaveCheckMaster(c, reqData.Data)
 	case NODE_REQUEST_TYPE_SYNCMASTER:
 		handleSyncMaster(c, reqData.Data)
+	case NODE_REQUEST_TYPE_STATREPORT:
+		handleStatisticReport(c, reqData.Data)
+	case NODE_REQUEST_TYPE_AUDITREPORT:
+		handleAuditLogReport(c, reqData.Data)
+	case NODE_REQUEST_TYPE_SESSION:
+		handleOpSessionRequest(c, reqData.Data)
+	case NODE_REQUEST_TYPE_LOGINFAILURE:
+		handleLoginFailureRequest(c, reqData.Data)
 	default:
 		Error(c, BAD_REQUEST, "unknown node request type")
 	}
@@ -515,7 +671,7 @@ func handleSlaveSyncUpdateData(c *gin.Context, data string) {
 	confWriteMux.Lock()
 	defer confWriteMux.Unlock()
 
-	if syncData.Kind != NODE_REQUEST_SYNC_TYPE_NODE {
+	if syncData.Kind != NODE_REQUEST_SYNC_TYPE_NODE && syncData.Kind != NODE_REQUEST_SYNC_TYPE_AUDIT_LOG {
 		if memConfDataVersion.Version+1 != syncData.DataVersion.Version {
 			Error(c, DATA_VERSION_ERROR, "slave node data version [%d] error for master data version [%d]", memConfDataVersion.Version, syncData.DataVersion.Version)
 			return
@@ -560,6 +716,39 @@ func handleSlaveSyncUpdateData(c *gin.Context, data string) {
 			return
 		}
 
+	case NODE_REQUEST_SYNC_TYPE_WEBHOOK_DELETE:
+		data := &deleteWebHookData{}
+		if err = json.Unmarshal([]byte(syncData.Data), data); err != nil || data.Hook == nil {
+			Error(c, BAD_REQUEST, "bad data format for webHook deletion")
+			return
+		}
+		if err = deleteWebHook(data.Hook, syncData.DataVersion); err != nil {
+			Error(c, SERVER_ERROR, err.Error())
+			return
+		}
+
+	case NODE_REQUEST_SYNC_TYPE_API_TOKEN:
+		apiToken := &models.APIToken{}
+		if err = json.Unmarshal([]byte(syncData.Data), apiToken); err != nil {
+			Error(c, BAD_REQUEST, "bad data format for api token model")
+			return
+		}
+		if _, err = updateAPIToken(apiToken, syncData.DataVersion); err != nil {
+			Error(c, SERVER_ERROR, err.Error())
+			return
+		}
+
+	case NODE_REQUEST_SYNC_TYPE_CONFIG_FREEZE:
+		freeze := &models.ConfigFreeze{}
+		if err = json.Unmarshal([]byte(syncData.Data), freeze); err != nil {
+			Error(c, BAD_REQUEST, "bad data format for config freeze model")
+			return
+		}
+		if _, err = updateConfigFreeze(freeze, syncData.DataVersion); err != nil {
+			Error(c, SERVER_ERROR, err.Error())
+			return
+		}
+
 	case NODE_REQUEST_SYNC_TYPE_CONFIG:
 		config := &models.Config{}
 		if err = json.Unmarshal([]byte(syncData.Data), config); err != nil {
@@ -597,6 +786,20 @@ func handleSlaveSyncUpdateData(c *gin.Context, data string) {
 		Success(c, nil)
 		return
 
+	case NODE_REQUEST_SYNC_TYPE_AUDIT_LOG:
+		auditLog := &models.AuditLog{}
+		if err = json.Unmarshal([]byte(syncData.Data), auditLog); err != nil {
+			Error(c, BAD_REQUEST, "bad data format for audit log model")
+			return
+		}
+		if _, err = storeNodeAuditLog(auditLog); err != nil {
+			Error(c, SERVER_ERROR, err.Error())
+			return
+		}
+
+		Success(c, nil)
+		return
+
 	case NODE_REQUEST_SYNC_TYPE_CLONE:
 		data := &cloneData{}
 		if err := json.Unmarshal([]byte(syncData.Data), data); err != nil {
@@ -609,6 +812,41 @@ func handleSlaveSyncUpdateData(c *gin.Context, data string) {
 			return
 		}
 
+	case NODE_REQUEST_SYNC_TYPE_APP_ENV:
+		env := &models.AppEnv{}
+		if err = json.Unmarshal([]byte(syncData.Data), env); err != nil {
+			Error(c, BAD_REQUEST, "bad data format for app env model")
+			return
+		}
+		if _, err = updateAppEnv(env, syncData.DataVersion); err != nil {
+			Error(c, SERVER_ERROR, err.Error())
+			return
+		}
+
+	case NODE_REQUEST_SYNC_TYPE_PROMOTE:
+		data := &promoteData{}
+		if err := json.Unmarshal([]byte(syncData.Data), data); err != nil {
+			Error(c, BAD_REQUEST, "bad data format for env promotion")
+			return
+		}
+
+		if err := promoteConfigs(data.AppKey, data.Configs, syncData.OpUserKey, syncData.DataVersion); err != nil {
+			Error(c, SERVER_ERROR, err.Error())
+			return
+		}
+
+	case NODE_REQUEST_SYNC_TYPE_CONFIG_BATCH:
+		data := &configBatchData{}
+		if err := json.Unmarshal([]byte(syncData.Data), data); err != nil {
+			Error(c, BAD_REQUEST, "bad data format for config batch")
+			return
+		}
+
+		if err := updateConfigs(data.Configs, syncData.OpUserKey, syncData.DataVersion); err != nil {
+			Error(c, SERVER_ERROR, err.Error())
+			return
+		}
+
 	default:
 		Error(c, BAD_REQUEST, "unknown node data sync type: "+syncData.Kind)
 		return
@@ -682,25 +920,155 @@ func handleSyncMaster(c *gin.Context, data string) {
 		return
 	}
 
+	auditLogs, err := models.GetAllAuditLogs(nil)
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
 	memConfMux.RLock()
 	webHooks := memConfGlobalWebHooks
 	for _, hooks := range memConfAppWebHooks {
 		webHooks = append(webHooks, hooks...)
 	}
 	resData, _ := json.Marshal(syncAllDataT{
-		Nodes:       memConfNodes,
-		Users:       memConfUsers,
-		Apps:        memConfApps,
-		WebHooks:    webHooks,
-		Configs:     memConfRawConfigs,
-		DataVersion: memConfDataVersion,
-		ConfHistory: history,
+		Nodes:         memConfNodes,
+		Users:         memConfUsers,
+		Apps:          memConfApps,
+		AppEnvs:       memConfEnvs,
+		WebHooks:      webHooks,
+		APITokens:     memConfAPITokens,
+		ConfigFreezes: memConfConfigFreezes,
+		Configs:       memConfRawConfigs,
+		DataVersion:   memConfDataVersion,
+		ConfHistory:   history,
+		AuditLogs:     auditLogs,
 	})
 	memConfMux.RUnlock()
 
 	Success(c, string(resData))
 }
 
+func handleStatisticReport(c *gin.Context, data string) {
+	if !conf.IsMasterNode() {
+		Error(c, BAD_REQUEST, "invalid req type for slave node: "+NODE_REQUEST_TYPE_STATREPORT)
+		return
+	}
+
+	reportData := &localStatisticReportData{}
+	if err := json.Unmarshal([]byte(data), reportData); err != nil {
+		Error(c, BAD_REQUEST, "bad req body format")
+		return
+	}
+
+	if err := storeLocalStatisticData(reportData); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	Success(c, nil)
+}
+
+func handleAuditLogReport(c *gin.Context, data string) {
+	if !conf.IsMasterNode() {
+		Error(c, BAD_REQUEST, "invalid req type for slave node: "+NODE_REQUEST_TYPE_AUDITREPORT)
+		return
+	}
+
+	auditLog := &models.AuditLog{}
+	if err := json.Unmarshal([]byte(data), auditLog); err != nil {
+		Error(c, BAD_REQUEST, "bad req body format")
+		return
+	}
+
+	isNew, err := storeNodeAuditLog(auditLog)
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	if isNew {
+		queueAuditLogSync2Slaves(auditLog)
+	}
+
+	Success(c, nil)
+}
+
+func handleOpSessionRequest(c *gin.Context, data string) {
+	if !conf.IsMasterNode() {
+		Error(c, BAD_REQUEST, "invalid req type for slave node: "+NODE_REQUEST_TYPE_SESSION)
+		return
+	}
+
+	reqData := &opSessionRequestData{}
+	if err := json.Unmarshal([]byte(data), reqData); err != nil {
+		Error(c, BAD_REQUEST, "bad req body format")
+		return
+	}
+
+	var res interface{}
+	var err error
+	switch reqData.Op {
+	case OP_SESSION_REQUEST_GET:
+		var session *models.UserSession
+		if session, err = getOpSession(reqData.Key); err == nil {
+			res = newNodeOpSession(session)
+		}
+	case OP_SESSION_REQUEST_SAVE:
+		if reqData.Session == nil || reqData.Session.Session == nil {
+			Error(c, BAD_REQUEST, "bad data format for session")
+			return
+		}
+		err = saveOpSession(reqData.Session.userSession())
+	case OP_SESSION_REQUEST_LIST:
+		res, err = getActiveOpSessions(reqData.UserKey)
+	case OP_SESSION_REQUEST_REVOKE_USER:
+		err = revokeUserOpSessions(reqData.UserKey, reqData.ExceptKey)
+	default:
+		Error(c, BAD_REQUEST, "unknown session request op: "+reqData.Op)
+		return
+	}
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	bs, _ := json.Marshal(res)
+	Success(c, string(bs))
+}
+
+func handleLoginFailureRequest(c *gin.Context, data string) {
+	if !conf.IsMasterNode() {
+		Error(c, BAD_REQUEST, "invalid req type for slave node: "+NODE_REQUEST_TYPE_LOGINFAILURE)
+		return
+	}
+
+	reqData := &loginFailureRequestData{}
+	if err := json.Unmarshal([]byte(data), reqData); err != nil || reqData.Key == "" {
+		Error(c, BAD_REQUEST, "bad req body format")
+		return
+	}
+
+	var lockedUntil int
+	var err error
+	switch reqData.Op {
+	case LOGIN_FAILURE_REQUEST_GET:
+		lockedUntil, err = getLoginLockedUntil(reqData.Key)
+	case LOGIN_FAILURE_REQUEST_RECORD:
+		err = recordLoginFailure(reqData.Key)
+	case LOGIN_FAILURE_REQUEST_CLEAR:
+		err = clearLoginFailure(reqData.Key)
+	default:
+		Error(c, BAD_REQUEST, "unknown login failure request op: "+reqData.Op)
+		return
+	}
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	Success(c, lockedUntil)
+}
+
 func masterSyncNodeToSlave(node *models.Node) {
 	nodes := make([]*models.Node, 0)
 	memConfMux.RLock()
@@ -1,18 +1,15 @@
 package main
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
-	"time"
 
 	"github.com/Instafig/Instafig/conf"
 	"github.com/Instafig/Instafig/models"
 	"github.com/Instafig/Instafig/utils"
-	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
 )
 
@@ -32,6 +29,15 @@ func ConfWriteCheck(c *gin.Context) {
 	if !conf.IsMasterNode() {
 		Error(c, NOT_PERMITTED, "You can not update config data as you connecting to slave node,")
 		c.Abort()
+		return
+	}
+
+	memConfMux.RLock()
+	user := memConfUsers[getOpUserKey(c)]
+	memConfMux.RUnlock()
+	if user != nil && isViewerUser(user) {
+		Error(c, NOT_PERMITTED, "You can not update config data as a viewer")
+		c.Abort()
 	}
 }
 
@@ -57,21 +63,48 @@ func Login(c *gin.Context) {
 	var data struct {
 		Name     string `json:"name" binding:"required"`
 		PassCode string `json:"pass_code" binding:"required"`
+		Provider string `json:"provider"` // empty for instafig passcode
+
+		// for users enabled totp
+		TOTPCode     string `json:"totp_code"`
+		RecoveryCode string `json:"recovery_code"`
 	}
 	if err := c.BindJSON(&data); err != nil {
 		Error(c, BAD_POST_DATA, err.Error())
 		return
 	}
+	setAuditLog(c, AUDIT_ACTION_LOGIN, "", nil, map[string]string{"name": data.Name, "provider": data.Provider})
+
+	if data.Provider != "" && data.Provider != "local" {
+		loginWithProvider(c, data.Provider, data.Name, data.PassCode, data.TOTPCode, data.RecoveryCode)
+		return
+	}
 
 	memConfMux.RLock()
 	user := memConfUsersByName[data.Name]
 	memConfMux.RUnlock()
 
-	if user == nil {
+	if user == nil || user.Source != models.USER_SOURCE_LOCAL {
 		Error(c, USER_NOT_EXIST)
 		return
 	}
-	if user.PassCode != encryptUserPassCode(data.PassCode) {
+
+	lockedUntil, err := getLoginLockedUntil(user.Key)
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	if lockedUntil > 0 {
+		Error(c, USER_LOCKED, fmt.Sprintf("too many failed logins, user is locked until %d", lockedUntil))
+		return
+	}
+
+	ok, needRehash := verifyUserPassCode(user.PassCode, data.PassCode)
+	if !ok {
+		if err := recordLoginFailure(user.Key); err != nil {
+			Error(c, SERVER_ERROR, err.Error())
+			return
+		}
 		Error(c, PASS_CODE_ERROR)
 		return
 	}
@@ -81,12 +114,57 @@ func Login(c *gin.Context) {
 		return
 	}
 
-	setUserKeyCookie(c, user.Key, user.PassCode)
+	verifiedUser, errCode, err := verifyLoginSecondFactor(user, data.TOTPCode, data.RecoveryCode)
+	if err != nil {
+		if errCode == TOTP_CODE_ERROR {
+			if err := recordLoginFailure(user.Key); err != nil {
+				Error(c, SERVER_ERROR, err.Error())
+				return
+			}
+		}
+		Error(c, errCode, err.Error())
+		return
+	}
+	user = verifiedUser
+
+	// failures are cleared only after both factors verified, or totp codes could be
+	// guessed without limit by anyone knowing the passcode
+	if err := clearLoginFailure(user.Key); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	// slave nodes can not write users, passcode is rehashed when user logins with master
+	if needRehash && conf.IsMasterNode() {
+		if user, err = rehashUserPassCode(user.Key, user.PassCode, data.PassCode); err != nil {
+			logger.Error(map[string]interface{}{
+				"type":  "passcode_rehash",
+				"error": err.Error(),
+			})
+		}
+	}
+
+	if err := startOpSession(c, user); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
 	Success(c, nil)
 }
 
 func Logout(c *gin.Context) {
-	deleteUserKeyCookie(c)
+	setAuditLog(c, AUDIT_ACTION_LOGOUT, "", nil, nil)
+	if sessionKey := getOpSessionKey(c); sessionKey != "" {
+		session, err := getOpSession(sessionKey)
+		if err == nil && session != nil {
+			err = revokeOpSession(session)
+		}
+		if err != nil {
+			Error(c, SERVER_ERROR, err.Error())
+			return
+		}
+	}
+
+	deleteOpSessionCookie(c)
 	Success(c, nil)
 }
 
@@ -123,8 +201,12 @@ func InitUser(c *gin.Context) {
 		return
 	}
 
+	setAuditLog(c, AUDIT_ACTION_USER_INIT, user.Key, nil, user)
 	failedNodes := syncData2SlaveIfNeed(user, key)
-	setUserKeyCookie(c, user.Key, user.PassCode)
+	if err := startOpSession(c, user); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
 	if len(failedNodes) > 0 {
 		Success(c, map[string]interface{}{"failed_nodes": failedNodes})
 	} else {
@@ -153,6 +235,8 @@ func NewUser(c *gin.Context) {
 		return
 	}
 
+	setAuditLog(c, AUDIT_ACTION_USER_NEW, user.Key, nil, user)
+	go TriggerUserWebHooks(WEBHOOK_EVENT_USER_NEW, user, getOpUserKey(c))
 	failedNodes := syncData2SlaveIfNeed(user, getOpUserKey(c))
 	if len(failedNodes) > 0 {
 		Success(c, map[string]interface{}{"failed_nodes": failedNodes})
@@ -170,11 +254,7 @@ func verifyNewUserData(data *newUserData) error {
 		return fmt.Errorf("user name too short, length must bigger than 2")
 	}
 
-	if len(data.PassCode) < 6 {
-		return fmt.Errorf("user passcode too short, length must bigger than 6")
-	}
-
-	return nil
+	return verifyPassCodePolicy(data.PassCode)
 }
 
 func newUserWithNewUserData(data *newUserData, userKey, creatorKey string) (*models.User, error) {
@@ -185,6 +265,7 @@ func newUserWithNewUserData(data *newUserData, userKey, creatorKey string) (*mod
 		CreatedUTC: utils.GetNowSecond(),
 		AuxInfo:    data.AuxInfo,
 		Status:     models.USER_STATUS_ACTIVE,
+		Role:       models.USER_ROLE_MEMBER,
 		Key:        userKey}
 
 	return updateUser(user, nil)
@@ -222,6 +303,8 @@ func UpdateUser(c *gin.Context) {
 		return
 	}
 
+	setAuditLog(c, AUDIT_ACTION_USER_UPDATE, user.Key, oldUser, user)
+	go TriggerUserWebHooks(WEBHOOK_EVENT_USER_UPDATE, user, getOpUserKey(c))
 	failedNodes := syncData2SlaveIfNeed(user, getOpUserKey(c))
 	if len(failedNodes) > 0 {
 		Success(c, map[string]interface{}{"failed_nodes": failedNodes})
@@ -261,15 +344,33 @@ func UpdateUserPassCode(c *gin.Context) {
 		return
 	}
 
-	user := *memConfUsers[getOpUserKey(c)]
+	oldUser := memConfUsers[getOpUserKey(c)]
+	user := *oldUser
+	if user.Source != models.USER_SOURCE_LOCAL {
+		Error(c, BAD_REQUEST, "passcode of user is managed by "+user.Source)
+		return
+	}
+	if err := verifyPassCodePolicy(data.PassCode); err != nil {
+		Error(c, BAD_REQUEST, err.Error())
+		return
+	}
 	user.PassCode = encryptUserPassCode(data.PassCode)
+	user.PassCodeVersion++
 
 	if _, err := updateUser(&user, nil); err != nil {
 		Error(c, SERVER_ERROR, err.Error())
 		return
 	}
 
-	setUserKeyCookie(c, user.Key, user.PassCode)
+	if err := revokeUserOpSessions(user.Key, ""); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	if err := startOpSession(c, &user); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	setAuditLog(c, AUDIT_ACTION_USER_PASS_CODE, user.Key, oldUser, &user)
 	failedNodes := syncData2SlaveIfNeed(&user, getOpUserKey(c))
 	if len(failedNodes) > 0 {
 		Success(c, map[string]interface{}{"failed_nodes": failedNodes})
@@ -291,10 +392,11 @@ func UpdateUserStatus(c *gin.Context) {
 		return
 	}
 
-	user := *memConfUsers[data.UserKey]
+	oldUser := memConfUsers[data.UserKey]
+	user := *oldUser
 	opUser := memConfUsers[getOpUserKey(c)]
-	if opUser.CreatorKey != opUser.Key {
-		Error(c, NOT_PERMITTED, "can not update user's status as current user is non-init user")
+	if !isAdminUser(opUser) {
+		Error(c, NOT_PERMITTED, "can not update user's status as current user is not admin")
 		return
 	}
 	if user.Key == opUser.Key {
@@ -307,6 +409,12 @@ func UpdateUserStatus(c *gin.Context) {
 		Error(c, SERVER_ERROR, err.Error())
 		return
 	}
+	event := WEBHOOK_EVENT_USER_UPDATE
+	if user.Status == models.USER_STATUS_INACTIVE {
+		event = WEBHOOK_EVENT_USER_DEACTIVATE
+	}
+	setAuditLog(c, AUDIT_ACTION_USER_STATUS, user.Key, oldUser, &user)
+	go TriggerUserWebHooks(event, &user, getOpUserKey(c))
 	failedNodes := syncData2SlaveIfNeed(&user, getOpUserKey(c))
 	if len(failedNodes) > 0 {
 		Success(c, map[string]interface{}{"failed_nodes": failedNodes})
@@ -382,7 +490,7 @@ func GetUsers(c *gin.Context) {
 
 	memConfMux.RLock()
 	for _, user := range users {
-		user.PassCode = ""
+		hideUserSecrets(user)
 		if memConfUsers[user.CreatorKey] != nil {
 			user.CreatorName = memConfUsers[user.CreatorKey].Name
 		}
@@ -464,9 +572,10 @@ func NewApp(c *gin.Context) {
 	defer confWriteMux.Unlock()
 
 	var data struct {
-		Name    string `json:"name" binding:"required"`
-		Type    string `json:"type" binding:"required"`
-		AuxInfo string `json:"aux_info"`
+		Name      string `json:"name" binding:"required"`
+		Type      string `json:"type" binding:"required"`
+		AuxInfo   string `json:"aux_info"`
+		ParentKey string `json:"parent_key"`
 	}
 	if err := c.BindJSON(&data); err != nil {
 		Error(c, BAD_POST_DATA, err.Error())
@@ -484,18 +593,30 @@ func NewApp(c *gin.Context) {
 	}
 
 	app := &models.App{
-		Key:        utils.GenerateKey(),
-		Name:       data.Name,
-		UserKey:    getOpUserKey(c),
-		Type:       data.Type,
-		AuxInfo:    data.AuxInfo,
-		CreatedUTC: utils.GetNowSecond(),
+		Key:          utils.GenerateKey(),
+		Name:         data.Name,
+		UserKey:      getOpUserKey(c),
+		Type:         data.Type,
+		AuxInfo:      data.AuxInfo,
+		CreatedUTC:   utils.GetNowSecond(),
+		ParentKey:    data.ParentKey,
+		InfoUpdateId: utils.GenerateKey(),
+	}
+	if app.ParentKey != "" {
+		if err := verifyAppTemplateRef(app.Key, app.ParentKey); err != nil {
+			Error(c, BAD_REQUEST, err.Error())
+			return
+		}
+		// configs of parent are served before this app has its own
+		app.DataSign = utils.GenerateKey()
 	}
 	if _, err := updateApp(app, nil, nil); err != nil {
 		Error(c, SERVER_ERROR, err.Error())
 		return
 	}
 
+	setAuditLog(c, AUDIT_ACTION_APP_NEW, app.Key, nil, app)
+	go TriggerAppWebHooks(WEBHOOK_EVENT_APP_NEW, app, getOpUserKey(c))
 	failedNodes := syncData2SlaveIfNeed(app, getOpUserKey(c))
 	if len(failedNodes) > 0 {
 		Success(c, map[string]interface{}{"failed_nodes": failedNodes})
@@ -509,10 +630,13 @@ func UpdateApp(c *gin.Context) {
 	defer confWriteMux.Unlock()
 
 	var data struct {
-		Key     string `json:"key" binding:"required"`
-		Name    string `json:"name" binding:"required"`
-		Type    string `json:"type" binding:"required"`
-		AuxInfo string `json:"aux_info"`
+		Key       string `json:"key" binding:"required"`
+		Name      string `json:"name" binding:"required"`
+		Type      string `json:"type" binding:"required"`
+		AuxInfo   string `json:"aux_info"`
+		ParentKey string `json:"parent_key"`
+
+		InfoUpdateId string `json:"info_update_id"` // the one loaded with app
 	}
 	if err := c.BindJSON(&data); err != nil {
 		Error(c, BAD_POST_DATA, err.Error())
@@ -529,8 +653,12 @@ func UpdateApp(c *gin.Context) {
 		Error(c, BAD_REQUEST, "app key not exists: "+data.Key)
 		return
 	}
+	if errCode, err := checkUpdateConflict(oldApp.InfoUpdateId, data.InfoUpdateId, "info_update_id", "app"); err != nil {
+		Error(c, errCode, err.Error())
+		return
+	}
 
-	if oldApp.Name == data.Name && oldApp.AuxInfo == data.AuxInfo && oldApp.Type == data.Type {
+	if oldApp.Name == data.Name && oldApp.AuxInfo == data.AuxInfo && oldApp.Type == data.Type && oldApp.ParentKey == data.ParentKey {
 		Success(c, nil)
 		return
 	}
@@ -552,17 +680,29 @@ func UpdateApp(c *gin.Context) {
 	app := *oldApp
 	app.Name = data.Name
 	app.AuxInfo = data.AuxInfo
+	app.InfoUpdateId = utils.GenerateKey()
+	if oldApp.ParentKey != data.ParentKey {
+		if data.ParentKey != "" {
+			if err := verifyAppTemplateRef(app.Key, data.ParentKey); err != nil {
+				Error(c, BAD_REQUEST, err.Error())
+				return
+			}
+		}
+		app.ParentKey = data.ParentKey
+		app.DataSign = utils.GenerateKey()
+	}
 	if _, err := updateApp(&app, nil, nil); err != nil {
 		Error(c, SERVER_ERROR, err.Error())
 		return
 	}
 
-	failedNodes := syncData2SlaveIfNeed(&app, getOpUserKey(c))
-	if len(failedNodes) > 0 {
-		Success(c, map[string]interface{}{"failed_nodes": failedNodes})
-	} else {
-		Success(c, nil)
+	setAuditLog(c, AUDIT_ACTION_APP_UPDATE, app.Key, oldApp, &app)
+	go TriggerAppWebHooks(WEBHOOK_EVENT_APP_UPDATE, &app, getOpUserKey(c))
+	res := map[string]interface{}{"info_update_id": app.InfoUpdateId}
+	if failedNodes := syncData2SlaveIfNeed(&app, getOpUserKey(c)); len(failedNodes) > 0 {
+		res["failed_nodes"] = failedNodes
 	}
+	Success(c, res)
 }
 
 func updateApp(app *models.App, newDataVersion *models.DataVersion, ms *models.Session) (*models.App, error) {
@@ -607,13 +747,25 @@ func updateApp(app *models.App, newDataVersion *models.DataVersion, ms *models.S
 		}
 	}
 
+	// data sign changes with parent template, envs of the app and its dependents must follow
+	var toUpdateApps []*models.App
+	if oldApp != nil && oldApp.DataSign != app.DataSign {
+		toUpdateApps = append(getTemplateDependentApps(app.Key), app)
+		if err := updateAppsDataSign(s, toUpdateApps, app.DataSign); err != nil {
+			if ms == nil {
+				s.Rollback()
+			}
+			return nil, err
+		}
+	}
+
 	if ms == nil {
 		if err := s.Commit(); err != nil {
 			s.Rollback()
 			return nil, err
 		}
 
-		updateMemConf(app, newDataVersion, &node)
+		updateMemConf(app, newDataVersion, &node, toUpdateApps)
 	}
 
 	return app, nil
@@ -733,12 +885,50 @@ func updateWebHook(hook *models.WebHook, newDataVersion *models.DataVersion) (*m
 	return hook, nil
 }
 
+func deleteWebHook(hook *models.WebHook, newDataVersion *models.DataVersion) error {
+	s := models.NewSession()
+	defer s.Close()
+	if err := s.Begin(); err != nil {
+		s.Rollback()
+		return err
+	}
+
+	node := *memConfNodes[conf.ClientAddr]
+	if newDataVersion == nil {
+		newDataVersion = genNewDataVersion(memConfDataVersion)
+	}
+	if err := updateNodeDataVersion(s, &node, newDataVersion); err != nil {
+		s.Rollback()
+		return err
+	}
+
+	if err := models.DeleteDBModel(s, hook); err != nil {
+		s.Rollback()
+		return err
+	}
+
+	if err := s.Commit(); err != nil {
+		s.Rollback()
+		return err
+	}
+
+	updateMemConf(&deleteWebHookData{Hook: hook}, newDataVersion, &node)
+
+	return nil
+}
+
 type newConfigData struct {
 	AppKey string `json:"app_key" binding:"required"`
 	K      string `json:"k" binding:"required"`
 	V      string `json:"v"`
 	VType  string `json:"v_type" binding:"required"`
 	Des    string `json:"des"`
+	Schema string `json:"schema"`
+	Env    string `json:"env"`
+
+	// why the config is created, stored on its history
+	Message string `json:"message"`
+	Ref     string `json:"ref"`
 }
 
 func NewConfig(c *gin.Context) {
@@ -761,6 +951,7 @@ func NewConfig(c *gin.Context) {
 		Error(c, SERVER_ERROR, err.Error())
 		return
 	}
+	setAuditLog(c, AUDIT_ACTION_CONFIG_NEW, config.Key, nil, config)
 
 	failedNodes := syncData2SlaveIfNeed(config, getOpUserKey(c))
 	if len(failedNodes) > 0 {
@@ -774,6 +965,9 @@ func verifyNewConfigData(data *newConfigData) error {
 	if !models.IsValidConfValueType(data.VType) {
 		return fmt.Errorf("unknown conf type: " + data.VType)
 	}
+	if err := verifyConfigUpdateMessage(data.Message, data.Ref); err != nil {
+		return err
+	}
 
 	isSysConf := isSysConfType(data.AppKey)
 
@@ -781,53 +975,86 @@ func verifyNewConfigData(data *newConfigData) error {
 		return fmt.Errorf("app key not exists: " + data.AppKey)
 	}
 
-	switch data.VType {
+	configsKey := data.AppKey
+	if data.Env != "" {
+		if isSysConf {
+			return fmt.Errorf("sys conf has no env")
+		}
+		if env := memConfEnvs[data.Env]; env == nil || env.AppKey != data.AppKey {
+			return fmt.Errorf("app env not exists: " + data.Env)
+		}
+		configsKey = data.Env
+	}
+
+	if err := verifyConfigValue(data.AppKey, data.VType, data.V); err != nil {
+		return err
+	}
+
+	if err := verifyConfigValueSchema(data.VType, data.V, data.Schema); err != nil {
+		return err
+	}
+
+	for _, config := range memConfAppConfigs[configsKey] {
+		if config.K == data.K {
+			return fmt.Errorf("config key has existed: " + data.K)
+		}
+	}
+
+	return nil
+}
+
+// checks value against its type, shared by saving and checking configs
+func verifyConfigValue(appKey, vType, v string) error {
+	isSysConf := isSysConfType(appKey)
+
+	switch vType {
 	case models.CONF_V_TYPE_CODE:
-		if err := CheckJsonString(data.V); err != nil {
+		if err := CheckJsonString(v); err != nil {
 			return fmt.Errorf("syntax error for code type value: " + err.Error())
 		}
 	case models.CONF_V_TYPE_FLOAT:
 		if isSysConf {
 			return fmt.Errorf("sys conf must be string value")
 		}
-		if _, err := strconv.ParseFloat(data.V, 64); err != nil {
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
 			return fmt.Errorf("config Value not float")
 		}
 	case models.CONF_V_TYPE_INT:
 		if isSysConf {
 			return fmt.Errorf("sys conf must be string value")
 		}
-		if _, err := strconv.ParseInt(data.V, 10, 64); err != nil {
+		if _, err := strconv.ParseInt(v, 10, 64); err != nil {
 			return fmt.Errorf("config Value not int")
 		}
 	case models.APP_TYPE_TEMPLATE:
 		if isSysConf {
 			return fmt.Errorf("sys conf must be string value")
 		}
-		app := memConfApps[data.V]
+		app := memConfApps[v]
 		if app == nil {
-			return fmt.Errorf("template not found for: " + data.V)
+			return fmt.Errorf("template not found for: " + v)
 		}
 		if app.Type != models.APP_TYPE_TEMPLATE {
 			return fmt.Errorf("can not set a template conf that is a real app")
 		}
+		if isAppReachable(v, appKey, map[string]bool{}) {
+			return fmt.Errorf("template conf refers back to this app")
+		}
 	case models.CONF_V_TYPE_STRING:
 	// no need check
 	default:
-		return fmt.Errorf("unknown config value type: " + data.VType)
-	}
-
-	for _, config := range memConfAppConfigs[data.AppKey] {
-		if config.K == data.K {
-			return fmt.Errorf("config key has existed: " + data.K)
-		}
+		return fmt.Errorf("unknown config value type: " + vType)
 	}
 
 	return nil
 }
 
 func newConfigWithNewConfigData(data *newConfigData, userKey string) (*models.Config, error) {
-	config := &models.Config{
+	return updateConfig(genNewConfig(data, userKey), userKey, nil, nil)
+}
+
+func genNewConfig(data *newConfigData, userKey string) *models.Config {
+	return &models.Config{
 		Key:        utils.GenerateKey(),
 		AppKey:     data.AppKey,
 		K:          data.K,
@@ -837,18 +1064,41 @@ func newConfigWithNewConfigData(data *newConfigData, userKey string) (*models.Co
 		CreatorKey: userKey,
 		Des:        data.Des,
 		Status:     models.CONF_STATUS_ACTIVE,
-	}
+		Schema:     data.Schema,
+		Env:        data.Env,
 
-	return updateConfig(config, userKey, nil, nil)
+		UpdateMessage: data.Message,
+		UpdateRef:     data.Ref,
+	}
 }
 
 type updateConfigData struct {
-	Key    string `json:"key" binding:"required"`
-	K      string `json:"k" binding:"required"`
-	V      string `json:"v"`
-	VType  string `json:"v_type" binding:"required"`
-	Des    string `json:"des"`
-	Status int    `json:"status"`
+	Key    string  `json:"key" binding:"required"`
+	K      string  `json:"k" binding:"required"`
+	V      string  `json:"v"`
+	VType  string  `json:"v_type" binding:"required"`
+	Des    string  `json:"des"`
+	Status int     `json:"status"`
+	Schema *string `json:"schema"` // nil keeps schema of config, empty string clears it
+
+	LastUpdateId string `json:"last_update_id"` // the one loaded with config
+
+	// why the config is updated, stored on its history
+	Message string `json:"message"`
+	Ref     string `json:"ref"`
+}
+
+// loadedId is the update id client loaded target with, it is required so changes of others
+// are never overwritten unknowingly. error code is only meaningful when err is not nil
+func checkUpdateConflict(currentId, loadedId, idName, target string) (int, error) {
+	if loadedId == "" {
+		return BAD_REQUEST, fmt.Errorf("%s of %s required", idName, target)
+	}
+	if loadedId != currentId {
+		return UPDATE_CONFLICT, fmt.Errorf("%s has been updated by others, reload and try again", target)
+	}
+
+	return 0, nil
 }
 
 func UpdateConfig(c *gin.Context) {
@@ -867,7 +1117,11 @@ func UpdateConfig(c *gin.Context) {
 	}
 
 	oldConfig := memConfRawConfigs[data.Key]
-	if oldConfig.K == data.K && oldConfig.V == data.V && oldConfig.VType == data.VType && oldConfig.Des == data.Des && oldConfig.Status == data.Status {
+	if errCode, err := checkUpdateConflict(oldConfig.LastUpdateId, data.LastUpdateId, "last_update_id", "config"); err != nil {
+		Error(c, errCode, err.Error())
+		return
+	}
+	if !isConfigChangedByUpdateData(oldConfig, data) {
 		Success(c, nil)
 		return
 	}
@@ -877,19 +1131,34 @@ func UpdateConfig(c *gin.Context) {
 		Error(c, SERVER_ERROR, err)
 		return
 	}
+	setAuditLog(c, AUDIT_ACTION_CONFIG_UPDATE, config.Key, oldConfig, config)
 
-	failedNodes := syncData2SlaveIfNeed(config, getOpUserKey(c))
-	if len(failedNodes) > 0 {
-		Success(c, map[string]interface{}{"failed_nodes": failedNodes})
-	} else {
-		Success(c, nil)
+	res := map[string]interface{}{"last_update_id": config.LastUpdateId}
+	if failedNodes := syncData2SlaveIfNeed(config, getOpUserKey(c)); len(failedNodes) > 0 {
+		res["failed_nodes"] = failedNodes
+	}
+	Success(c, res)
+}
+
+func isConfigChangedByUpdateData(config *models.Config, data *updateConfigData) bool {
+	return config.K != data.K || config.V != data.V || config.VType != data.VType || config.Des != data.Des || config.Status != data.Status || config.Schema != getUpdateConfigSchema(config, data)
+}
+
+// schema of config after update, which is unchanged if not posted
+func getUpdateConfigSchema(config *models.Config, data *updateConfigData) string {
+	if data.Schema == nil {
+		return config.Schema
 	}
+	return *data.Schema
 }
 
 func verifyUpdateConfigData(data *updateConfigData) error {
 	if !models.IsValidConfValueType(data.VType) {
 		return fmt.Errorf("unknown conf type: " + data.VType)
 	}
+	if err := verifyConfigUpdateMessage(data.Message, data.Ref); err != nil {
+		return err
+	}
 	if !models.IsValidConfStatus(data.Status) {
 		return fmt.Errorf("unknown conf status: " + strconv.Itoa(data.Status))
 	}
@@ -899,46 +1168,16 @@ func verifyUpdateConfigData(data *updateConfigData) error {
 		return fmt.Errorf("config key not exists: " + data.Key)
 	}
 
-	isSysConf := isSysConfType(oldConfig.AppKey)
+	if err := verifyConfigValue(oldConfig.AppKey, data.VType, data.V); err != nil {
+		return err
+	}
 
-	switch data.VType {
-	case models.CONF_V_TYPE_CODE:
-		if err := CheckJsonString(data.V); err != nil {
-			return fmt.Errorf("syntax error for code type value: " + err.Error())
-		}
-	case models.CONF_V_TYPE_FLOAT:
-		if isSysConf {
-			return fmt.Errorf("sys conf must be string value")
-		}
-		if _, err := strconv.ParseFloat(data.V, 64); err != nil {
-			return fmt.Errorf("config Value not float")
-		}
-	case models.CONF_V_TYPE_INT:
-		if isSysConf {
-			return fmt.Errorf("sys conf must be string value")
-		}
-		if _, err := strconv.ParseInt(data.V, 10, 64); err != nil {
-			return fmt.Errorf("config Value not int")
-		}
-	case models.APP_TYPE_TEMPLATE:
-		if isSysConf {
-			return fmt.Errorf("sys conf must be string value")
-		}
-		app := memConfApps[data.V]
-		if app == nil {
-			return fmt.Errorf("template not found for: " + data.V)
-		}
-		if app.Type != models.APP_TYPE_TEMPLATE {
-			return fmt.Errorf("can not set a template conf that is a real app")
-		}
-	case models.CONF_V_TYPE_STRING:
-		// no need check
-	default:
-		return fmt.Errorf("unknown config value type: " + data.VType)
+	if err := verifyConfigValueSchema(data.VType, data.V, getUpdateConfigSchema(oldConfig, data)); err != nil {
+		return err
 	}
 
 	if oldConfig.K != data.K {
-		for _, config := range memConfAppConfigs[oldConfig.AppKey] {
+		for _, config := range memConfAppConfigs[getConfigsKey(oldConfig)] {
 			if config.K == data.K {
 				return fmt.Errorf("config [%s] already exists", data.K)
 			}
@@ -949,14 +1188,37 @@ func verifyUpdateConfigData(data *updateConfigData) error {
 }
 
 func updateConfigWithUpdateData(data *updateConfigData, userKey string) (*models.Config, error) {
+	return updateConfig(genUpdatedConfig(data), userKey, nil, nil)
+}
+
+func genUpdatedConfig(data *updateConfigData) *models.Config {
 	config := *memConfRawConfigs[data.Key]
 	config.K = data.K
 	config.V = data.V
 	config.VType = data.VType
 	config.Des = data.Des
 	config.Status = data.Status
+	config.Schema = getUpdateConfigSchema(&config, data)
+	config.UpdateMessage = data.Message
+	config.UpdateRef = data.Ref
 
-	return updateConfig(&config, userKey, nil, nil)
+	return &config
+}
+
+const (
+	CONFIG_UPDATE_MESSAGE_MAX_LEN = 1024
+	CONFIG_UPDATE_REF_MAX_LEN     = 128
+)
+
+func verifyConfigUpdateMessage(message, ref string) error {
+	if len(message) > CONFIG_UPDATE_MESSAGE_MAX_LEN {
+		return fmt.Errorf("message too long, length must not be bigger than %d", CONFIG_UPDATE_MESSAGE_MAX_LEN)
+	}
+	if len(ref) > CONFIG_UPDATE_REF_MAX_LEN {
+		return fmt.Errorf("ref too long, length must not be bigger than %d", CONFIG_UPDATE_REF_MAX_LEN)
+	}
+
+	return nil
 }
 
 func updateConfig(config *models.Config, userKey string, newDataVersion *models.DataVersion, ms *models.Session) (*models.Config, error) {
@@ -1005,6 +1267,11 @@ func updateConfig(config *models.Config, userKey string, newDataVersion *models.
 			OldVType:   "",
 			NewV:       config.V,
 			NewVType:   config.VType,
+			OldSchema:  "",
+			NewSchema:  config.Schema,
+			BatchId:    config.UpdateBatchId,
+			Message:    config.UpdateMessage,
+			Ref:        config.UpdateRef,
 			Kind:       models.CONFIG_UPDATE_KIND_NEW,
 			UserKey:    userKey,
 			CreatedUTC: utils.GetNowSecond(),
@@ -1025,7 +1292,9 @@ func updateConfig(config *models.Config, userKey string, newDataVersion *models.
 		}
 
 		if !isSysConf {
-			app.KeyCount++
+			if config.Env == "" {
+				app.KeyCount++
+			}
 			app.LastUpdateUTC = configHistory.CreatedUTC
 			app.LastUpdateId = configHistory.Id
 			app.UpdateTimes++
@@ -1049,6 +1318,11 @@ func updateConfig(config *models.Config, userKey string, newDataVersion *models.
 			OldVType:   oldConfig.VType,
 			NewV:       config.V,
 			NewVType:   config.VType,
+			OldSchema:  oldConfig.Schema,
+			NewSchema:  config.Schema,
+			BatchId:    config.UpdateBatchId,
+			Message:    config.UpdateMessage,
+			Ref:        config.UpdateRef,
 			Kind:       kind,
 			UserKey:    userKey,
 			CreatedUTC: utils.GetNowSecond(),
@@ -1076,7 +1350,32 @@ func updateConfig(config *models.Config, userKey string, newDataVersion *models.
 	}
 
 	var toUpdateApps []*models.App
-	if !isSysConf {
+	if !isSysConf && config.Env != "" {
+		// configs of app env only change the env's data sign
+		env, err := models.GetAppEnvByKey(s, config.Env)
+		if err == nil && env == nil {
+			err = fmt.Errorf("app env not exists: " + config.Env)
+		}
+		if err != nil {
+			if ms == nil {
+				s.Rollback()
+			}
+			return nil, err
+		}
+		env.DataSign = utils.GenerateKey()
+		if err := models.UpdateDBModel(s, env); err != nil {
+			if ms == nil {
+				s.Rollback()
+			}
+			return nil, err
+		}
+		if err := models.UpdateDBModel(s, app); err != nil {
+			if ms == nil {
+				s.Rollback()
+			}
+			return nil, err
+		}
+	} else if !isSysConf {
 		newDataSign := utils.GenerateKey()
 		app.DataSign = newDataSign
 		if err := models.UpdateDBModel(s, app); err != nil {
@@ -1087,29 +1386,14 @@ func updateConfig(config *models.Config, userKey string, newDataVersion *models.
 		}
 
 		if app.Type == models.APP_TYPE_TEMPLATE {
-			for _, _app := range memConfApps {
-				if _app.Key == config.AppKey {
-					continue
-				}
-				for _, _config := range memConfAppConfigs[_app.Key] {
-					if _config.VType == models.CONF_V_TYPE_TEMPLATE && _config.V == config.AppKey {
-						// this app has a config refer to this template app
-						toUpdateApps = append(toUpdateApps, _app)
-						break
-					}
-				}
-			}
+			toUpdateApps = getTemplateDependentApps(config.AppKey)
 		}
 
-		for _, app := range toUpdateApps {
-			_app := *app
-			_app.DataSign = newDataSign
-			if err := models.UpdateDBModel(s, &_app); err != nil {
-				if ms == nil {
-					s.Rollback()
-				}
-				return nil, err
+		if err := updateAppsDataSign(s, toUpdateApps, newDataSign); err != nil {
+			if ms == nil {
+				s.Rollback()
 			}
+			return nil, err
 		}
 	}
 
@@ -1120,9 +1404,9 @@ func updateConfig(config *models.Config, userKey string, newDataVersion *models.
 		}
 
 		if !isSysConf {
-			go TriggerWebHooks(configHistory, app)
+			go TriggerWebHooks(configHistory, app, newDataVersion.Version)
 		} else {
-			go TriggerWebHooks(configHistory, &models.App{Key: config.Key, Name: config.Key})
+			go TriggerWebHooks(configHistory, &models.App{Key: config.Key, Name: config.Key}, newDataVersion.Version)
 		}
 
 		updateMemConf(config, newDataVersion, &node, toUpdateApps)
@@ -1133,7 +1417,7 @@ func updateConfig(config *models.Config, userKey string, newDataVersion *models.
 
 func GetConfigs(c *gin.Context) {
 	appKey := c.Param("app_key")
-	configs, err := models.GetConfigsByAppKey(nil, appKey)
+	configs, err := models.GetConfigsByAppEnv(nil, appKey, c.Query("env"))
 	if err != nil {
 		Error(c, SERVER_ERROR, err.Error())
 		return
@@ -1211,6 +1495,65 @@ func GetAppConfigUpdateHistory(c *gin.Context) {
 	})
 }
 
+// histories of which message contains q or ref equals q, app_key is optional
+func SearchConfigUpdateHistory(c *gin.Context) {
+	page, err := strconv.Atoi(c.Param("page"))
+	if err != nil {
+		Error(c, BAD_REQUEST, "page not number")
+		return
+	}
+
+	count, err := strconv.Atoi(c.Param("count"))
+	if err != nil {
+		Error(c, BAD_REQUEST, "count not number")
+		return
+	}
+
+	q := strings.TrimSpace(c.Query("q"))
+	if q == "" {
+		Error(c, BAD_REQUEST, "q required")
+		return
+	}
+
+	histories, err := models.SearchConfigUpdateHistory(nil, q, c.Query("app_key"), page, count)
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	if len(histories) == 0 {
+		Success(c, map[string]interface{}{
+			"total_count": 0,
+			"list":        []interface{}{},
+		})
+		return
+	}
+
+	memConfMux.RLock()
+	for _, history := range histories {
+		history.UserName = memConfUsers[history.UserKey].Name
+		if appKey := memConfRawConfigs[history.ConfigKey].AppKey; isSysConfType(appKey) {
+			history.App = &models.App{Key: appKey, Name: appKey}
+		} else {
+			app := *memConfApps[appKey]
+			app.UserName = memConfUsers[app.UserKey].Name
+			history.App = &app
+		}
+	}
+	memConfMux.RUnlock()
+
+	totalCount, err := models.SearchConfigUpdateHistoryCount(nil, q, c.Query("app_key"))
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	Success(c, map[string]interface{}{
+		"total_count": totalCount,
+		"list":        histories,
+	})
+}
+
 func GetConfigUpdateHistoryOfUser(c *gin.Context) {
 	page, err := strconv.Atoi(c.Param("page"))
 	if err != nil {
@@ -1284,6 +1627,36 @@ func GetConfigByKey(c *gin.Context) {
 	}
 }
 
+// dry-run validation of a config value, nothing is saved
+func CheckConfig(c *gin.Context) {
+	var data struct {
+		AppKey string `json:"app_key"`
+		V      string `json:"v"`
+		VType  string `json:"v_type" binding:"required"`
+		Schema string `json:"schema"`
+	}
+	if err := c.BindJSON(&data); err != nil {
+		Error(c, BAD_POST_DATA, err.Error())
+		return
+	}
+
+	errs := make([]*codeConfigIssue, 0)
+	warnings := make([]*codeConfigIssue, 0)
+	for _, issue := range checkConfigValue(data.AppKey, data.VType, data.V, data.Schema) {
+		if issue.Level == CODE_ISSUE_LEVEL_ERROR {
+			errs = append(errs, issue)
+		} else {
+			warnings = append(warnings, issue)
+		}
+	}
+
+	Success(c, map[string]interface{}{
+		"valid":    len(errs) == 0,
+		"errors":   errs,
+		"warnings": warnings,
+	})
+}
+
 func GetNodes(c *gin.Context) {
 	memConfMux.RLock()
 	nodes := make([]*models.Node, 0)
@@ -1299,68 +1672,23 @@ func GetNodes(c *gin.Context) {
 }
 
 func OpAuth(c *gin.Context) {
-	cookie, err := c.Request.Cookie("op_user")
-	if err != nil {
-		Error(c, NOT_LOGIN, err.Error())
-		c.Abort()
-		return
-	}
-
-	token, err := jwt.Parse(cookie.Value, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+	if authorization := c.Request.Header.Get("Authorization"); strings.HasPrefix(authorization, "Bearer ") {
+		apiTokenAuth(c, strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer ")))
+	} else {
+		cookie, err := c.Request.Cookie(OP_SESSION_COOKIE)
+		if err != nil {
+			Error(c, NOT_LOGIN, err.Error())
+			c.Abort()
+			return
 		}
-		return []byte(conf.NodeAuth), nil
-	})
-	if err != nil {
-		Error(c, NOT_LOGIN, err.Error())
-		c.Abort()
-		return
-	}
-
-	if !token.Valid {
-		Error(c, NOT_LOGIN, "cookie token invalid")
-		c.Abort()
-		return
+		opSessionAuth(c, cookie.Value)
 	}
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		Error(c, NOT_LOGIN, "cookie token invalid")
-		c.Abort()
+	if c.IsAborted() {
 		return
 	}
 
-	userKey := claims["uky"].(string)
-	memConfMux.RLock()
-	defer memConfMux.RUnlock()
-
-	user := memConfUsers[userKey]
-
-	if user == nil {
-		Error(c, NOT_LOGIN, "user not exist")
-		c.Abort()
-		return
-	}
-	if claims["upc"] == nil {
-		Error(c, NOT_LOGIN, "pass_code not correct")
-		c.Abort()
-		return
-	}
-	passCode := claims["upc"].(string)
-	if user.PassCode != passCode {
-		Error(c, NOT_LOGIN, "pass_code not correct")
-		c.Abort()
-		return
-	}
-
-	if user.Status == models.USER_STATUS_INACTIVE {
-		deleteUserKeyCookie(c)
-		Error(c, USER_INACTIVE)
-		c.Abort()
-		return
-	}
-
-	setOpUserKey(c, userKey)
+	// tokens issued before totp is enforced must not let admins skip enrolling
+	totpEnrollCheck(c)
 }
 
 func InitUserCheck(c *gin.Context) {
@@ -1380,7 +1708,7 @@ func GetLoginUserInfo(c *gin.Context) {
 	user.CreatorName = memConfUsers[user.CreatorKey].Name
 	memConfMux.RUnlock()
 
-	user.PassCode = ""
+	hideUserSecrets(&user)
 
 	Success(c, user)
 }
@@ -1421,6 +1749,8 @@ func CloneAppConfigs(c *gin.Context) {
 		return
 	}
 
+	setAuditLog(c, AUDIT_ACTION_APP_CLONE, app.Key, nil, app)
+	go TriggerAppCloneWebHooks(app, fromApp, getOpUserKey(c))
 	failedNodes := syncData2SlaveIfNeed(&cloneData{App: app, Configs: configs}, getOpUserKey(c))
 	if len(failedNodes) > 0 {
 		Success(c, map[string]interface{}{"failed_nodes": failedNodes})
@@ -1446,6 +1776,12 @@ func cloneConfigsFromApp(from, to, aux_info, userKey string) (*models.App, []*mo
 		AuxInfo:    aux_info,
 		CreatedUTC: utils.GetNowSecond(),
 		Type:       fromApp.Type,
+		ParentKey:  fromApp.ParentKey,
+
+		InfoUpdateId: utils.GenerateKey(),
+	}
+	if app.ParentKey != "" {
+		app.DataSign = utils.GenerateKey()
 	}
 
 	for _, config := range fromConfigs {
@@ -1508,31 +1844,3 @@ func cloneConfigs(app *models.App, configs []*models.Config, userKey string) (er
 
 	return
 }
-
-func encryptUserPassCode(code string) string {
-	hash := hmac.New(sha256.New, []byte(conf.UserPassCodeEncryptKey))
-	hash.Write([]byte(code))
-	return fmt.Sprintf("%x", hash.Sum(nil))
-}
-
-func setUserKeyCookie(c *gin.Context, userKey, passCode string) {
-	jwtIns := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"uky": userKey,
-		"upc": passCode,
-	})
-
-	encStr, _ := jwtIns.SignedString([]byte(conf.NodeAuth))
-	cookie := new(http.Cookie)
-	cookie.Name = "op_user"
-	cookie.Expires = time.Now().Add(time.Duration(30*86400) * time.Second)
-	cookie.Value = encStr
-	cookie.Path = "/op"
-	http.SetCookie(c.Writer, cookie)
-}
-
-func deleteUserKeyCookie(c *gin.Context) {
-	cookie := new(http.Cookie)
-	cookie.Name = "op_user"
-	cookie.Value = ""
-	http.SetCookie(c.Writer, cookie)
-}
@@ -1,13 +1,19 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"reflect"
+	"strings"
 
 	"github.com/Instafig/Instafig/conf"
 	"github.com/Instafig/Instafig/models"
 	"github.com/Instafig/Instafig/utils"
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -16,6 +22,22 @@ func _clearModelData() error {
 	return models.ClearModeData(nil)
 }
 
+// posts data to op handler as user logged in and returns the json response
+func _callOpHandler(handler gin.HandlerFunc, method, userKey string, data interface{}) map[string]interface{} {
+	body, _ := json.Marshal(data)
+	req, _ := http.NewRequest(method, "/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	router := gin.New()
+	router.Handle(method, "/", func(c *gin.Context) { setOpUserKey(c, userKey) }, handler)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	res := make(map[string]interface{})
+	json.Unmarshal(w.Body.Bytes(), &res)
+	return res
+}
+
 func TestNewUser(t *testing.T) {
 	err := _clearModelData()
 	assert.True(t, err == nil, "must correctly clear data")
@@ -260,7 +282,9 @@ func initOneConfig(userName, appName, appType, configK, configV, configVType str
 		Key:     utils.GenerateKey(),
 		UserKey: user.Key,
 		Name:    appName,
-		Type:    appType}, nil, nil)
+		Type:    appType,
+
+		InfoUpdateId: utils.GenerateKey()}, nil, nil)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -376,6 +400,64 @@ func TestCloneAppConfig(t *testing.T) {
 	_clearModelData()
 }
 
+func TestAppEnvPromote(t *testing.T) {
+	err := _clearModelData()
+	assert.True(t, err == nil, "must correctly clear data")
+	loadAllData()
+	initNodeData()
+
+	user, app, _, _ := initOneConfig("rahuahua", "iconfreecn", models.APP_TYPE_REAL, "config1", "1", models.CONF_V_TYPE_INT)
+	appDataSign := memConfApps[app.Key].DataSign
+
+	dev, err := updateAppEnv(&models.AppEnv{Key: utils.GenerateKey(), AppKey: app.Key, Name: "dev", Seq: 1, DataSign: utils.GenerateKey()}, nil)
+	assert.True(t, err == nil)
+	staging, err := updateAppEnv(&models.AppEnv{Key: utils.GenerateKey(), AppKey: app.Key, Name: "staging", Seq: 2, DataSign: utils.GenerateKey()}, nil)
+	assert.True(t, err == nil)
+	assert.True(t, verifyAppEnvData(app.Key, "", "dev") != nil, "env name must be unique in app")
+	assert.True(t, getAppEnvByName(app.Key, "staging").Key == staging.Key)
+
+	for _, k := range []string{"config1", "config2"} {
+		newData := &newConfigData{K: k, V: "2", VType: models.CONF_V_TYPE_INT, AppKey: app.Key, Env: dev.Key}
+		assert.True(t, verifyNewConfigData(newData) == nil, "same key can exist in different envs")
+		_, err = newConfigWithNewConfigData(newData, user.Key)
+		assert.True(t, err == nil)
+	}
+	assert.True(t, len(memConfAppConfigs[dev.Key]) == 2 && len(memConfAppConfigs[app.Key]) == 1)
+	assert.True(t, memConfApps[app.Key].DataSign == appDataSign, "env config must not change app's data sign")
+	assert.True(t, memConfEnvs[dev.Key].DataSign != dev.DataSign)
+
+	toEnv, err := getNextAppEnvKey(app.Key, dev.Key)
+	assert.True(t, err == nil && toEnv == staging.Key)
+	toEnv, err = getNextAppEnvKey(app.Key, staging.Key)
+	assert.True(t, err == nil && toEnv == "", "default env is the last one")
+
+	diffs, configs, err := genPromoteConfigs(app.Key, staging.Key, "", []string{"config1"}, "batch", user.Key)
+	assert.True(t, err != nil, "config1 not exists in staging")
+
+	diffs, configs, err = genPromoteConfigs(app.Key, dev.Key, staging.Key, []string{"config1", "config2"}, "batch", user.Key)
+	assert.True(t, err == nil && len(diffs) == 2 && len(configs) == 2)
+	assert.True(t, diffs[0].Action == PROMOTE_ACTION_NEW)
+	assert.True(t, promoteConfigs(app.Key, configs, user.Key, nil) == nil)
+	assert.True(t, len(memConfAppConfigs[staging.Key]) == 2)
+	assert.True(t, memConfApps[app.Key].DataSign == appDataSign)
+
+	diffs, configs, err = genPromoteConfigs(app.Key, staging.Key, "", []string{"config1", "config2"}, "batch2", user.Key)
+	assert.True(t, err == nil && len(configs) == 2)
+	assert.True(t, diffs[0].Action == PROMOTE_ACTION_UPDATE && diffs[0].OldV == "1" && diffs[0].NewV == "2")
+	assert.True(t, diffs[1].Action == PROMOTE_ACTION_NEW)
+	assert.True(t, promoteConfigs(app.Key, configs, user.Key, nil) == nil)
+	assert.True(t, len(memConfAppConfigs[app.Key]) == 2)
+	assert.True(t, memConfApps[app.Key].DataSign != appDataSign)
+
+	histories, err := models.GetConfigUpdateHistory(nil, configs[0].Key)
+	assert.True(t, err == nil && histories[0].BatchId == "batch2")
+
+	diffs, configs, err = genPromoteConfigs(app.Key, staging.Key, "", []string{"config1"}, "batch3", user.Key)
+	assert.True(t, err == nil && len(configs) == 0 && diffs[0].Action == PROMOTE_ACTION_SAME)
+
+	_clearModelData()
+}
+
 func TestUpdateConfig(t *testing.T) {
 	err := _clearModelData()
 	assert.True(t, err == nil, "must correctly clear data")
@@ -394,6 +476,9 @@ func TestUpdateConfig(t *testing.T) {
 		V:      "2",
 		VType:  models.CONF_V_TYPE_STRING,
 		Status: models.CONF_STATUS_INACTIVE,
+
+		Message: "Hide config1 for release",
+		Ref:     "OPS-42",
 	}
 	err = verifyUpdateConfigData(updateData)
 	assert.True(t, err == nil, "all update data is valid")
@@ -408,6 +493,64 @@ func TestUpdateConfig(t *testing.T) {
 	assert.True(t, config.UpdateTimes == oldConfig.UpdateTimes+1)
 	assert.True(t, config.LastUpdateId != oldConfig.LastUpdateId)
 
+	history, err := models.GetConfigUpdateHistoryById(nil, config.LastUpdateId)
+	assert.True(t, err == nil && history.Message == "Hide config1 for release" && history.Ref == "OPS-42")
+
+	histories, err := models.SearchConfigUpdateHistory(nil, "release", config.AppKey, 1, 10)
+	assert.True(t, err == nil && len(histories) == 1 && histories[0].Id == history.Id, "must find history by message")
+	count, err := models.SearchConfigUpdateHistoryCount(nil, "OPS-42", "")
+	assert.True(t, err == nil && count == 1, "must find history by ref")
+	count, _ = models.SearchConfigUpdateHistoryCount(nil, "OPS-4", "")
+	assert.True(t, count == 0, "ref must match exactly")
+
+	schema := `{"type": "string"}`
+	updateData.Schema = &schema
+	config, err = updateConfigWithUpdateData(updateData, user.Key)
+	assert.True(t, err == nil && config.Schema == schema)
+	updateData.Schema = nil
+	updateData.V = "3"
+	config, err = updateConfigWithUpdateData(updateData, user.Key)
+	assert.True(t, err == nil && config.Schema == schema, "schema not posted must be kept")
+
+	updateData.Ref = strings.Repeat("x", CONFIG_UPDATE_REF_MAX_LEN+1)
+	assert.True(t, verifyUpdateConfigData(updateData) != nil, "too long ref must be rejected")
+
+	_clearModelData()
+}
+
+func TestUpdateConflict(t *testing.T) {
+	err := _clearModelData()
+	assert.True(t, err == nil, "must correctly clear data")
+	loadAllData()
+	initNodeData()
+
+	confWriteMux.Lock()
+	user, app, config, err := initOneConfig("rahuahua", "iconfreecn", models.APP_TYPE_REAL, "config1", "1", models.CONF_V_TYPE_INT)
+	confWriteMux.Unlock()
+	assert.True(t, err == nil, "must correctly add new config")
+
+	configData := map[string]interface{}{"key": config.Key, "k": config.K, "v": "2", "v_type": config.VType, "status": config.Status}
+	res := _callOpHandler(UpdateConfig, "PUT", user.Key, configData)
+	assert.True(t, res["code"] == "bad_request", "config update without last_update_id must be rejected")
+	configData["last_update_id"] = "stale"
+	res = _callOpHandler(UpdateConfig, "PUT", user.Key, configData)
+	assert.True(t, res["code"] == "update_conflict", "config update with stale last_update_id must be rejected")
+	configData["last_update_id"] = config.LastUpdateId
+	res = _callOpHandler(UpdateConfig, "PUT", user.Key, configData)
+	assert.True(t, res["status"] == true && memConfRawConfigs[config.Key].V == "2")
+	res = _callOpHandler(UpdateConfig, "PUT", user.Key, configData)
+	assert.True(t, res["code"] == "update_conflict", "last_update_id must change with update")
+
+	appData := map[string]interface{}{"key": app.Key, "name": "iconfree", "type": app.Type}
+	res = _callOpHandler(UpdateApp, "PUT", user.Key, appData)
+	assert.True(t, res["code"] == "bad_request", "app update without info_update_id must be rejected")
+	appData["info_update_id"] = "stale"
+	res = _callOpHandler(UpdateApp, "PUT", user.Key, appData)
+	assert.True(t, res["code"] == "update_conflict", "app update with stale info_update_id must be rejected")
+	appData["info_update_id"] = memConfApps[app.Key].InfoUpdateId
+	res = _callOpHandler(UpdateApp, "PUT", user.Key, appData)
+	assert.True(t, res["status"] == true && memConfApps[app.Key].Name == "iconfree")
+
 	_clearModelData()
 }
 
@@ -649,3 +792,78 @@ func TestTemplateApp(t *testing.T) {
 
 	_clearModelData()
 }
+
+func TestTemplateInheritance(t *testing.T) {
+	err := _clearModelData()
+	assert.True(t, err == nil, "must correctly clear data")
+	loadAllData()
+	initNodeData()
+
+	user, _ := updateUser(&models.User{
+		Name: "rahuahua",
+		Key:  utils.GenerateKey()}, nil)
+
+	newConfig := func(appKey, k, v string) *models.Config {
+		config, err := updateConfig(&models.Config{
+			Key:    utils.GenerateKey(),
+			AppKey: appKey,
+			K:      k,
+			V:      v,
+			VType:  models.CONF_V_TYPE_INT,
+			Status: models.CONF_STATUS_ACTIVE}, "", nil, nil)
+		assert.True(t, err == nil)
+		return config
+	}
+
+	base, _ := updateApp(&models.App{
+		Key:     utils.GenerateKey(),
+		UserKey: user.Key,
+		Name:    "base_template",
+		Type:    models.APP_TYPE_TEMPLATE}, nil, nil)
+	baseConfig := newConfig(base.Key, "a", "1")
+	newConfig(base.Key, "b", "1")
+
+	mid, _ := updateApp(&models.App{
+		Key:       utils.GenerateKey(),
+		UserKey:   user.Key,
+		Name:      "mid_template",
+		Type:      models.APP_TYPE_TEMPLATE,
+		ParentKey: base.Key}, nil, nil)
+	newConfig(mid.Key, "b", "2")
+
+	assert.True(t, verifyAppTemplateRef(utils.GenerateKey(), mid.Key) == nil)
+	app, _ := updateApp(&models.App{
+		Key:       utils.GenerateKey(),
+		UserKey:   user.Key,
+		Name:      "iconfreecn",
+		Type:      models.APP_TYPE_REAL,
+		ParentKey: mid.Key}, nil, nil)
+	newConfig(app.Key, "c", "3")
+	newConfig(app.Key, "a", "9")
+
+	appConfig := getAppMatchConf(app.Key, &ClientData{AppKey: app.Key})
+	assert.True(t, len(appConfig) == 3)
+	assert.True(t, appConfig["a"] == 9 && appConfig["b"] == 2 && appConfig["c"] == 3, "local keys must override parent's")
+	assert.True(t, getAppMatchConfWithKey(app.Key, &ClientData{}, "b")["b"] == 2)
+
+	assert.True(t, verifyAppTemplateRef(base.Key, mid.Key) != nil, "must detect parent cycle")
+	assert.True(t, verifyAppTemplateRef(base.Key, base.Key) != nil)
+	assert.True(t, verifyAppTemplateRef(mid.Key, app.Key) != nil, "real app can not be parent")
+	badData := &newConfigData{
+		K:      "template_conf",
+		V:      mid.Key,
+		VType:  models.CONF_V_TYPE_TEMPLATE,
+		AppKey: base.Key,
+	}
+	assert.True(t, verifyNewConfigData(badData) != nil, "must detect template conf cycle")
+
+	dependents := getTemplateDependentApps(base.Key)
+	assert.True(t, len(dependents) == 2)
+
+	appOldDataSign := memConfApps[app.Key].DataSign
+	updateConfig(baseConfig, "", nil, nil)
+	assert.True(t, appOldDataSign != memConfApps[app.Key].DataSign, "data sign must follow the whole template chain")
+	assert.True(t, memConfApps[mid.Key].DataSign == memConfApps[app.Key].DataSign)
+
+	_clearModelData()
+}
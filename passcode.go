@@ -0,0 +1,331 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"unicode"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	PASS_CODE_RESET_EXPIRES = 86400 // second
+
+	LOGIN_FAILURE_REQUEST_GET    = "get"
+	LOGIN_FAILURE_REQUEST_RECORD = "record"
+	LOGIN_FAILURE_REQUEST_CLEAR  = "clear"
+)
+
+var loginFailureMux = sync.Mutex{}
+
+// failures are counted by master node, or users could try max failures with each node
+type loginFailureRequestData struct {
+	Op  string `json:"op"`
+	Key string `json:"key"`
+}
+
+// returns utc the user is locked until for get op
+func loginFailureRequest2Master(op, userKey string) (int, error) {
+	bs, _ := json.Marshal(&loginFailureRequestData{Op: op, Key: userKey})
+	resData, err := nodeRequest(conf.MasterAddr, NODE_REQUEST_TYPE_LOGINFAILURE, nodeRequestDataT{
+		Auth: nodeAuthString,
+		Data: string(bs),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	lockedUntil, _ := resData.(float64)
+	return int(lockedUntil), nil
+}
+
+// stored as bcrypt hash, the bcrypt input is the hmac of user_passcode_encrypt_key so
+// the config key still works as a pepper and long passcodes are not truncated by bcrypt
+func encryptUserPassCode(code string) string {
+	return encryptUserPassCodeWithCost(code, conf.PassCodeBcryptCost)
+}
+
+func encryptUserPassCodeWithCost(code string, cost int) string {
+	hash, err := bcrypt.GenerateFromPassword([]byte(legacyEncryptUserPassCode(code)), cost)
+	if err != nil {
+		// cost is checked when conf is loaded
+		panic("failed to hash passcode: " + err.Error())
+	}
+	return string(hash)
+}
+
+// passcodes stored before kdf
+func legacyEncryptUserPassCode(code string) string {
+	hash := hmac.New(sha256.New, []byte(conf.UserPassCodeEncryptKey))
+	hash.Write([]byte(code))
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
+// needRehash is true when stored passcode is legacy or of less cost than config
+func verifyUserPassCode(stored, code string) (ok bool, needRehash bool) {
+	if stored == "" {
+		return false, false
+	}
+
+	cost, err := bcrypt.Cost([]byte(stored))
+	if err != nil {
+		return hmac.Equal([]byte(stored), []byte(legacyEncryptUserPassCode(code))), true
+	}
+	if bcrypt.CompareHashAndPassword([]byte(stored), []byte(legacyEncryptUserPassCode(code))) != nil {
+		return false, false
+	}
+
+	return true, cost < conf.PassCodeBcryptCost
+}
+
+func verifyPassCodePolicy(code string) error {
+	if len(code) < conf.PassCodeMinLength {
+		return fmt.Errorf("user passcode too short, length must not be less than %d", conf.PassCodeMinLength)
+	}
+
+	var lower, upper, digit, other int
+	for _, r := range code {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	if lower+upper+digit+other < conf.PassCodeMinClasses {
+		return fmt.Errorf("user passcode must contain at least %d kinds of lower case, upper case, digit and other chars", conf.PassCodeMinClasses)
+	}
+
+	return nil
+}
+
+// returns utc the user is locked until, 0 if not locked
+func getLoginLockedUntil(userKey string) (int, error) {
+	if conf.LoginMaxFailures <= 0 {
+		return 0, nil
+	}
+	if !conf.IsMasterNode() {
+		return loginFailureRequest2Master(LOGIN_FAILURE_REQUEST_GET, userKey)
+	}
+
+	failure, err := models.GetLoginFailure(nil, userKey)
+	if err != nil || failure == nil || failure.LockedUntilUTC <= utils.GetNowSecond() {
+		return 0, err
+	}
+
+	return failure.LockedUntilUTC, nil
+}
+
+// counts failures within lockout seconds and locks user when they reach max failures
+func recordLoginFailure(userKey string) error {
+	if conf.LoginMaxFailures <= 0 {
+		return nil
+	}
+	if !conf.IsMasterNode() {
+		_, err := loginFailureRequest2Master(LOGIN_FAILURE_REQUEST_RECORD, userKey)
+		return err
+	}
+
+	loginFailureMux.Lock()
+	defer loginFailureMux.Unlock()
+
+	failure, err := models.GetLoginFailure(nil, userKey)
+	if err != nil {
+		return err
+	}
+
+	now := utils.GetNowSecond()
+	isNew := failure == nil
+	if isNew {
+		failure = &models.LoginFailure{UserKey: userKey}
+	}
+	if now-failure.LastFailedUTC > conf.LoginLockout {
+		failure.Count = 0
+	}
+	failure.Count++
+	failure.LastFailedUTC = now
+	if failure.Count >= conf.LoginMaxFailures {
+		failure.Count = 0
+		failure.LockedUntilUTC = now + conf.LoginLockout
+	}
+
+	if isNew {
+		return models.InsertRow(nil, failure)
+	}
+	return models.UpdateDBModel(nil, failure)
+}
+
+func clearLoginFailure(userKey string) error {
+	if !conf.IsMasterNode() {
+		_, err := loginFailureRequest2Master(LOGIN_FAILURE_REQUEST_CLEAR, userKey)
+		return err
+	}
+
+	loginFailureMux.Lock()
+	defer loginFailureMux.Unlock()
+
+	return models.DeleteLoginFailure(nil, userKey)
+}
+
+// stores passcode of user with current kdf, only called by master after passcode verified.
+// passcode version is kept so sessions of user stay valid after rehash
+func rehashUserPassCode(userKey, oldPassCode, code string) (*models.User, error) {
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	memConfMux.RLock()
+	oldUser := memConfUsers[userKey]
+	memConfMux.RUnlock()
+	if oldUser == nil || oldUser.PassCode != oldPassCode {
+		// changed by others meanwhile
+		return oldUser, nil
+	}
+
+	user := *oldUser
+	user.PassCode = encryptUserPassCode(code)
+	if _, err := updateUser(&user, nil); err != nil {
+		return oldUser, err
+	}
+	syncData2SlaveIfNeed(&user, user.Key)
+
+	return &user, nil
+}
+
+func hashPassCodeResetToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+// admin issues a one-time token which the user sets new passcode with
+func ResetUserPassCode(c *gin.Context) {
+	var data struct {
+		UserKey string `json:"user_key" binding:"required"`
+	}
+	if err := c.BindJSON(&data); err != nil {
+		Error(c, BAD_POST_DATA, err.Error())
+		return
+	}
+
+	memConfMux.RLock()
+	opUser := memConfUsers[getOpUserKey(c)]
+	user := memConfUsers[data.UserKey]
+	memConfMux.RUnlock()
+
+	if !isAdminUser(opUser) {
+		Error(c, NOT_PERMITTED, "can not reset user's passcode as current user is not admin")
+		return
+	}
+	if user == nil {
+		Error(c, USER_NOT_EXIST)
+		return
+	}
+	if user.Source != models.USER_SOURCE_LOCAL {
+		Error(c, BAD_REQUEST, "passcode of user is managed by "+user.Source)
+		return
+	}
+
+	token := utils.GenerateSecret(20)
+	reset := &models.PassCodeReset{
+		Hash:       hashPassCodeResetToken(token),
+		UserKey:    user.Key,
+		CreatorKey: opUser.Key,
+		CreatedUTC: utils.GetNowSecond(),
+	}
+	reset.ExpiresUTC = reset.CreatedUTC + PASS_CODE_RESET_EXPIRES
+
+	s := models.NewSession()
+	defer s.Close()
+	if err := s.Begin(); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	// only the latest reset token works
+	if err := models.DeletePassCodeResetsOfUser(s, user.Key); err != nil {
+		s.Rollback()
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	if err := models.InsertRow(s, reset); err != nil {
+		s.Rollback()
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	if err := s.Commit(); err != nil {
+		s.Rollback()
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	setAuditLog(c, AUDIT_ACTION_USER_PASS_CODE_RESET, user.Key, nil, user)
+	// the token is only shown once
+	Success(c, map[string]interface{}{"token": token, "expires_utc": reset.ExpiresUTC})
+}
+
+func ConfirmUserPassCodeReset(c *gin.Context) {
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	var data struct {
+		Token    string `json:"token" binding:"required"`
+		PassCode string `json:"pass_code" binding:"required"`
+	}
+	if err := c.BindJSON(&data); err != nil {
+		Error(c, BAD_POST_DATA, err.Error())
+		return
+	}
+
+	reset, err := models.GetPassCodeResetByHash(nil, hashPassCodeResetToken(data.Token))
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	if reset == nil || reset.ExpiresUTC <= utils.GetNowSecond() {
+		Error(c, BAD_REQUEST, "passcode reset token invalid or expired")
+		return
+	}
+	if err := verifyPassCodePolicy(data.PassCode); err != nil {
+		Error(c, BAD_REQUEST, err.Error())
+		return
+	}
+
+	memConfMux.RLock()
+	oldUser := memConfUsers[reset.UserKey]
+	memConfMux.RUnlock()
+	if oldUser == nil {
+		Error(c, USER_NOT_EXIST)
+		return
+	}
+
+	user := *oldUser
+	user.PassCode = encryptUserPassCode(data.PassCode)
+	user.PassCodeVersion++
+	if _, err := updateUser(&user, nil); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	if err := models.DeletePassCodeResetsOfUser(nil, user.Key); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	clearLoginFailure(user.Key)
+	setAuditLog(c, AUDIT_ACTION_USER_PASS_CODE, user.Key, oldUser, &user)
+
+	failedNodes := syncData2SlaveIfNeed(&user, user.Key)
+	if len(failedNodes) > 0 {
+		Success(c, map[string]interface{}{"failed_nodes": failedNodes})
+	} else {
+		Success(c, nil)
+	}
+}
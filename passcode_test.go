@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyUserPassCode(t *testing.T) {
+	stored := encryptUserPassCode("huahua")
+	assert.True(t, stored != encryptUserPassCode("huahua"), "salt must differ between users")
+
+	ok, needRehash := verifyUserPassCode(stored, "huahua")
+	assert.True(t, ok && !needRehash)
+	ok, _ = verifyUserPassCode(stored, "xixi")
+	assert.True(t, !ok)
+
+	ok, needRehash = verifyUserPassCode(legacyEncryptUserPassCode("huahua"), "huahua")
+	assert.True(t, ok && needRehash, "legacy passcode must be rehashed")
+	ok, _ = verifyUserPassCode(legacyEncryptUserPassCode("huahua"), "xixi")
+	assert.True(t, !ok)
+
+	weak := encryptUserPassCodeWithCost("huahua", conf.PassCodeBcryptCost-1)
+	ok, needRehash = verifyUserPassCode(weak, "huahua")
+	assert.True(t, ok && needRehash, "passcode of less cost must be rehashed")
+
+	ok, _ = verifyUserPassCode("", "")
+	assert.True(t, !ok, "users without passcode must not login with passcode")
+}
+
+func TestVerifyPassCodePolicy(t *testing.T) {
+	minLength, minClasses := conf.PassCodeMinLength, conf.PassCodeMinClasses
+	defer func() { conf.PassCodeMinLength, conf.PassCodeMinClasses = minLength, minClasses }()
+
+	conf.PassCodeMinLength, conf.PassCodeMinClasses = 8, 3
+	assert.True(t, verifyPassCodePolicy("Huahua22") == nil)
+	assert.True(t, verifyPassCodePolicy("Hua22") != nil, "too short")
+	assert.True(t, verifyPassCodePolicy("huahua22") != nil, "too few kinds of chars")
+	assert.True(t, verifyPassCodePolicy("huahua_22") == nil)
+}
+
+func TestLoginFailureLockout(t *testing.T) {
+	err := _clearModelData()
+	assert.True(t, err == nil, "must correctly clear data")
+
+	maxFailures := conf.LoginMaxFailures
+	defer func() { conf.LoginMaxFailures = maxFailures }()
+	conf.LoginMaxFailures = 3
+
+	userKey := utils.GenerateKey()
+	for i := 0; i < 2; i++ {
+		assert.True(t, recordLoginFailure(userKey) == nil)
+	}
+	lockedUntil, err := getLoginLockedUntil(userKey)
+	assert.True(t, err == nil && lockedUntil == 0)
+
+	assert.True(t, recordLoginFailure(userKey) == nil)
+	lockedUntil, err = getLoginLockedUntil(userKey)
+	assert.True(t, err == nil && lockedUntil > utils.GetNowSecond(), "user must be locked after max failures")
+
+	assert.True(t, clearLoginFailure(userKey) == nil)
+	lockedUntil, err = getLoginLockedUntil(userKey)
+	assert.True(t, err == nil && lockedUntil == 0)
+
+	// slave nodes record and check failures with master
+	callMaster := func(op string) map[string]interface{} {
+		bs, _ := json.Marshal(&loginFailureRequestData{Op: op, Key: userKey})
+		router := gin.New()
+		router.POST("/", func(c *gin.Context) { handleLoginFailureRequest(c, string(bs)) })
+		req, _ := http.NewRequest(http.MethodPost, "/", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		res := make(map[string]interface{})
+		json.Unmarshal(w.Body.Bytes(), &res)
+		return res
+	}
+	for i := 0; i < 3; i++ {
+		assert.True(t, callMaster(LOGIN_FAILURE_REQUEST_RECORD)["status"] == true)
+	}
+	res := callMaster(LOGIN_FAILURE_REQUEST_GET)
+	lockedUntilF, _ := res["data"].(float64)
+	assert.True(t, res["status"] == true && int(lockedUntilF) > utils.GetNowSecond(), "failures reported by slave nodes must lock user")
+	assert.True(t, callMaster(LOGIN_FAILURE_REQUEST_CLEAR)["status"] == true)
+	lockedUntil, err = getLoginLockedUntil(userKey)
+	assert.True(t, err == nil && lockedUntil == 0)
+	assert.True(t, callMaster("unknown")["code"] == "bad_request")
+
+	_clearModelData()
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+)
+
+const OP_SESSION_COOKIE = "op_user"
+
+const (
+	OP_SESSION_REQUEST_GET         = "get"
+	OP_SESSION_REQUEST_SAVE        = "save"
+	OP_SESSION_REQUEST_LIST        = "list"
+	OP_SESSION_REQUEST_REVOKE_USER = "revoke_user"
+)
+
+// sessions are kept by master node, slave nodes read and write them with master
+// so sessions of user can be listed and revoked on any node
+type opSessionRequestData struct {
+	Op        string         `json:"op"`
+	Key       string         `json:"key"`
+	UserKey   string         `json:"user_key"`
+	ExceptKey string         `json:"except_key"`
+	Session   *nodeOpSession `json:"session"`
+}
+
+// pass_code_version of session is not in json for op api
+type nodeOpSession struct {
+	Session         *models.UserSession `json:"session"`
+	PassCodeVersion int                 `json:"pass_code_version"`
+}
+
+func newNodeOpSession(session *models.UserSession) *nodeOpSession {
+	if session == nil {
+		return &nodeOpSession{}
+	}
+	return &nodeOpSession{Session: session, PassCodeVersion: session.PassCodeVersion}
+}
+
+func (s *nodeOpSession) userSession() *models.UserSession {
+	if s.Session != nil {
+		s.Session.PassCodeVersion = s.PassCodeVersion
+	}
+	return s.Session
+}
+
+func opSessionRequest2Master(data *opSessionRequestData, res interface{}) error {
+	bs, _ := json.Marshal(data)
+	resData, err := nodeRequest(conf.MasterAddr, NODE_REQUEST_TYPE_SESSION, nodeRequestDataT{
+		Auth: nodeAuthString,
+		Data: string(bs),
+	})
+	if err != nil {
+		return err
+	}
+
+	resStr, _ := resData.(string)
+	return json.Unmarshal([]byte(resStr), res)
+}
+
+func getOpSession(key string) (*models.UserSession, error) {
+	if conf.IsMasterNode() {
+		return models.GetUserSessionByKey(nil, key)
+	}
+
+	res := &nodeOpSession{}
+	if err := opSessionRequest2Master(&opSessionRequestData{Op: OP_SESSION_REQUEST_GET, Key: key}, res); err != nil {
+		return nil, err
+	}
+	return res.userSession(), nil
+}
+
+func saveOpSession(session *models.UserSession) error {
+	if !conf.IsMasterNode() {
+		var res interface{}
+		return opSessionRequest2Master(&opSessionRequestData{Op: OP_SESSION_REQUEST_SAVE, Session: newNodeOpSession(session)}, &res)
+	}
+
+	oldSession, err := models.GetUserSessionByKey(nil, session.Key)
+	if err != nil {
+		return err
+	}
+	if oldSession != nil {
+		return models.UpdateDBModel(nil, session)
+	}
+
+	if err := models.InsertRow(nil, session); err != nil {
+		return err
+	}
+	if err := models.DeleteExpiredUserSessions(nil, utils.GetNowSecond()); err != nil {
+		logger.Error(map[string]interface{}{
+			"type":  "session_clean",
+			"error": err.Error(),
+		})
+	}
+	return nil
+}
+
+// sessions of user not revoked, expired or invalid by passcode change
+func getActiveOpSessions(userKey string) ([]*models.UserSession, error) {
+	res := make([]*models.UserSession, 0)
+	if !conf.IsMasterNode() {
+		err := opSessionRequest2Master(&opSessionRequestData{Op: OP_SESSION_REQUEST_LIST, UserKey: userKey}, &res)
+		return res, err
+	}
+
+	sessions, err := models.GetActiveUserSessions(nil, userKey, utils.GetNowSecond())
+	if err != nil {
+		return nil, err
+	}
+
+	memConfMux.RLock()
+	user := memConfUsers[userKey]
+	memConfMux.RUnlock()
+	if user == nil {
+		return res, nil
+	}
+	for _, session := range sessions {
+		if session.PassCodeVersion == user.PassCodeVersion {
+			res = append(res, session)
+		}
+	}
+
+	return res, nil
+}
+
+// revokes all sessions of user except the one of exceptKey
+func revokeUserOpSessions(userKey, exceptKey string) error {
+	if !conf.IsMasterNode() {
+		var res interface{}
+		return opSessionRequest2Master(&opSessionRequestData{Op: OP_SESSION_REQUEST_REVOKE_USER, UserKey: userKey, ExceptKey: exceptKey}, &res)
+	}
+
+	return models.RevokeUserSessions(nil, userKey, exceptKey)
+}
+
+// creates a session for user and sets the login cookie
+func startOpSession(c *gin.Context, user *models.User) error {
+	now := utils.GetNowSecond()
+	session := &models.UserSession{
+		Key:             utils.GenerateKey(),
+		UserKey:         user.Key,
+		PassCodeVersion: user.PassCodeVersion,
+		IP:              c.ClientIP(),
+		UserAgent:       c.Request.UserAgent(),
+		Status:          models.USER_SESSION_STATUS_ACTIVE,
+		CreatedUTC:      now,
+		ExpiresUTC:      now + conf.SessionExpires,
+	}
+	if err := saveOpSession(session); err != nil {
+		return err
+	}
+
+	jwtIns := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sid": session.Key,
+		"uky": user.Key,
+		"iat": session.CreatedUTC,
+		"exp": session.ExpiresUTC,
+	})
+	encStr, err := jwtIns.SignedString([]byte(conf.SessionSignKey))
+	if err != nil {
+		return err
+	}
+
+	cookie := new(http.Cookie)
+	cookie.Name = OP_SESSION_COOKIE
+	cookie.Expires = time.Unix(int64(session.ExpiresUTC), 0)
+	cookie.Value = encStr
+	cookie.Path = "/op"
+	cookie.HttpOnly = true
+	http.SetCookie(c.Writer, cookie)
+
+	setOpUserKey(c, user.Key)
+	setOpSessionKey(c, session.Key)
+	return nil
+}
+
+func deleteOpSessionCookie(c *gin.Context) {
+	cookie := new(http.Cookie)
+	cookie.Name = OP_SESSION_COOKIE
+	cookie.Value = ""
+	cookie.Path = "/op"
+	cookie.MaxAge = -1
+	http.SetCookie(c.Writer, cookie)
+}
+
+// authenticates op api requests with login cookie, called by OpAuth
+func opSessionAuth(c *gin.Context, cookieValue string) {
+	session, errCode, err := verifyOpSession(cookieValue)
+	if err != nil {
+		if errCode == USER_INACTIVE {
+			deleteOpSessionCookie(c)
+		}
+		Error(c, errCode, err.Error())
+		c.Abort()
+		return
+	}
+
+	setOpUserKey(c, session.UserKey)
+	setOpSessionKey(c, session.Key)
+}
+
+// error code is only meaningful when err is not nil
+func verifyOpSession(cookieValue string) (*models.UserSession, int, error) {
+	token, err := jwt.Parse(cookieValue, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(conf.SessionSignKey), nil
+	})
+	if err != nil {
+		return nil, NOT_LOGIN, err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, NOT_LOGIN, fmt.Errorf("cookie token invalid")
+	}
+	// cookies issued before sessions have no exp claim, which jwt does not require
+	sessionKey, _ := claims["sid"].(string)
+	if sessionKey == "" || claims["exp"] == nil {
+		return nil, NOT_LOGIN, fmt.Errorf("cookie token invalid")
+	}
+
+	session, err := getOpSession(sessionKey)
+	if err != nil {
+		return nil, SERVER_ERROR, err
+	}
+	if session == nil || session.Status != models.USER_SESSION_STATUS_ACTIVE {
+		return nil, NOT_LOGIN, fmt.Errorf("session invalid")
+	}
+	if session.ExpiresUTC <= utils.GetNowSecond() {
+		return nil, NOT_LOGIN, fmt.Errorf("session expired")
+	}
+
+	memConfMux.RLock()
+	defer memConfMux.RUnlock()
+
+	user := memConfUsers[session.UserKey]
+	if user == nil {
+		return nil, NOT_LOGIN, fmt.Errorf("user not exist")
+	}
+	if session.PassCodeVersion != user.PassCodeVersion {
+		return nil, NOT_LOGIN, fmt.Errorf("pass_code changed, need login again")
+	}
+	if user.Status == models.USER_STATUS_INACTIVE {
+		return nil, USER_INACTIVE, fmt.Errorf(errorStr[USER_INACTIVE][1])
+	}
+
+	return session, 0, nil
+}
+
+// active sessions of login user
+func GetOpSessions(c *gin.Context) {
+	sessions, err := getActiveOpSessions(getOpUserKey(c))
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	for _, session := range sessions {
+		session.Current = session.Key == getOpSessionKey(c)
+	}
+
+	Success(c, sessions)
+}
+
+// active sessions of any user, only for admin or the user
+func GetUserOpSessions(c *gin.Context) {
+	userKey := c.Param("user_key")
+	memConfMux.RLock()
+	user := memConfUsers[userKey]
+	opUser := memConfUsers[getOpUserKey(c)]
+	memConfMux.RUnlock()
+
+	if user == nil {
+		Error(c, BAD_REQUEST, "user not exists: "+userKey)
+		return
+	}
+	if user.Key != opUser.Key && !isAdminUser(opUser) {
+		Error(c, NOT_PERMITTED, "can not get sessions of other users as current user is not admin")
+		return
+	}
+
+	sessions, err := getActiveOpSessions(userKey)
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	for _, session := range sessions {
+		session.Current = session.Key == getOpSessionKey(c)
+	}
+
+	Success(c, sessions)
+}
+
+// sessions of other users can only be revoked by admin
+func RevokeOpSession(c *gin.Context) {
+	session, err := getOpSession(c.Param("key"))
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	memConfMux.RLock()
+	opUser := memConfUsers[getOpUserKey(c)]
+	memConfMux.RUnlock()
+	if session == nil || (session.UserKey != opUser.Key && !isAdminUser(opUser)) {
+		Error(c, BAD_REQUEST, "session not exists: "+c.Param("key"))
+		return
+	}
+
+	if err := revokeOpSession(session); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	if session.Key == getOpSessionKey(c) {
+		deleteOpSessionCookie(c)
+	}
+
+	Success(c, nil)
+}
+
+// revokes all sessions of user, only for admin
+func RevokeUserOpSessions(c *gin.Context) {
+	userKey := c.Param("user_key")
+	memConfMux.RLock()
+	user := memConfUsers[userKey]
+	opUser := memConfUsers[getOpUserKey(c)]
+	memConfMux.RUnlock()
+
+	if user == nil {
+		Error(c, BAD_REQUEST, "user not exists: "+userKey)
+		return
+	}
+	if !isAdminUser(opUser) {
+		Error(c, NOT_PERMITTED, "can not revoke sessions of user as current user is not admin")
+		return
+	}
+
+	// current session is kept when admin revokes own sessions
+	if err := revokeUserOpSessions(user.Key, getOpSessionKey(c)); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	Success(c, nil)
+}
+
+func revokeOpSession(session *models.UserSession) error {
+	if session.Status == models.USER_SESSION_STATUS_REVOKED {
+		return nil
+	}
+
+	session.Status = models.USER_SESSION_STATUS_REVOKED
+	return saveOpSession(session)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func _signOpSession(claims jwt.MapClaims, key string) string {
+	str, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(key))
+	return str
+}
+
+func TestOpSession(t *testing.T) {
+	err := _clearModelData()
+	assert.True(t, err == nil, "must correctly clear data")
+	loadAllData()
+	initNodeData()
+
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	user, err := newUserWithNewUserData(&newUserData{Name: "rahuahua", PassCode: "huahua"}, "1234567", "1234567")
+	assert.True(t, err == nil, "must correctly add new user")
+
+	now := utils.GetNowSecond()
+	session := &models.UserSession{
+		Key:             utils.GenerateKey(),
+		UserKey:         user.Key,
+		PassCodeVersion: user.PassCodeVersion,
+		CreatedUTC:      now,
+		ExpiresUTC:      now + conf.SessionExpires,
+	}
+	assert.True(t, models.InsertRow(nil, session) == nil)
+
+	claims := jwt.MapClaims{"sid": session.Key, "uky": user.Key, "iat": now, "exp": session.ExpiresUTC}
+	verified, _, err := verifyOpSession(_signOpSession(claims, conf.SessionSignKey))
+	assert.True(t, err == nil && verified.UserKey == user.Key)
+
+	_, _, err = verifyOpSession(_signOpSession(claims, conf.NodeAuth))
+	assert.True(t, err != nil, "cookie signed by node auth must not be accepted")
+	_, _, err = verifyOpSession(_signOpSession(jwt.MapClaims{"sid": session.Key, "uky": user.Key}, conf.SessionSignKey))
+	assert.True(t, err != nil, "cookie without exp must not be accepted")
+	_, _, err = verifyOpSession(_signOpSession(jwt.MapClaims{"sid": session.Key, "exp": now - 1}, conf.SessionSignKey))
+	assert.True(t, err != nil, "expired cookie must not be accepted")
+
+	newUser := *user
+	newUser.PassCode = encryptUserPassCode("huahua")
+	_, err = updateUser(&newUser, nil)
+	assert.True(t, err == nil)
+	_, _, err = verifyOpSession(_signOpSession(claims, conf.SessionSignKey))
+	assert.True(t, err == nil, "session must be valid after passcode rehashed")
+
+	newUser.PassCode = encryptUserPassCode("xixi")
+	newUser.PassCodeVersion++
+	_, err = updateUser(&newUser, nil)
+	assert.True(t, err == nil)
+	_, errCode, err := verifyOpSession(_signOpSession(claims, conf.SessionSignKey))
+	assert.True(t, err != nil && errCode == NOT_LOGIN, "session must be invalid after passcode changed")
+
+	session.PassCodeVersion = newUser.PassCodeVersion
+	assert.True(t, models.UpdateDBModel(nil, session) == nil)
+	_, _, err = verifyOpSession(_signOpSession(claims, conf.SessionSignKey))
+	assert.True(t, err == nil)
+
+	other := *session
+	other.Key = utils.GenerateKey()
+	assert.True(t, saveOpSession(&other) == nil)
+	sessions, err := getActiveOpSessions(user.Key)
+	assert.True(t, err == nil && len(sessions) == 2)
+	assert.True(t, revokeUserOpSessions(user.Key, session.Key) == nil)
+	sessions, err = getActiveOpSessions(user.Key)
+	assert.True(t, err == nil && len(sessions) == 1 && sessions[0].Key == session.Key, "sessions except the kept one must be revoked")
+
+	assert.True(t, revokeOpSession(session) == nil)
+	_, errCode, err = verifyOpSession(_signOpSession(claims, conf.SessionSignKey))
+	assert.True(t, err != nil && errCode == NOT_LOGIN, "revoked session must not be accepted")
+
+	sessions, err = models.GetActiveUserSessions(nil, user.Key, now)
+	assert.True(t, err == nil && len(sessions) == 0)
+
+	_clearModelData()
+}
+
+func TestNodeOpSession(t *testing.T) {
+	session := &models.UserSession{Key: utils.GenerateKey(), UserKey: utils.GenerateKey(), PassCodeVersion: 3}
+	bs, err := json.Marshal(newNodeOpSession(session))
+	assert.True(t, err == nil)
+
+	res := &nodeOpSession{}
+	assert.True(t, json.Unmarshal(bs, res) == nil)
+	received := res.userSession()
+	assert.True(t, received.Key == session.Key && received.PassCodeVersion == 3, "pass_code_version must be sent between nodes")
+
+	bs, _ = json.Marshal(newNodeOpSession(nil))
+	res = &nodeOpSession{}
+	assert.True(t, json.Unmarshal(bs, res) == nil && res.userSession() == nil)
+}
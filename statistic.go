@@ -1,50 +1,128 @@
 package main
 
 import (
-	"log"
-	"os"
-	"time"
-
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
-
+	"hash/fnv"
+	"net/http"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
 	"github.com/gin-gonic/gin"
-	influx "github.com/influxdata/influxdb/client/v2"
+)
+
+const (
+	STATISTIC_BACKEND_INFLUX = "influx"
+	STATISTIC_BACKEND_LOCAL  = "local"
+
+	STATISTIC_TAG_APP  = "app"
+	STATISTIC_TAG_NODE = "node"
+
+	STATISTIC_DIMENSION_OS_TYPE     = "os_type"
+	STATISTIC_DIMENSION_APP_VERSION = "app_version"
+	STATISTIC_DIMENSION_LANG        = "lang"
+	STATISTIC_DIMENSION_NETWORK     = "network"
+
+	STATISTIC_CODE_VALUE_MAX_LEN = 256
 )
 
 var (
-	statisticCh       = make(chan *influx.Point, 100000)
-	influxClient      influx.Client
-	influxBatchPoints influx.BatchPoints
-	microSecondUnit   = time.Microsecond / time.Nanosecond
-	secondUnit        = time.Second / time.Nanosecond
+	microSecondUnit = time.Microsecond / time.Nanosecond
+
+	statBackend statisticBackend
+
+	statisticDimensions = []string{
+		STATISTIC_DIMENSION_OS_TYPE,
+		STATISTIC_DIMENSION_APP_VERSION,
+		STATISTIC_DIMENSION_LANG,
+		STATISTIC_DIMENSION_NETWORK,
+	}
 )
 
-func init() {
-	if conf.StatisticEnable {
-		var err error
-		influxClient, err = influx.NewHTTPClient(
-			influx.HTTPConfig{
-				Addr:     conf.InfluxURL,
-				Username: conf.InfluxUser,
-				Password: conf.InfluxPassword,
-			})
-		if err != nil {
-			log.Println("Failed to init influx client: ", err.Error())
-			os.Exit(1)
+type statisticPoint struct {
+	Time       time.Time
+	Node       string
+	App        string
+	Status     bool
+	ErrorCode  string
+	Ip         string
+	Lang       string
+	OSType     string
+	OSVersion  string
+	AppVersion string
+	NetWork    string
+	DeviceId   string
+	RespTime   int // micro second
+	CodeValues []*statisticCodeValue
+}
+
+// values of the point for breakdowns
+func (p *statisticPoint) dimensions() map[string]string {
+	return map[string]string{
+		STATISTIC_DIMENSION_OS_TYPE:     p.OSType,
+		STATISTIC_DIMENSION_APP_VERSION: p.AppVersion,
+		STATISTIC_DIMENSION_LANG:        p.Lang,
+		STATISTIC_DIMENSION_NETWORK:     p.NetWork,
+	}
+}
+
+// evaluated value of one code config
+type statisticCodeValue struct {
+	K     string
+	Hash  string
+	Value string // json text, truncated for long values
+}
+
+func newStatisticCodeValues(values map[string]interface{}) []*statisticCodeValue {
+	res := make([]*statisticCodeValue, 0, len(values))
+	for k, v := range values {
+		bs, _ := json.Marshal(v)
+		h := fnv.New64a()
+		h.Write(bs)
+
+		value := string(bs)
+		if len(value) > STATISTIC_CODE_VALUE_MAX_LEN {
+			value = value[:STATISTIC_CODE_VALUE_MAX_LEN]
 		}
+		res = append(res, &statisticCodeValue{K: k, Hash: fmt.Sprintf("%016x", h.Sum64()), Value: value})
+	}
+	sort.Sort(statisticCodeValuesByK(res))
+
+	return res
+}
 
-		influxBatchPoints, err = influx.NewBatchPoints(influx.BatchPointsConfig{
-			Database:  conf.InfluxDB,
-			Precision: "s",
-		})
-		if err != nil {
-			log.Println("Failed to init influx batch points: ", err.Error())
-			os.Exit(1)
+type statisticCodeValuesByK []*statisticCodeValue
+
+func (values statisticCodeValuesByK) Len() int           { return len(values) }
+func (values statisticCodeValuesByK) Less(i, j int) bool { return values[i].K < values[j].K }
+func (values statisticCodeValuesByK) Swap(i, j int)      { values[i], values[j] = values[j], values[i] }
+
+type statisticBackend interface {
+	logPoint(p *statisticPoint)
+	// count of devices of the app which request configs since utc
+	getDeviceCount(appKey string, since int) (int, error)
+	// [time, request count, mean response time] of each unit seconds between start and end, tag is app or node
+	getResponseData(tag, value string, start, end, unit int) ([][]interface{}, error)
+	// [dimension value, request count, mean response time] of the app between start and end
+	getBreakdownData(appKey, dimension string, start, end int) ([][]interface{}, error)
+	// devices grouped by the value of the code config key they got between start and end
+	getValueDistribution(appKey, k string, start, end int) ([]*models.StatValueCount, error)
+}
+
+func init() {
+	if conf.StatisticEnable {
+		switch conf.StatisticBackend {
+		case STATISTIC_BACKEND_INFLUX:
+			statBackend = newInfluxStatisticBackend()
+		case STATISTIC_BACKEND_LOCAL:
+			statBackend = newLocalStatisticBackend()
 		}
-		go logStatisticTask()
 	}
 }
 
@@ -67,82 +145,107 @@ func StatisticHandler(c *gin.Context) {
 		return
 	}
 
-	respTime := time.Now().Sub(now)
-	tags := map[string]string{
-		"node": conf.ClientAddr,
-		"app":  clientData.AppKey,
-	}
+	statBackend.logPoint(&statisticPoint{
+		Time:       now,
+		Node:       conf.ClientAddr,
+		App:        clientData.AppKey,
+		Status:     getServiceStatus(c),
+		ErrorCode:  getServiceErrorCode(c),
+		Ip:         clientData.Ip,
+		Lang:       clientData.Lang,
+		OSType:     clientData.OSType,
+		OSVersion:  clientData.OSVersion,
+		AppVersion: clientData.AppVersion,
+		NetWork:    clientData.NetWork,
+		DeviceId:   clientData.DeviceId,
+		RespTime:   int(time.Now().Sub(now) / microSecondUnit),
+		CodeValues: newStatisticCodeValues(clientData.CodeValues),
+	})
+}
+
+func GetDeviceCountOfAppLatestConfig(c *gin.Context) {
+	memConfMux.RLock()
+	app := memConfApps[c.Param("app_key")]
+	memConfMux.RUnlock()
 
-	fields := map[string]interface{}{
-		"status":     getServiceStatus(c),
-		"error_code": getServiceErrorCode(c),
-		"ip":         clientData.Ip,
-		"lang":       clientData.Lang,
-		"os":         clientData.OSType,
-		"osv":        clientData.OSVersion,
-		"appv":       clientData.AppVersion,
-		"deviceid":   clientData.DeviceId,
-		"resp_time":  int(respTime / microSecondUnit),
+	if app == nil {
+		Error(c, BAD_REQUEST, "app not found for app key: "+c.Param("app_key"))
+		return
 	}
 
-	p, _ := influx.NewPoint("client_request", tags, fields, now)
-	select {
-	case statisticCh <- p:
-	default:
-		log.Println("failed to send influx point to channel")
+	count, err := statBackend.getDeviceCount(app.Key, app.LastUpdateUTC)
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
 	}
+
+	Success(c, count)
 }
 
-func logStatisticTask() {
-	exitChan := make(chan struct{})
-	for {
-		go func() {
-			defer func() { exitChan <- struct{}{} }()
-			for {
-				logStatistic(<-statisticCh)
-			}
-		}()
+// unit is like 1h, 2d or 1w, returns seconds of it
+func parseStatisticUnit(unit string) (int, error) {
+	if len(unit) < 2 {
+		return 0, fmt.Errorf("bad unit: %s", unit)
+	}
 
-		<-exitChan
+	var unitSeconds int
+	switch unit[len(unit)-1] {
+	case 'h':
+		unitSeconds = 3600
+	case 'd':
+		unitSeconds = 24 * 3600
+	case 'w':
+		unitSeconds = 7 * 24 * 3600
+	default:
+		return 0, fmt.Errorf("only 'd' or 'h' or 'w' unit support")
 	}
+
+	n, err := strconv.Atoi(unit[:len(unit)-1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("bad unit: %s", unit)
+	}
+
+	return n * unitSeconds, nil
 }
 
-func logStatistic(p *influx.Point) {
-	influxBatchPoints.AddPoint(p)
-	if len(influxBatchPoints.Points()) < conf.InfluxBatchPointsCount {
+func getStatisticTimeRange(c *gin.Context) (start, end int, ok bool) {
+	var err error
+	if start, err = strconv.Atoi(c.Query("start_time")); err != nil {
+		Error(c, BAD_REQUEST, "start_time not number")
+		return
+	}
+	if end, err = strconv.Atoi(c.Query("end_time")); err != nil {
+		Error(c, BAD_REQUEST, "end_time not number")
+		return
+	}
+	if end < start {
+		Error(c, BAD_REQUEST, "end_time must not be before start_time")
 		return
 	}
 
-	if err := influxClient.Write(influxBatchPoints); err != nil {
-		log.Println("Failed to dump point to influxdb: ", err.Error())
+	if (end-start)/(24*3600) > 30 {
+		Error(c, BAD_REQUEST, "only max 30 days duration support")
+		return
 	}
-	influxBatchPoints, _ = influx.NewBatchPoints(
-		influx.BatchPointsConfig{
-			Database:  conf.InfluxDB,
-			Precision: "s",
-		})
+
+	ok = true
+	return
 }
 
-func queryInflux(q string) (*influx.Response, error) {
-	client, err := influx.NewHTTPClient(
-		influx.HTTPConfig{
-			Addr:     conf.InfluxURL,
-			Username: conf.InfluxUser,
-			Password: conf.InfluxPassword,
-		})
-	if err != nil {
-		return nil, err
+func getStatisticTimeRangeWithUnit(c *gin.Context) (start, end, unit int, ok bool) {
+	if start, end, ok = getStatisticTimeRange(c); !ok {
+		return
 	}
 
-	return client.Query(
-		influx.Query{
-			Command:   q,
-			Database:  conf.InfluxDB,
-			Precision: "s",
-		})
+	var err error
+	if unit, err = parseStatisticUnit(c.Query("unit")); err != nil {
+		Error(c, BAD_REQUEST, err.Error())
+		ok = false
+	}
+	return
 }
 
-func GetDeviceCountOfAppLatestConfig(c *gin.Context) {
+func GetAppConfigResponseData(c *gin.Context) {
 	memConfMux.RLock()
 	app := memConfApps[c.Param("app_key")]
 	memConfMux.RUnlock()
@@ -152,26 +255,46 @@ func GetDeviceCountOfAppLatestConfig(c *gin.Context) {
 		return
 	}
 
-	q := fmt.Sprintf("SELECT COUNT(DISTINCT(deviceid)) FROM client_request where app = '%s' AND time >= %d", app.Key, app.LastUpdateUTC*int(secondUnit))
-	resp, err := queryInflux(q)
+	startTime, endTime, unit, ok := getStatisticTimeRangeWithUnit(c)
+	if !ok {
+		return
+	}
+
+	res, err := statBackend.getResponseData(STATISTIC_TAG_APP, app.Key, startTime, endTime, unit)
 	if err != nil {
 		Error(c, SERVER_ERROR, err.Error())
 		return
 	}
-	if resp.Error() != nil {
-		Error(c, SERVER_ERROR, resp.Error().Error())
+
+	successStatisticSeries(c, "app-config-response-"+app.Name, []string{"time", "count", "mean_resp_time"}, res)
+}
+
+func GetNodeConfigResponseData(c *gin.Context) {
+	memConfMux.RLock()
+	node := memConfNodes[c.Param("node_url")]
+	memConfMux.RUnlock()
+
+	if node == nil {
+		Error(c, BAD_REQUEST, "node not found for node url "+c.Param("node_url"))
+		return
+	}
+
+	startTime, endTime, unit, ok := getStatisticTimeRangeWithUnit(c)
+	if !ok {
 		return
 	}
 
-	if len(resp.Results[0].Series) == 0 {
-		Success(c, 0)
+	res, err := statBackend.getResponseData(STATISTIC_TAG_NODE, node.URL, startTime, endTime, unit)
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
 		return
 	}
 
-	Success(c, resp.Results[0].Series[0].Values[0][1])
+	successStatisticSeries(c, "node-config-response-"+node.URL, []string{"time", "count", "mean_resp_time"}, res)
 }
 
-func GetAppConfigResponseData(c *gin.Context) {
+// request count and mean response time of the app by os_type, app_version, lang or network
+func GetAppConfigBreakdownData(c *gin.Context) {
 	memConfMux.RLock()
 	app := memConfApps[c.Param("app_key")]
 	memConfMux.RUnlock()
@@ -181,100 +304,88 @@ func GetAppConfigResponseData(c *gin.Context) {
 		return
 	}
 
-	startTime, err := strconv.Atoi(c.Query("start_time"))
-	if err != nil {
-		Error(c, BAD_REQUEST, "start_time not number")
-		return
-	}
-	endTime, err := strconv.Atoi(c.Query("end_time"))
-	if err != nil {
-		Error(c, BAD_REQUEST, "end_time not number")
+	dimension := c.Param("dimension")
+	if !inStringSlice(dimension, statisticDimensions) {
+		Error(c, BAD_REQUEST, "unknown dimension: "+dimension)
 		return
 	}
-	unit := c.Query("unit")
 
-	if (endTime-startTime)/(24*3600) > 30 {
-		Error(c, BAD_REQUEST, "only max 30 days duration support")
-		return
-	}
-	unitKind := unit[len(unit)-1]
-	if unitKind != 'h' && unitKind != 'd' && unitKind != 'w' {
-		Error(c, BAD_REQUEST, "only 'd' or 'h' or 'w' unit support")
+	startTime, endTime, ok := getStatisticTimeRange(c)
+	if !ok {
 		return
 	}
 
-	q := fmt.Sprintf(
-		"SELECT COUNT(resp_time), MEAN(resp_time) FROM client_request where app = '%s' AND time >= %d AND time <= %d GROUP BY time(%s) fill(0)",
-		app.Key, startTime*int(secondUnit), endTime*int(secondUnit), unit)
-	resp, err := queryInflux(q)
+	res, err := statBackend.getBreakdownData(app.Key, dimension, startTime, endTime)
 	if err != nil {
 		Error(c, SERVER_ERROR, err.Error())
 		return
 	}
-	if resp.Error() != nil {
-		Error(c, SERVER_ERROR, resp.Error().Error())
+
+	successStatisticSeries(c, "app-config-"+dimension+"-"+app.Name, []string{dimension, "count", "mean_resp_time"}, res)
+}
+
+// responds rows as csv file when format=csv is given
+func successStatisticSeries(c *gin.Context, name string, header []string, rows [][]interface{}) {
+	if c.Query("format") != "csv" {
+		Success(c, rows)
 		return
 	}
 
-	res := make([][]interface{}, 0)
-	if len(resp.Results[0].Series) > 0 {
-		for _, val := range resp.Results[0].Series[0].Values {
-			res = append(res, []interface{}{val[0], val[1], val[2]})
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write(header)
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for ix, v := range row {
+			if v != nil {
+				record[ix] = fmt.Sprint(v)
+			}
 		}
+		w.Write(record)
 	}
+	w.Flush()
 
-	Success(c, res)
+	setServiceStatus(c, true)
+	setRequestLogData(c, &RequestLogData{Status: true})
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, strings.NewReplacer(`"`, "", "/", "-", ":", "-").Replace(name)))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
 }
 
-func GetNodeConfigResponseData(c *gin.Context) {
+// distribution of values devices got for one code config key
+func GetConfigValueDistribution(c *gin.Context) {
 	memConfMux.RLock()
-	node := memConfNodes[c.Param("node_url")]
+	app := memConfApps[c.Param("app_key")]
 	memConfMux.RUnlock()
 
-	if node == nil {
-		Error(c, BAD_REQUEST, "node not found for node url "+c.Param("node_url"))
+	if app == nil {
+		Error(c, BAD_REQUEST, "app not found for app key: "+c.Param("app_key"))
 		return
 	}
 
-	startTime, err := strconv.Atoi(c.Query("start_time"))
-	if err != nil {
-		Error(c, BAD_REQUEST, "start_time not number")
-		return
-	}
-	endTime, err := strconv.Atoi(c.Query("end_time"))
-	if err != nil {
-		Error(c, BAD_REQUEST, "end_time not number")
+	k := c.Query("key")
+	if k == "" {
+		Error(c, BAD_REQUEST, "key required")
 		return
 	}
-	unit := c.Query("unit")
 
-	if (endTime-startTime)/(24*3600) > 30 {
-		Error(c, BAD_REQUEST, "only max 30 days duration support")
-		return
-	}
-	unitKind := unit[len(unit)-1]
-	if unitKind != 'h' && unitKind != 'd' && unitKind != 'w' {
-		Error(c, BAD_REQUEST, "only 'd' or 'h' or 'w' unit support")
+	startTime, endTime, ok := getStatisticTimeRange(c)
+	if !ok {
 		return
 	}
 
-	q := fmt.Sprintf(
-		"SELECT COUNT(resp_time), MEAN(resp_time) FROM client_request where node = '%s' AND time >= %d AND time <= %d GROUP BY time(%s) fill(0)",
-		node.URL, startTime*int(secondUnit), endTime*int(secondUnit), unit)
-	resp, err := queryInflux(q)
+	res, err := statBackend.getValueDistribution(app.Key, k, startTime, endTime)
 	if err != nil {
 		Error(c, SERVER_ERROR, err.Error())
 		return
 	}
-	if resp.Error() != nil {
-		Error(c, SERVER_ERROR, resp.Error().Error())
-		return
-	}
 
-	res := make([][]interface{}, 0)
-	if len(resp.Results[0].Series) > 0 {
-		for _, val := range resp.Results[0].Series[0].Values {
-			res = append(res, []interface{}{val[0], val[1], val[2]})
+	total := 0
+	for _, count := range res {
+		total += count.DeviceCount
+	}
+	if total > 0 {
+		for _, count := range res {
+			count.Ratio = float64(count.DeviceCount) / float64(total)
 		}
 	}
 
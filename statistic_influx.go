@@ -0,0 +1,330 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
+	influx "github.com/influxdata/influxdb/client/v2"
+	influxmodels "github.com/influxdata/influxdb/models"
+)
+
+var (
+	statisticCh       = make(chan *influx.Point, 100000)
+	influxClient      influx.Client
+	influxBatchPoints influx.BatchPoints
+	secondUnit        = time.Second / time.Nanosecond
+)
+
+// builder of InfluxQL select statements, tags and values are always quoted
+// so that params of requests can not change the statement
+type influxQuery struct {
+	fields      []string
+	measurement string
+	conds       []string
+	groupBys    []string
+	fill        string
+}
+
+// fields are select expressions given by code, never by requests
+func newInfluxQuery(measurement string, fields ...string) *influxQuery {
+	return &influxQuery{fields: fields, measurement: measurement}
+}
+
+func (q *influxQuery) whereTag(tag, value string) *influxQuery {
+	q.conds = append(q.conds, fmt.Sprintf("%s = %s", quoteInfluxIdent(tag), quoteInfluxString(value)))
+	return q
+}
+
+// start and end are utc seconds, end is ignored when it is 0
+func (q *influxQuery) whereTime(start, end int) *influxQuery {
+	q.conds = append(q.conds, fmt.Sprintf("time >= %d", start*int(secondUnit)))
+	if end > 0 {
+		q.conds = append(q.conds, fmt.Sprintf("time <= %d", end*int(secondUnit)))
+	}
+	return q
+}
+
+// unit is by second
+func (q *influxQuery) groupByTime(unit int) *influxQuery {
+	q.groupBys = append(q.groupBys, fmt.Sprintf("time(%ds)", unit))
+	return q
+}
+
+func (q *influxQuery) groupByTag(tag string) *influxQuery {
+	q.groupBys = append(q.groupBys, quoteInfluxIdent(tag))
+	return q
+}
+
+func (q *influxQuery) fillWith(v int) *influxQuery {
+	q.fill = fmt.Sprintf("fill(%d)", v)
+	return q
+}
+
+func (q *influxQuery) String() string {
+	s := fmt.Sprintf("SELECT %s FROM %s", strings.Join(q.fields, ", "), quoteInfluxIdent(q.measurement))
+	if len(q.conds) > 0 {
+		s += " WHERE " + strings.Join(q.conds, " AND ")
+	}
+	if len(q.groupBys) > 0 {
+		s += " GROUP BY " + strings.Join(q.groupBys, ", ")
+	}
+	if q.fill != "" {
+		s += " " + q.fill
+	}
+
+	return s
+}
+
+func quoteInfluxString(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	return "'" + strings.Replace(s, `'`, `\'`, -1) + "'"
+}
+
+func quoteInfluxIdent(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	return `"` + strings.Replace(s, `"`, `\"`, -1) + `"`
+}
+
+type influxStatisticBackend struct{}
+
+func newInfluxStatisticBackend() *influxStatisticBackend {
+	var err error
+	influxClient, err = influx.NewHTTPClient(
+		influx.HTTPConfig{
+			Addr:     conf.InfluxURL,
+			Username: conf.InfluxUser,
+			Password: conf.InfluxPassword,
+		})
+	if err != nil {
+		log.Println("Failed to init influx client: ", err.Error())
+		os.Exit(1)
+	}
+
+	influxBatchPoints, err = influx.NewBatchPoints(influx.BatchPointsConfig{
+		Database:  conf.InfluxDB,
+		Precision: "s",
+	})
+	if err != nil {
+		log.Println("Failed to init influx batch points: ", err.Error())
+		os.Exit(1)
+	}
+	go logStatisticTask()
+
+	return &influxStatisticBackend{}
+}
+
+func (*influxStatisticBackend) logPoint(point *statisticPoint) {
+	tags := map[string]string{
+		"node": point.Node,
+		"app":  point.App,
+	}
+	// tags for breakdowns, influxdb does not accept empty tag values
+	for dimension, value := range point.dimensions() {
+		if value != "" {
+			tags[influxDimensionTag(dimension)] = value
+		}
+	}
+
+	fields := map[string]interface{}{
+		"status":     point.Status,
+		"error_code": point.ErrorCode,
+		"ip":         point.Ip,
+		"lang":       point.Lang,
+		"os":         point.OSType,
+		"osv":        point.OSVersion,
+		"appv":       point.AppVersion,
+		"deviceid":   point.DeviceId,
+		"resp_time":  point.RespTime,
+	}
+
+	p, _ := influx.NewPoint("client_request", tags, fields, point.Time)
+	sendInfluxPoint(p)
+
+	if point.DeviceId == "" {
+		return
+	}
+	for _, v := range point.CodeValues {
+		p, _ := influx.NewPoint(
+			"config_value",
+			map[string]string{"app": point.App, "k": v.K, "hash": v.Hash},
+			map[string]interface{}{"deviceid": point.DeviceId, "value": v.Value},
+			point.Time)
+		sendInfluxPoint(p)
+	}
+}
+
+// tag of breakdown dimension, suffixed as names like "lang" are also fields of client_request
+func influxDimensionTag(dimension string) string {
+	return dimension + "_tag"
+}
+
+func sendInfluxPoint(p *influx.Point) {
+	select {
+	case statisticCh <- p:
+	default:
+		log.Println("failed to send influx point to channel")
+	}
+}
+
+func (*influxStatisticBackend) getDeviceCount(appKey string, since int) (int, error) {
+	q := newInfluxQuery("client_request", "COUNT(DISTINCT(deviceid))").
+		whereTag(STATISTIC_TAG_APP, appKey).
+		whereTime(since, 0)
+	series, err := queryInfluxSeries(q)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(series) == 0 {
+		return 0, nil
+	}
+
+	return getInfluxInt(series[0].Values[0][1])
+}
+
+func (*influxStatisticBackend) getResponseData(tag, value string, start, end, unit int) ([][]interface{}, error) {
+	q := newInfluxQuery("client_request", "COUNT(resp_time)", "MEAN(resp_time)").
+		whereTag(tag, value).
+		whereTime(start, end).
+		groupByTime(unit).
+		fillWith(0)
+	series, err := queryInfluxSeries(q)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([][]interface{}, 0)
+	if len(series) > 0 {
+		for _, val := range series[0].Values {
+			res = append(res, []interface{}{val[0], val[1], val[2]})
+		}
+	}
+
+	return res, nil
+}
+
+func (*influxStatisticBackend) getBreakdownData(appKey, dimension string, start, end int) ([][]interface{}, error) {
+	q := newInfluxQuery("client_request", "COUNT(resp_time)", "MEAN(resp_time)").
+		whereTag(STATISTIC_TAG_APP, appKey).
+		whereTime(start, end).
+		groupByTag(influxDimensionTag(dimension))
+	series, err := queryInfluxSeries(q)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([][]interface{}, 0, len(series))
+	for _, s := range series {
+		if len(s.Values) == 0 {
+			continue
+		}
+		res = append(res, []interface{}{s.Tags[influxDimensionTag(dimension)], s.Values[0][1], s.Values[0][2]})
+	}
+
+	return res, nil
+}
+
+func (*influxStatisticBackend) getValueDistribution(appKey, k string, start, end int) ([]*models.StatValueCount, error) {
+	q := newInfluxQuery("config_value", "COUNT(DISTINCT(deviceid))", "LAST(value)").
+		whereTag(STATISTIC_TAG_APP, appKey).
+		whereTag("k", k).
+		whereTime(start, end).
+		groupByTag("hash")
+	series, err := queryInfluxSeries(q)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*models.StatValueCount, 0)
+	for _, s := range series {
+		if len(s.Values) == 0 {
+			continue
+		}
+		count := &models.StatValueCount{Hash: s.Tags["hash"]}
+		count.DeviceCount, _ = getInfluxInt(s.Values[0][1])
+		count.Value, _ = s.Values[0][2].(string)
+		res = append(res, count)
+	}
+
+	return res, nil
+}
+
+func getInfluxInt(v interface{}) (int, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("bad influx number format: %v", v)
+	}
+
+	i, err := n.Int64()
+	return int(i), err
+}
+
+func logStatisticTask() {
+	exitChan := make(chan struct{})
+	for {
+		go func() {
+			defer func() { exitChan <- struct{}{} }()
+			for {
+				logStatistic(<-statisticCh)
+			}
+		}()
+
+		<-exitChan
+	}
+}
+
+func logStatistic(p *influx.Point) {
+	influxBatchPoints.AddPoint(p)
+	if len(influxBatchPoints.Points()) < conf.InfluxBatchPointsCount {
+		return
+	}
+
+	if err := influxClient.Write(influxBatchPoints); err != nil {
+		log.Println("Failed to dump point to influxdb: ", err.Error())
+	}
+	influxBatchPoints, _ = influx.NewBatchPoints(
+		influx.BatchPointsConfig{
+			Database:  conf.InfluxDB,
+			Precision: "s",
+		})
+}
+
+func queryInflux(q *influxQuery) (*influx.Response, error) {
+	client, err := influx.NewHTTPClient(
+		influx.HTTPConfig{
+			Addr:     conf.InfluxURL,
+			Username: conf.InfluxUser,
+			Password: conf.InfluxPassword,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Query(
+		influx.Query{
+			Command:   q.String(),
+			Database:  conf.InfluxDB,
+			Precision: "s",
+		})
+}
+
+func queryInfluxSeries(q *influxQuery) ([]influxmodels.Row, error) {
+	resp, err := queryInflux(q)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error() != nil {
+		return nil, resp.Error()
+	}
+	if len(resp.Results) == 0 {
+		return nil, nil
+	}
+
+	return resp.Results[0].Series, nil
+}
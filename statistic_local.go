@@ -0,0 +1,329 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
+)
+
+const (
+	LOCAL_STATISTIC_FLUSH_INTERVAL = 60   // second
+	LOCAL_STATISTIC_CLEAN_INTERVAL = 3600 // second
+)
+
+type localStatisticMinuteKey struct {
+	Minute     int
+	AppKey     string
+	Node       string
+	OSType     string
+	AppVersion string
+	Lang       string
+	Network    string
+}
+
+type localStatisticDeviceKey struct {
+	AppKey   string
+	DeviceId string
+}
+
+type localStatisticDeviceValueKey struct {
+	AppKey   string
+	K        string
+	DeviceId string
+}
+
+// aggregations slave nodes report to master
+type localStatisticReportData struct {
+	Minutes []*models.StatMinute `json:"minutes"`
+	Devices []*models.StatDevice `json:"devices"`
+
+	DeviceValues []*models.StatDeviceValue `json:"device_values"`
+	CodeValues   []*models.StatCodeValue   `json:"code_values"`
+}
+
+// aggregates points per minute in memory and flushes them to sqlite of master node
+type localStatisticBackend struct {
+	ch           chan *statisticPoint
+	minutes      map[localStatisticMinuteKey]*models.StatMinute
+	devices      map[localStatisticDeviceKey]int
+	deviceValues map[localStatisticDeviceValueKey]*models.StatDeviceValue
+	codeValues   map[models.StatCodeValue]bool
+	lastCleanUTC int
+}
+
+func newLocalStatisticBackend() *localStatisticBackend {
+	b := &localStatisticBackend{
+		ch:      make(chan *statisticPoint, 100000),
+		minutes: make(map[localStatisticMinuteKey]*models.StatMinute),
+		devices: make(map[localStatisticDeviceKey]int),
+	}
+	b.resetCodeValues()
+	doEverTask(b.run)
+
+	return b
+}
+
+func (b *localStatisticBackend) logPoint(p *statisticPoint) {
+	select {
+	case b.ch <- p:
+	default:
+		log.Println("failed to send statistic point to channel")
+	}
+}
+
+func (b *localStatisticBackend) run() {
+	ticker := time.NewTicker(LOCAL_STATISTIC_FLUSH_INTERVAL * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case p := <-b.ch:
+			b.add(p)
+		case <-ticker.C:
+			b.flush()
+		}
+	}
+}
+
+func (b *localStatisticBackend) add(p *statisticPoint) {
+	utc := int(p.Time.Unix())
+	key := localStatisticMinuteKey{
+		Minute:     utc - utc%60,
+		AppKey:     p.App,
+		Node:       p.Node,
+		OSType:     p.OSType,
+		AppVersion: p.AppVersion,
+		Lang:       p.Lang,
+		Network:    p.NetWork,
+	}
+
+	minute := b.minutes[key]
+	if minute == nil {
+		minute = &models.StatMinute{
+			Minute:     key.Minute,
+			AppKey:     key.AppKey,
+			Node:       key.Node,
+			OSType:     key.OSType,
+			AppVersion: key.AppVersion,
+			Lang:       key.Lang,
+			Network:    key.Network,
+		}
+		b.minutes[key] = minute
+	}
+	minute.Count++
+	if !p.Status {
+		minute.ErrorCount++
+	}
+	minute.RespTimeSum += p.RespTime
+
+	if p.DeviceId == "" {
+		return
+	}
+	deviceKey := localStatisticDeviceKey{AppKey: p.App, DeviceId: p.DeviceId}
+	if b.devices[deviceKey] < utc {
+		b.devices[deviceKey] = utc
+	}
+	for _, v := range p.CodeValues {
+		b.addDeviceValue(&models.StatDeviceValue{AppKey: p.App, K: v.K, DeviceId: p.DeviceId, Hash: v.Hash, LastSeenUTC: utc})
+		b.codeValues[models.StatCodeValue{AppKey: p.App, K: v.K, Hash: v.Hash, Value: v.Value}] = true
+	}
+}
+
+func (b *localStatisticBackend) addDeviceValue(v *models.StatDeviceValue) {
+	key := localStatisticDeviceValueKey{AppKey: v.AppKey, K: v.K, DeviceId: v.DeviceId}
+	if old := b.deviceValues[key]; old == nil || old.LastSeenUTC <= v.LastSeenUTC {
+		b.deviceValues[key] = v
+	}
+}
+
+func (b *localStatisticBackend) resetCodeValues() {
+	b.deviceValues = make(map[localStatisticDeviceValueKey]*models.StatDeviceValue)
+	b.codeValues = make(map[models.StatCodeValue]bool)
+}
+
+func (b *localStatisticBackend) merge(data *localStatisticReportData) {
+	expiredUTC := utils.GetNowSecond() - conf.StatisticRetention*24*3600
+	for _, m := range data.Minutes {
+		if m.Minute < expiredUTC {
+			continue
+		}
+		key := localStatisticMinuteKey{m.Minute, m.AppKey, m.Node, m.OSType, m.AppVersion, m.Lang, m.Network}
+		if minute := b.minutes[key]; minute != nil {
+			minute.Count += m.Count
+			minute.ErrorCount += m.ErrorCount
+			minute.RespTimeSum += m.RespTimeSum
+		} else {
+			b.minutes[key] = m
+		}
+	}
+	for _, d := range data.Devices {
+		deviceKey := localStatisticDeviceKey{AppKey: d.AppKey, DeviceId: d.DeviceId}
+		if d.LastSeenUTC >= expiredUTC && b.devices[deviceKey] < d.LastSeenUTC {
+			b.devices[deviceKey] = d.LastSeenUTC
+		}
+	}
+	for _, v := range data.DeviceValues {
+		if v.LastSeenUTC >= expiredUTC {
+			b.addDeviceValue(v)
+		}
+	}
+	for _, v := range data.CodeValues {
+		b.codeValues[*v] = true
+	}
+}
+
+func (b *localStatisticBackend) flush() {
+	if len(b.minutes) == 0 && len(b.devices) == 0 && len(b.deviceValues) == 0 {
+		b.cleanIfNeed()
+		return
+	}
+
+	data := &localStatisticReportData{
+		Minutes: make([]*models.StatMinute, 0, len(b.minutes)),
+		Devices: make([]*models.StatDevice, 0, len(b.devices)),
+	}
+	for _, minute := range b.minutes {
+		data.Minutes = append(data.Minutes, minute)
+	}
+	for key, utc := range b.devices {
+		data.Devices = append(data.Devices, &models.StatDevice{AppKey: key.AppKey, DeviceId: key.DeviceId, LastSeenUTC: utc})
+	}
+	for _, v := range b.deviceValues {
+		data.DeviceValues = append(data.DeviceValues, v)
+	}
+	for v := range b.codeValues {
+		codeValue := v
+		data.CodeValues = append(data.CodeValues, &codeValue)
+	}
+	b.minutes = make(map[localStatisticMinuteKey]*models.StatMinute)
+	b.devices = make(map[localStatisticDeviceKey]int)
+	b.resetCodeValues()
+
+	var err error
+	if conf.IsMasterNode() {
+		err = storeLocalStatisticData(data)
+	} else {
+		err = reportLocalStatisticData(data)
+	}
+	if err != nil {
+		logger.Error(map[string]interface{}{
+			"type":  "statistic_flush",
+			"error": err.Error(),
+		})
+		// keep them for next flush
+		b.merge(data)
+	}
+
+	b.cleanIfNeed()
+}
+
+func (b *localStatisticBackend) cleanIfNeed() {
+	if !conf.IsMasterNode() {
+		return
+	}
+
+	now := utils.GetNowSecond()
+	if now-b.lastCleanUTC < LOCAL_STATISTIC_CLEAN_INTERVAL {
+		return
+	}
+	b.lastCleanUTC = now
+
+	if err := models.DeleteStatDataBefore(nil, now-conf.StatisticRetention*24*3600); err != nil {
+		logger.Error(map[string]interface{}{
+			"type":  "statistic_clean",
+			"error": err.Error(),
+		})
+	}
+}
+
+func storeLocalStatisticData(data *localStatisticReportData) error {
+	s := models.NewSession()
+	defer s.Close()
+	if err := s.Begin(); err != nil {
+		return err
+	}
+
+	if err := models.AddStatMinutes(s, data.Minutes); err != nil {
+		s.Rollback()
+		return err
+	}
+	if err := models.UpsertStatDevices(s, data.Devices); err != nil {
+		s.Rollback()
+		return err
+	}
+	if err := models.UpsertStatDeviceValues(s, data.DeviceValues, data.CodeValues); err != nil {
+		s.Rollback()
+		return err
+	}
+
+	return s.Commit()
+}
+
+func reportLocalStatisticData(data *localStatisticReportData) error {
+	bs, _ := json.Marshal(data)
+	reqData := nodeRequestDataT{
+		Auth: nodeAuthString,
+		Data: string(bs),
+	}
+
+	_, err := nodeRequest(conf.MasterAddr, NODE_REQUEST_TYPE_STATREPORT, reqData)
+	return err
+}
+
+func (*localStatisticBackend) getDeviceCount(appKey string, since int) (int, error) {
+	return models.GetStatDeviceCount(nil, appKey, since)
+}
+
+func (*localStatisticBackend) getResponseData(tag, value string, start, end, unit int) ([][]interface{}, error) {
+	col := "app_key"
+	if tag == STATISTIC_TAG_NODE {
+		col = "node"
+	}
+
+	// align buckets to unit as influxdb does
+	start -= start % unit
+	summaries, err := models.GetStatSummaries(nil, col, value, start, end, unit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([][]interface{}, 0, (end-start)/unit+1)
+	for t := start; t <= end; t += unit {
+		res = append(res, []interface{}{t, 0, 0})
+	}
+	for _, summary := range summaries {
+		if summary.Bucket < 0 || summary.Bucket >= len(res) || summary.Count == 0 {
+			continue
+		}
+		res[summary.Bucket][1] = summary.Count
+		res[summary.Bucket][2] = float64(summary.RespTimeSum) / float64(summary.Count)
+	}
+
+	return res, nil
+}
+
+func (*localStatisticBackend) getBreakdownData(appKey, dimension string, start, end int) ([][]interface{}, error) {
+	breakdowns, err := models.GetStatBreakdowns(nil, appKey, dimension, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([][]interface{}, 0, len(breakdowns))
+	for _, breakdown := range breakdowns {
+		var mean float64
+		if breakdown.Count > 0 {
+			mean = float64(breakdown.RespTimeSum) / float64(breakdown.Count)
+		}
+		res = append(res, []interface{}{breakdown.Value, breakdown.Count, mean})
+	}
+
+	return res, nil
+}
+
+func (*localStatisticBackend) getValueDistribution(appKey, k string, start, end int) ([]*models.StatValueCount, error) {
+	return models.GetStatValueDistribution(nil, appKey, k, start, end)
+}
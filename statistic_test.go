@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Instafig/Instafig/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStatisticUnit(t *testing.T) {
+	unit, err := parseStatisticUnit("2h")
+	assert.True(t, err == nil && unit == 7200)
+	unit, err = parseStatisticUnit("1w")
+	assert.True(t, err == nil && unit == 7*24*3600)
+
+	for _, bad := range []string{"", "d", "0d", "-1h", "1m", "xh"} {
+		_, err = parseStatisticUnit(bad)
+		assert.True(t, err != nil, bad)
+	}
+}
+
+func TestLocalStatisticBackendAggregate(t *testing.T) {
+	b := &localStatisticBackend{
+		minutes: make(map[localStatisticMinuteKey]*models.StatMinute),
+		devices: make(map[localStatisticDeviceKey]int),
+	}
+	b.resetCodeValues()
+
+	now := time.Now()
+	minute := int(now.Unix()) - int(now.Unix())%60
+	b.add(&statisticPoint{Time: now, App: "app", Node: "node", Lang: "zh", Status: true, RespTime: 100, DeviceId: "d1"})
+	b.add(&statisticPoint{Time: now, App: "app", Node: "node", Lang: "zh", Status: false, RespTime: 300, DeviceId: "d1"})
+	b.add(&statisticPoint{Time: now, App: "app", Node: "node", Lang: "en", Status: true, RespTime: 200})
+
+	assert.True(t, len(b.minutes) == 2)
+	zh := b.minutes[localStatisticMinuteKey{Minute: minute, AppKey: "app", Node: "node", Lang: "zh"}]
+	assert.True(t, zh != nil && zh.Count == 2 && zh.ErrorCount == 1 && zh.RespTimeSum == 400)
+	assert.True(t, len(b.devices) == 1 && b.devices[localStatisticDeviceKey{"app", "d1"}] == int(now.Unix()))
+
+	b.merge(&localStatisticReportData{
+		Minutes: []*models.StatMinute{{Minute: minute, AppKey: "app", Node: "node", Lang: "zh", Count: 1, RespTimeSum: 50}},
+		Devices: []*models.StatDevice{{AppKey: "app", DeviceId: "d2", LastSeenUTC: minute}},
+	})
+	assert.True(t, zh.Count == 3 && zh.RespTimeSum == 450)
+	assert.True(t, len(b.devices) == 2)
+}
+
+func TestStatisticCodeValues(t *testing.T) {
+	values := newStatisticCodeValues(map[string]interface{}{"new_ui": true, "a": "x", "b": true})
+	assert.True(t, len(values) == 3 && values[0].K == "a" && values[1].K == "b" && values[2].K == "new_ui")
+	assert.True(t, values[1].Hash == values[2].Hash && values[0].Hash != values[1].Hash)
+	assert.True(t, values[2].Value == "true" && len(values[2].Hash) == 16)
+
+	b := &localStatisticBackend{
+		minutes: make(map[localStatisticMinuteKey]*models.StatMinute),
+		devices: make(map[localStatisticDeviceKey]int),
+	}
+	b.resetCodeValues()
+
+	now := time.Now()
+	b.add(&statisticPoint{Time: now, App: "app", DeviceId: "d1", CodeValues: values})
+	b.add(&statisticPoint{Time: now.Add(-time.Minute), App: "app", DeviceId: "d1",
+		CodeValues: newStatisticCodeValues(map[string]interface{}{"new_ui": false})})
+	b.add(&statisticPoint{Time: now, App: "app", CodeValues: values})
+
+	assert.True(t, len(b.deviceValues) == 3, "values of requests without device id must be skipped")
+	v := b.deviceValues[localStatisticDeviceValueKey{"app", "new_ui", "d1"}]
+	assert.True(t, v.Hash == values[2].Hash, "older value must not override the latest one")
+	assert.True(t, len(b.codeValues) == 3)
+}
+
+func TestInfluxQuery(t *testing.T) {
+	q := newInfluxQuery("client_request", "COUNT(resp_time)", "MEAN(resp_time)").
+		whereTag(STATISTIC_TAG_APP, `x' OR app = 'y`).
+		whereTime(1, 2).
+		groupByTime(3600).
+		fillWith(0)
+	assert.True(t, q.String() == `SELECT COUNT(resp_time), MEAN(resp_time) FROM "client_request" `+
+		`WHERE "app" = 'x\' OR app = \'y' AND time >= 1000000000 AND time <= 2000000000 GROUP BY time(3600s) fill(0)`, q.String())
+
+	q = newInfluxQuery("config_value", "LAST(value)").
+		whereTag("k", `a\`).
+		whereTime(1, 0).
+		groupByTag(`hash"`)
+	assert.True(t, q.String() == `SELECT LAST(value) FROM "config_value" WHERE "k" = 'a\\' AND time >= 1000000000 GROUP BY "hash\""`, q.String())
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/Instafig/Instafig/conf"
+	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	TOTP_PERIOD              = 30 // second
+	TOTP_DIGITS              = 6
+	TOTP_SECRET_LEN          = 20
+	TOTP_ENROLL_EXPIRES      = 600 // second
+	TOTP_RECOVERY_CODE_COUNT = 10
+)
+
+var (
+	totpBase32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	// last used time step of users on this node, so a code can not be replayed
+	totpUsedStepsMux = sync.Mutex{}
+	totpUsedSteps    = make(map[string]int)
+)
+
+// op api must not return secrets of users
+func hideUserSecrets(user *models.User) {
+	user.PassCode = ""
+	user.TOTPEnabled = user.TOTPSecret != ""
+	user.TOTPSecret = ""
+	user.RecoveryCodes = ""
+}
+
+// checks code of previous, current and next time step for clock drift
+func verifyTOTPCode(userKey, secret, code string) bool {
+	key, err := totpBase32.DecodeString(secret)
+	if err != nil || len(code) != TOTP_DIGITS {
+		return false
+	}
+
+	totpUsedStepsMux.Lock()
+	defer totpUsedStepsMux.Unlock()
+
+	step := utils.GetNowSecond() / TOTP_PERIOD
+	for s := step - 1; s <= step+1; s++ {
+		if s <= totpUsedSteps[userKey] {
+			continue
+		}
+		if utils.HOTPCode(key, uint64(s), TOTP_DIGITS) == code {
+			totpUsedSteps[userKey] = s
+			return true
+		}
+	}
+
+	return false
+}
+
+func hashRecoveryCode(code string) string {
+	h := sha256.Sum256([]byte(strings.ToLower(strings.Replace(code, "-", "", -1))))
+	return hex.EncodeToString(h[:])
+}
+
+// returns codes shown to user and hashes stored
+func newRecoveryCodes() ([]string, string) {
+	codes := make([]string, TOTP_RECOVERY_CODE_COUNT)
+	hashes := make([]string, TOTP_RECOVERY_CODE_COUNT)
+	for i := range codes {
+		code := utils.GenerateSecret(5)
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+
+	bs, _ := json.Marshal(hashes)
+	return codes, string(bs)
+}
+
+// returns stored hashes without the used code, ok is false if code is not one of them
+func useRecoveryCode(recoveryCodes, code string) (string, bool) {
+	var hashes []string
+	if err := json.Unmarshal([]byte(recoveryCodes), &hashes); err != nil {
+		return recoveryCodes, false
+	}
+
+	hash := hashRecoveryCode(code)
+	for i, h := range hashes {
+		if h == hash {
+			bs, _ := json.Marshal(append(hashes[:i], hashes[i+1:]...))
+			return string(bs), true
+		}
+	}
+
+	return recoveryCodes, false
+}
+
+// second factor of users enabled totp, called by Login after passcode verified.
+// error code is only meaningful when err is not nil
+func verifyLoginSecondFactor(user *models.User, totpCode, recoveryCode string) (*models.User, int, error) {
+	if user.TOTPSecret == "" {
+		return user, 0, nil
+	}
+
+	if totpCode != "" {
+		if !verifyTOTPCode(user.Key, user.TOTPSecret, totpCode) {
+			return nil, TOTP_CODE_ERROR, fmt.Errorf(errorStr[TOTP_CODE_ERROR][1])
+		}
+		return user, 0, nil
+	}
+
+	if recoveryCode != "" {
+		// used codes are removed from user, which only master can write
+		if !conf.IsMasterNode() {
+			return nil, NOT_PERMITTED, fmt.Errorf("recovery codes can only be used with master node")
+		}
+
+		confWriteMux.Lock()
+		defer confWriteMux.Unlock()
+
+		memConfMux.RLock()
+		newUser := *memConfUsers[user.Key]
+		memConfMux.RUnlock()
+
+		var ok bool
+		if newUser.RecoveryCodes, ok = useRecoveryCode(newUser.RecoveryCodes, recoveryCode); !ok {
+			return nil, TOTP_CODE_ERROR, fmt.Errorf("recovery code wrong")
+		}
+		if _, err := updateUser(&newUser, nil); err != nil {
+			return nil, SERVER_ERROR, err
+		}
+		syncData2SlaveIfNeed(&newUser, newUser.Key)
+		return &newUser, 0, nil
+	}
+
+	return nil, TOTP_REQUIRED, fmt.Errorf(errorStr[TOTP_REQUIRED][1])
+}
+
+// admins without totp can do nothing but enrol when totp is enforced
+func totpEnrollRequired(user *models.User) bool {
+	return conf.TOTPEnforceAdmin && user.TOTPSecret == "" && isAdminUser(user)
+}
+
+var totpEnrollPaths = map[string]bool{
+	"/op/user/totp/enroll":  true,
+	"/op/user/totp/confirm": true,
+	"/op/user/info":         true,
+	"/op/logout":            true,
+}
+
+// called by OpAuth after login session or api token verified, totp can only be enrolled in login session
+func totpEnrollCheck(c *gin.Context) {
+	memConfMux.RLock()
+	user := memConfUsers[getOpUserKey(c)]
+	memConfMux.RUnlock()
+
+	if user == nil || !totpEnrollRequired(user) {
+		return
+	}
+	if getAPITokenKey(c) != "" || !totpEnrollPaths[c.Request.URL.Path] {
+		Error(c, TOTP_ENROLL_REQUIRED)
+		c.Abort()
+	}
+}
+
+// new secret is kept in a signed token until user confirms it with a code
+func EnrollTOTP(c *gin.Context) {
+	memConfMux.RLock()
+	user := *memConfUsers[getOpUserKey(c)]
+	memConfMux.RUnlock()
+
+	if user.TOTPSecret != "" {
+		Error(c, BAD_REQUEST, "totp already enabled")
+		return
+	}
+
+	key, _ := hex.DecodeString(utils.GenerateSecret(TOTP_SECRET_LEN))
+	secret := totpBase32.EncodeToString(key)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"uky": user.Key,
+		"sec": secret,
+		"exp": utils.GetNowSecond() + TOTP_ENROLL_EXPIRES,
+	}).SignedString([]byte(conf.SessionSignKey))
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	Success(c, map[string]interface{}{
+		"token":  token,
+		"secret": secret,
+		"uri":    newTOTPProvisioningURI(user.Name, secret),
+	})
+}
+
+// otpauth uri of key uri format, rendered as qr code by web
+func newTOTPProvisioningURI(userName, secret string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", conf.TOTPIssuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprintf("%d", TOTP_DIGITS))
+	params.Set("period", fmt.Sprintf("%d", TOTP_PERIOD))
+
+	label := strings.Replace(url.QueryEscape(conf.TOTPIssuer+":"+userName), "+", "%20", -1)
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+func ConfirmTOTP(c *gin.Context) {
+	if !totpWriteCheck(c) {
+		return
+	}
+
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	var data struct {
+		Token string `json:"token" binding:"required"`
+		Code  string `json:"code" binding:"required"`
+	}
+	if err := c.BindJSON(&data); err != nil {
+		Error(c, BAD_POST_DATA, err.Error())
+		return
+	}
+
+	token, err := jwt.Parse(data.Token, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(conf.SessionSignKey), nil
+	})
+	if err != nil {
+		Error(c, BAD_REQUEST, err.Error())
+		return
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid || claims["exp"] == nil || claims["uky"] != getOpUserKey(c) {
+		Error(c, BAD_REQUEST, "totp enroll token invalid")
+		return
+	}
+	secret, _ := claims["sec"].(string)
+	if !verifyTOTPCode(getOpUserKey(c), secret, data.Code) {
+		Error(c, TOTP_CODE_ERROR)
+		return
+	}
+
+	memConfMux.RLock()
+	user := *memConfUsers[getOpUserKey(c)]
+	memConfMux.RUnlock()
+
+	codes, hashes := newRecoveryCodes()
+	user.TOTPSecret = secret
+	user.RecoveryCodes = hashes
+	updateUserTOTP(c, &user, map[string]interface{}{"recovery_codes": codes})
+}
+
+// disables totp of login user, or other user's by admin for lost devices
+func DisableTOTP(c *gin.Context) {
+	if !totpWriteCheck(c) {
+		return
+	}
+
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	var data struct {
+		UserKey string `json:"user_key"`
+		Code    string `json:"code"`
+	}
+	if err := c.BindJSON(&data); err != nil {
+		Error(c, BAD_POST_DATA, err.Error())
+		return
+	}
+
+	memConfMux.RLock()
+	opUser := memConfUsers[getOpUserKey(c)]
+	oldUser := memConfUsers[data.UserKey]
+	memConfMux.RUnlock()
+
+	if data.UserKey == "" || data.UserKey == opUser.Key {
+		oldUser = opUser
+		if oldUser.TOTPSecret != "" && !verifyTOTPCode(oldUser.Key, oldUser.TOTPSecret, data.Code) {
+			Error(c, TOTP_CODE_ERROR)
+			return
+		}
+	} else if !isAdminUser(opUser) {
+		Error(c, NOT_PERMITTED, "can not disable user's totp as current user is not admin")
+		return
+	}
+	if oldUser == nil {
+		Error(c, USER_NOT_EXIST)
+		return
+	}
+	if oldUser.TOTPSecret == "" {
+		Success(c, nil)
+		return
+	}
+
+	user := *oldUser
+	user.TOTPSecret = ""
+	user.RecoveryCodes = ""
+	updateUserTOTP(c, &user, nil)
+}
+
+func RenewRecoveryCodes(c *gin.Context) {
+	if !totpWriteCheck(c) {
+		return
+	}
+
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	var data struct {
+		Code string `json:"code" binding:"required"`
+	}
+	if err := c.BindJSON(&data); err != nil {
+		Error(c, BAD_POST_DATA, err.Error())
+		return
+	}
+
+	memConfMux.RLock()
+	user := *memConfUsers[getOpUserKey(c)]
+	memConfMux.RUnlock()
+
+	if user.TOTPSecret == "" {
+		Error(c, BAD_REQUEST, "totp not enabled")
+		return
+	}
+	if !verifyTOTPCode(user.Key, user.TOTPSecret, data.Code) {
+		Error(c, TOTP_CODE_ERROR)
+		return
+	}
+
+	codes, hashes := newRecoveryCodes()
+	user.RecoveryCodes = hashes
+	updateUserTOTP(c, &user, map[string]interface{}{"recovery_codes": codes})
+}
+
+// totp of users is synced from master, viewers may still manage their own totp
+func totpWriteCheck(c *gin.Context) bool {
+	if !conf.IsMasterNode() {
+		Error(c, NOT_PERMITTED, "You can not update totp as you connecting to slave node,")
+		return false
+	}
+	if getAPITokenKey(c) != "" {
+		Error(c, NOT_PERMITTED, "totp can not be managed with api token")
+		return false
+	}
+
+	return true
+}
+
+func updateUserTOTP(c *gin.Context, user *models.User, res map[string]interface{}) {
+	if _, err := updateUser(user, nil); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	failedNodes := syncData2SlaveIfNeed(user, getOpUserKey(c))
+	if len(failedNodes) > 0 {
+		if res == nil {
+			res = make(map[string]interface{})
+		}
+		res["failed_nodes"] = failedNodes
+	}
+	if res != nil {
+		Success(c, res)
+	} else {
+		Success(c, nil)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Instafig/Instafig/models"
+	"github.com/Instafig/Instafig/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyTOTPCode(t *testing.T) {
+	secret := totpBase32.EncodeToString([]byte("12345678901234567890"))
+	userKey := utils.GenerateKey()
+	code := utils.HOTPCode([]byte("12345678901234567890"), uint64(utils.GetNowSecond()/TOTP_PERIOD), TOTP_DIGITS)
+
+	assert.True(t, !verifyTOTPCode(userKey, secret, "12345"))
+	assert.True(t, verifyTOTPCode(userKey, secret, code))
+	assert.True(t, !verifyTOTPCode(userKey, secret, code), "code must not be replayed")
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes := newRecoveryCodes()
+	assert.True(t, len(codes) == TOTP_RECOVERY_CODE_COUNT && !strings.Contains(hashes, codes[0]))
+
+	hashes, ok := useRecoveryCode(hashes, strings.ToUpper(codes[3]))
+	assert.True(t, ok, "recovery code must be case insensitive")
+	_, ok = useRecoveryCode(hashes, codes[3])
+	assert.True(t, !ok, "recovery code must be used only once")
+	_, ok = useRecoveryCode(hashes, codes[4])
+	assert.True(t, ok)
+}
+
+func TestVerifyLoginSecondFactor(t *testing.T) {
+	user := &models.User{Key: utils.GenerateKey()}
+	verified, _, err := verifyLoginSecondFactor(user, "", "")
+	assert.True(t, err == nil && verified == user, "users without totp need no second factor")
+
+	user.TOTPSecret = totpBase32.EncodeToString([]byte("12345678901234567890"))
+	_, errCode, err := verifyLoginSecondFactor(user, "", "")
+	assert.True(t, err != nil && errCode == TOTP_REQUIRED)
+	_, errCode, err = verifyLoginSecondFactor(user, "000000", "")
+	assert.True(t, err != nil && errCode == TOTP_CODE_ERROR)
+
+	uri := newTOTPProvisioningURI("rahuahua", user.TOTPSecret)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Instafig%3Arahuahua?") && strings.Contains(uri, "secret="+user.TOTPSecret), uri)
+}
@@ -15,28 +15,40 @@ const (
 	DATA_EXPIRED
 	DATA_SYNCING
 	DATA_VERSION_ERROR
+	UPDATE_CONFLICT
+	CONFIG_FROZEN
 
 	NOT_LOGIN
 	USER_INACTIVE
 	USER_NOT_EXIST
 	USER_NOT_INIT
 	PASS_CODE_ERROR
+	USER_LOCKED
+	TOTP_REQUIRED
+	TOTP_CODE_ERROR
+	TOTP_ENROLL_REQUIRED
 )
 
 var (
 	errorStr = map[int][2]string{
-		SERVER_ERROR:       [2]string{"server_error", "server error"},
-		BAD_REQUEST:        [2]string{"bad_request", "bad requeset"},
-		BAD_POST_DATA:      [2]string{"bad_post_data", "bad request body"},
-		NOT_PERMITTED:      [2]string{"not_permitted", "not permitted"},
-		DATA_EXPIRED:       [2]string{"data_expired", "conf data expired, try from anthor node"},
-		DATA_SYNCING:       [2]string{"data_syncing", "conf data syncing, try from anthor node"},
-		DATA_VERSION_ERROR: [2]string{"data_verison_error", "data version error"},
-		NOT_LOGIN:          [2]string{"not_login", "need login"},
-		USER_NOT_EXIST:     [2]string{"user_not_exist", "user not exist"},
-		USER_NOT_INIT:      [2]string{"user_not_init", "need init user first"},
-		USER_INACTIVE:      [2]string{"user_inactive", "user is inactive"},
-		PASS_CODE_ERROR:    [2]string{"pass_code_error", "user passcode wrong"},
+		SERVER_ERROR:         [2]string{"server_error", "server error"},
+		BAD_REQUEST:          [2]string{"bad_request", "bad requeset"},
+		BAD_POST_DATA:        [2]string{"bad_post_data", "bad request body"},
+		NOT_PERMITTED:        [2]string{"not_permitted", "not permitted"},
+		DATA_EXPIRED:         [2]string{"data_expired", "conf data expired, try from anthor node"},
+		DATA_SYNCING:         [2]string{"data_syncing", "conf data syncing, try from anthor node"},
+		DATA_VERSION_ERROR:   [2]string{"data_verison_error", "data version error"},
+		UPDATE_CONFLICT:      [2]string{"update_conflict", "data has been updated by others, reload and try again"},
+		CONFIG_FROZEN:        [2]string{"config_frozen", "configs are frozen"},
+		NOT_LOGIN:            [2]string{"not_login", "need login"},
+		USER_NOT_EXIST:       [2]string{"user_not_exist", "user not exist"},
+		USER_NOT_INIT:        [2]string{"user_not_init", "need init user first"},
+		USER_INACTIVE:        [2]string{"user_inactive", "user is inactive"},
+		PASS_CODE_ERROR:      [2]string{"pass_code_error", "user passcode wrong"},
+		USER_LOCKED:          [2]string{"user_locked", "user is locked for too many failed logins"},
+		TOTP_REQUIRED:        [2]string{"totp_required", "need totp code or recovery code"},
+		TOTP_CODE_ERROR:      [2]string{"totp_code_error", "totp code wrong"},
+		TOTP_ENROLL_REQUIRED: [2]string{"totp_enroll_required", "admin must enable totp first"},
 	}
 )
 
@@ -120,6 +132,52 @@ func getOpUserKey(c *gin.Context) string {
 	return data
 }
 
+func setOpSessionKey(c *gin.Context, key string) {
+	c.Set("_session_key_", key)
+}
+
+func getOpSessionKey(c *gin.Context) string {
+	i, exists := c.Get("_session_key_")
+	if !exists || i == nil {
+		return ""
+	}
+
+	data := i.(string)
+
+	return data
+}
+
+func setAuditLogData(c *gin.Context, data *auditLogData) {
+	c.Set("_audit_log_data_", data)
+}
+
+func getAuditLogData(c *gin.Context) *auditLogData {
+	i, exists := c.Get("_audit_log_data_")
+	if !exists || i == nil {
+		return nil
+	}
+
+	data := i.(*auditLogData)
+
+	return data
+}
+
+func setAPITokenKey(c *gin.Context, key string) {
+	c.Set("_api_token_key_", key)
+}
+
+// empty when the request is not authenticated by api token
+func getAPITokenKey(c *gin.Context) string {
+	i, exists := c.Get("_api_token_key_")
+	if !exists || i == nil {
+		return ""
+	}
+
+	data := i.(string)
+
+	return data
+}
+
 func setClientData(c *gin.Context, data *ClientData) {
 	c.Set("_client_request_data_", data)
 }
@@ -178,3 +236,12 @@ func doEverTask(f func()) {
 		}
 	}()
 }
+
+func inStringSlice(s string, ss []string) bool {
+	for _, _s := range ss {
+		if _s == s {
+			return true
+		}
+	}
+	return false
+}
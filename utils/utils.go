@@ -1,6 +1,11 @@
 package utils
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"time"
 
@@ -14,6 +19,33 @@ func GenerateKey() string {
 	return fmt.Sprintf("%x%x%x%x%x", u[:4], u[4:6], u[6:8], u[8:10], u[10:])
 }
 
+// hex string of n random bytes, for secrets like tokens
+func GenerateSecret(n int) string {
+	bs := make([]byte, n)
+	if _, err := rand.Read(bs); err != nil {
+		panic("failed to read random bytes: " + err.Error())
+	}
+	return hex.EncodeToString(bs)
+}
+
+// HOTP of rfc 4226 with HMAC-SHA1, TOTP of rfc 6238 uses unix time / period as counter
+func HOTPCode(secret []byte, counter uint64, digits int) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+	h := hmac.New(sha1.New, secret)
+	h.Write(msg)
+	sum := h.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", digits, code%mod)
+}
+
 func GetNowSecond() int {
 	return int(time.Now().Unix())
 }
@@ -15,6 +15,23 @@ func TestGenKey(t *testing.T) {
 	}
 }
 
+func TestGenerateSecret(t *testing.T) {
+	secret := GenerateSecret(20)
+	assert.True(t, len(secret) == 40)
+	assert.True(t, secret != GenerateSecret(20), "should not gen same secret")
+}
+
+func TestHOTPCode(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	// vectors of rfc 4226
+	for i, code := range []string{"755224", "287082", "359152", "969429", "338314"} {
+		assert.True(t, HOTPCode(secret, uint64(i), 6) == code, code)
+	}
+	// vectors of rfc 6238 with sha1
+	assert.True(t, HOTPCode(secret, 59/30, 8) == "94287082")
+	assert.True(t, HOTPCode(secret, 1111111109/30, 8) == "07081804")
+}
+
 func TestGetNowSecond(t *testing.T) {
 	now := GetNowSecond()
 	assert.True(t, now > 0)
@@ -545,7 +545,7 @@ func webIndexHtml() (*asset, error) {
 	return a, nil
 }
 
-var _webJsComponentsAppJs = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xec\x5c\x5f\x73\xdb\xb8\x11\x7f\xf7\xa7\xc0\x71\xda\x48\x9e\xf3\x49\xc9\xf4\x4d\x19\xcd\x4d\x26\xf5\x64\xd2\xf6\x6c\x4f\xec\xeb\x4d\x9b\xe9\x70\x18\x11\xb2\x78\xa6\x48\x85\x04\xed\xb8\x39\x7f\xf7\x62\x41\x80\x04\xf8\x0f\x7f\x24\x45\x49\x23\x3c\xc4\x14\x88\x5d\x2c\x80\xc5\xfe\x76\x17\x60\xa6\xd3\x57\x9b\x4d\x7e\x72\x1f\x64\x08\x1e\xd0\x1c\x2d\x8b\x64\x41\xa2\x34\x19\x67\x38\x4f\xe3\x7b\x7c\x86\x32\xfc\x3b\x5e\x90\x53\xf4\xf9\x04\xd1\x02\x4d\x09\x5e\x6f\xe2\x80\x60\xbf\xc8\x62\x4a\x32\x5a\xa4\xeb\x4d\x9a\xe0\x84\xe4\xd3\x80\x72\x99\xac\xc8\x3a\x1e\xbd\x64\xcd\x33\x4c\x8a\x2c\x41\x4b\x4c\x16\xab\xb1\x4c\x77\xca\x5e\x43\x99\x90\x15\x4e\xc6\x55\xbf\x55\x23\x2a\xc0\x46\xf4\x2a\x0a\x67\xa7\xb4\x99\x10\xfc\x89\x8c\x4f\x5f\x56\x0d\x9f\x4e\x7b\x58\xb6\xb9\xb1\x21\x8e\xd5\x5a\x28\x82\x62\x56\x3d\x9d\xb5\xda\x64\x69\x01\x0d\xda\xc4\x50\xc2\x80\x04\xb3\x7a\x32\x49\x16\x24\x79\x04\x8f\x4d\x19\xe4\x02\x73\xbb\x09\xc8\x8a\xce\x69\x52\xc4\xf1\xcb\xc1\x86\xaf\x2f\x7f\xbd\xb8\xf1\xaf\xce\xdf\xf9\x57\xaf\xde\x9c\x53\x92\x17\xcf\x87\x09\x36\xc1\x2d\x86\xe7\x39\xaa\xa5\x99\x90\x74\xb2\x09\xb2\x60\x9d\x4f\xe0\xf5\x30\x03\xf2\xb8\xe9\x64\xf0\xb1\xc0\xd9\xe3\x64\xb1\x4a\xd3\x1c\xfb\xd0\x68\x98\xcd\x1d\x7e\x7c\x48\xb3\xb0\x4f\x0e\xfe\xba\x9f\x47\xb4\x44\xe3\x28\x9f\xe4\x24\xc8\xc8\x6f\x11\x59\x8d\x9b\x6c\xc8\xea\x0c\x8d\x98\x26\x4e\x83\x38\x1e\x9d\x0e\x4d\x39\x14\x3e\xe5\xa3\x69\xba\xa9\xa8\xa6\x23\xf4\x63\x39\x63\x3f\xd2\x17\xf0\x43\x9d\xef\x7e\xf1\x9e\x10\x8e\x73\x6c\x25\x65\x8e\x83\x6c\xb1\x72\x10\xb4\x24\xfc\xf9\xe3\x1c\x04\xd4\x4e\x9c\x83\x64\x45\x8e\x33\x07\xb9\x80\x8c\x4d\x5a\xe7\x0a\xc3\x5b\x9f\x4a\x3b\x20\xe9\x49\xef\x2b\xc5\xa0\x94\xb2\x7e\x46\x6b\x4c\x56\x69\x38\x43\xa3\x37\xe7\x37\xa3\x33\xb4\xc8\x70\x48\xad\x51\x14\xc4\x39\xad\xcb\x83\x35\xfe\x29\xcd\xa2\xdb\x28\x19\x51\xdb\x30\x38\x92\x86\xe1\x80\x5d\xdc\x69\x87\x06\x24\xab\x68\x26\xbf\xe7\x94\xc5\x69\xff\x28\xd9\x48\x9b\xb6\x0a\xc8\x4d\x7a\x83\x55\x84\xb6\xe8\xd9\x33\xd6\xe5\x84\xfd\xf8\xa1\xb4\x1c\x26\x0c\xa0\xc0\x76\xa4\x0b\x36\x2c\xa2\xdc\x18\x76\x84\xbf\x48\x8b\x84\x68\x6d\x54\x47\x3f\x3e\xc8\x68\x46\xb1\xf3\x4d\x2e\x17\x21\x0a\x1d\x43\x35\x75\x93\x38\xca\x89\x99\x6c\x42\xbe\x9a\x96\xa4\x24\x88\xf9\xbc\xfc\xb9\x65\x99\xe7\xe8\xb9\x8d\x74\x50\x94\x79\xee\xee\x67\x6a\x6c\x91\x9a\x85\xdb\x81\x2d\x24\xfa\x85\x4e\xff\x64\x19\xa7\x69\xd6\x33\x09\x4d\xe1\x4e\xa9\x2d\x78\x61\x21\xa1\x51\x4b\xeb\x71\x74\xad\xbb\x99\x50\x66\x02\x2d\xd3\x8c\x6a\x2d\x8a\x92\xaa\x27\x4b\xad\xa4\x82\x09\xca\xf7\xd1\x7f\xcc\xe7\x8b\x64\x8f\x96\xcb\x49\xbb\x99\x04\xc5\x27\x3f\x4a\x96\x29\xed\xf5\x6f\xd7\x97\x17\x60\x9e\x73\x3c\x96\xdf\x68\x8c\x97\x5c\x9e\xd0\x22\xa0\x26\x19\x8d\x5b\x5e\x96\xa5\x28\x9f\x9f\x76\xae\x27\xda\x56\xfa\x16\xdc\xb8\x9b\x8d\x8c\xe9\x98\x5a\x66\xd5\xc2\xb6\x1d\xc9\xae\x02\xca\xd0\x62\x01\x06\xd7\x8c\x5c\xda\xad\x35\x79\x5d\x69\xc6\x04\x67\x99\xbf\xce\x6f\x5d\x65\x10\x7e\x9e\x23\x79\x94\x6c\x0a\xe2\x53\x19\xe8\xa6\x72\x20\x5f\xa7\x21\xb5\x46\x24\x22\x31\x76\x21\xdf\x50\x17\x45\x38\xcc\x15\xb9\x6a\xd3\xcc\x18\x25\xf8\xc1\x87\xa5\x4f\xa8\x17\xe2\x20\x87\x4c\xce\x27\xc3\x86\x3c\x4e\x17\x41\x8c\x73\xa4\x0c\xe3\x4f\x65\xed\x78\xd8\x15\x82\x32\xbc\x2f\x7a\x7c\xa9\x36\xd1\x53\x5b\xd8\xd2\x63\xcb\xfb\xc2\xa7\x3c\xb8\xc7\x7e\x23\x86\xd2\x45\x4e\xf7\x6b\x08\x29\x56\x51\x3e\x1c\x7e\x34\xad\x5e\x4e\xb2\x28\xb9\x8d\x96\x8f\xe3\xfb\xf5\xc4\xd0\xf8\xd1\xb0\x39\x0c\x61\x8f\xf6\xb6\x28\xfd\x53\xe1\x11\x53\x9f\x74\xd8\x72\x54\x0e\xec\xd5\xe5\x35\xf5\x60\x07\xdb\x7e\x48\xc3\xc7\x59\x53\x76\xbd\x61\xf2\x40\x85\x3c\xf6\x38\x43\x7c\xa8\x50\xa5\x57\x24\x0f\x62\xba\x06\x25\x54\x19\x50\x8a\xb9\xf4\xc0\x00\xf2\x67\x8d\x4e\x0d\x73\xed\x77\xed\xfb\x83\x89\xa6\x8b\x0d\xce\x79\x9a\xe4\x5a\xa0\xe2\x36\x5f\x34\xd7\xfa\xf3\x4e\xbe\x7c\xe5\x48\x52\x57\x97\x14\x39\x78\x8b\x24\x2b\x8c\x40\x34\xc7\xe4\x26\x5a\xe3\xb4\x20\x63\xef\x21\x4a\xc2\xf4\x61\x02\x3b\x9b\xf9\xc8\x19\x8e\xd3\x20\x1c\x9f\x7a\x67\xe8\x2f\xcf\x9f\xeb\x82\x10\x53\x47\xaa\xe5\x95\x33\xc9\x17\x69\x88\xcf\x90\xf7\x21\x08\x69\xdc\xf3\xb1\xc0\x39\xf1\x8c\xdd\x71\xaa\x4e\x02\x66\xe6\xb5\x61\xaa\x94\xd3\xc7\x9f\xa8\x5b\x5e\x1a\x3e\xbd\x5b\xd0\x17\xe0\x36\xa5\xdc\xa4\x94\x27\x54\xee\x4e\x4e\x30\xc9\xbb\x14\x33\x49\x89\x4f\x31\x68\x1d\x11\x82\x43\x26\x26\xda\x09\x5f\x1a\x7a\xdf\x53\x68\x63\x92\xee\x64\xf4\x32\x43\x83\xa1\x0f\xeb\xe1\xc0\xd6\xea\xe6\xdd\x81\x2c\x50\xa6\xd3\x0b\xfc\xd0\x6b\x9f\xf3\x55\x4a\x41\x35\x0c\x01\x58\x0d\xf1\x05\x60\x65\x22\xbb\x14\xcd\x69\xe0\xec\xca\xb7\xfd\xf3\xc0\xf8\xc8\x7e\x95\x2e\x8a\x66\x04\xb2\x33\x38\xd7\x2d\x99\x64\xe7\xa9\xd1\xf5\x3c\x8d\x95\x96\x8c\x3b\x34\xcf\x70\x10\x6b\x49\x6a\xab\x8e\xfa\x00\xbc\xd1\x07\xcc\x8a\x67\x22\x0e\x6b\x1e\x2d\xd2\xc4\x33\x92\x9e\x35\x8f\xa3\xe4\x8e\x37\x77\x55\x2f\x2b\xed\xfa\x10\x17\x99\x8d\xde\xc8\x9e\xec\xd0\x7a\xf7\x2a\xf3\x35\x4b\xf3\xb1\x98\xe0\xc3\x23\xf8\xd5\xdd\x6a\xcd\x5a\x35\x94\x9a\x3b\xe1\x3b\xf0\x9d\xc0\xac\x54\xa9\xdb\x39\x2a\x92\x10\x2f\xa3\x04\x87\xe8\x8f\x3f\x90\x54\x3f\x1a\x35\x2a\x4c\xf2\x51\xd4\xbe\xa8\x93\xa4\x6e\x2e\xe9\x9d\x36\xbb\x69\xd9\x91\x3e\x87\x25\x25\xe5\x85\x3b\x37\x35\x4a\xb7\x42\x39\xa6\x29\xdd\xdd\x1b\x85\xbc\x4c\xdb\x48\x6a\x67\x93\x65\xd0\xa8\x17\xd8\x6d\xba\x9a\x3e\x60\x2e\xf5\x37\x0c\xb3\x80\xd6\xd9\xa7\x96\x14\xa3\x91\x79\xa6\x83\x1d\x36\x65\x93\xdb\x74\xfc\x99\x69\xe3\xac\x4c\x7e\x4e\xa9\xa5\x5c\x46\xb7\x4c\x21\xeb\x4c\x1c\x8b\x35\xc5\xb9\x45\x5d\x4d\xc7\x78\x86\xd8\x61\x0d\xb5\xda\x68\x99\xa5\x6b\x30\x16\x39\x8b\x73\x67\x2f\xe8\x80\xfa\x50\xb6\x35\xf4\xfd\x04\x8e\xfd\x84\x3d\x96\x91\x01\xf9\x22\x4e\x13\xdc\xb0\x7a\xf4\x97\x15\x9a\xf3\xd2\x54\x8c\x8a\xb5\x35\xac\x57\x0c\x3d\xcf\x02\xdb\x2b\x2a\xd6\x31\x1b\x85\x86\xba\x23\xbb\x60\xd0\x67\x47\x52\xa1\x9f\xaa\x17\x95\x5e\x83\x90\x0c\x94\x3a\x1b\xd4\x93\x57\xea\xe8\xd7\x19\xc9\xab\x71\xfa\x94\x09\xfd\x55\x44\xeb\xb0\x3d\x1d\xa3\xf5\x94\xbb\x73\x25\xa5\xbc\xd8\xc7\x88\x5d\xd3\xd1\x31\x62\xd7\x96\x86\x4a\xf5\x60\xea\xd7\x16\xbc\x9b\x8a\xbc\xff\x38\xfe\xb0\x61\xbc\xc1\x3c\x7c\xa9\x88\xde\x14\x6d\x54\x1e\x4f\xca\x45\x9f\x97\x27\x34\x74\x3b\x61\xb7\x98\x10\xb3\x54\xfc\x26\xd3\xdb\x12\x16\xb6\xbf\xcc\xc4\xec\xdf\xf1\x42\xd3\x16\x17\x9a\xb8\x77\xdd\x77\xdd\x87\xbf\xee\x57\x34\x65\xc2\x95\x08\x8c\x93\x7e\xa7\x91\x95\x7c\xa7\xc2\xee\x40\xbb\xcb\x77\xaa\xce\x7e\x19\x3f\x9b\x03\xe0\x16\x0d\xab\x9d\x57\x3d\x58\x70\x28\x36\x21\xec\x01\x42\x21\x12\xae\x22\x32\x99\xde\x26\x64\xdc\xd9\xc0\x40\x34\xb7\x43\x5b\x87\xa3\xda\xd2\xff\xae\x09\x0d\x5d\x35\x46\x2d\x66\xad\x76\xb7\x2c\x4f\x67\xa9\x86\x6b\x7c\x50\x28\x87\x3d\xca\x33\xcf\xb4\xee\xcc\xff\xb7\xf5\xfd\x2d\xbc\xfe\x5f\xf7\xe5\xf4\x53\x6b\x56\xa5\x62\xe9\x18\xaa\xa8\x5d\x4f\xd9\x3e\xdc\x33\x75\xf8\x1b\x87\x7b\xe5\x55\x9e\xe3\xe9\x9e\xbe\xa3\x63\xac\xa0\x2d\xfd\xe7\x46\xa5\x5f\xc5\x7c\x4c\x78\x7d\xe8\xf0\x60\x50\xca\xef\x22\x30\x18\x9c\x81\x03\x1f\xf2\x69\x43\x02\xc9\x53\x3e\x79\x82\x78\xe0\x75\x1c\x51\x3b\xc2\x30\x9d\x85\x04\x7f\x2d\x7d\x32\xa6\xbe\xdb\xc7\x05\x79\x78\x57\xc2\xfc\x31\x2e\x38\x78\x5c\x30\xac\xe0\xa2\x93\xaa\xcc\xea\xca\x61\x28\x4a\x73\x9f\xaa\x7c\x4e\x85\x92\x28\x75\xe7\x91\xc0\x59\xa1\x32\x21\x8a\x83\x44\x49\x1a\x1b\x11\x51\xf1\xc4\xe7\x18\xe6\x44\xe0\x37\xff\x17\x92\xb6\x36\x44\x09\x26\x0f\x69\x76\x67\xd7\x53\xeb\x06\xa4\xe1\x98\xf8\xe4\x95\x41\x8d\xed\x94\x97\x54\xa6\x53\xee\xcb\x32\x5a\x4c\xb9\x2f\x05\x0a\xe6\x53\x5e\x51\x59\x4c\xb9\x5d\x4f\x39\x79\x8c\xb1\x2f\xa9\x2d\x25\x32\xb3\xfe\xa3\x30\xca\xe9\xd6\x7f\x1c\x51\x9f\xeb\x03\x35\xfe\x77\xfd\xde\x16\x94\x9e\x03\x01\x55\x0e\x69\x59\x8c\xae\x08\xec\x49\x0c\x58\xe8\x43\xf6\xcf\x75\xe6\x90\x22\x08\x0d\x3c\xa4\x0c\xc2\x86\x1c\x46\x06\x50\x82\xa2\x79\x97\x57\x54\x6a\x02\x92\xf2\xa3\xb6\x88\x82\xa3\x1f\x85\x9f\x4a\xd2\x9f\x5e\xe8\x88\xf0\xe2\x4e\x35\xcf\xa6\x03\x57\xb6\xaf\xd1\xed\x17\xa4\x98\x40\x73\x22\x09\x75\xcc\x89\x24\xd4\x31\x27\x92\x50\xc7\x9c\x48\x42\x1d\xfd\xbd\x9e\x61\x96\x9b\x20\xcf\xb1\x72\x0d\x1d\xcd\x96\x34\xc2\xc4\xfd\x8e\xe8\xde\x92\x21\xdc\x05\xb5\xb9\x3d\xd4\xb4\xeb\x13\xbe\x37\x20\xc7\x36\x4a\xe0\xb8\x52\x73\xda\xdb\x32\xc8\x15\x07\x2b\x06\xa0\x30\x75\xdf\x65\xb1\x62\xc0\x95\x47\xe1\x61\xc5\x40\x28\x92\xcc\xc1\x8a\x01\x57\x2a\x4b\x09\x2c\x32\x52\xd5\x3d\xa1\x05\x8b\x3f\xf8\xed\x8c\x9f\x21\x59\x3d\xe2\x5e\x27\xfb\x54\x53\x9c\xfc\x33\xdf\x94\xbe\x7b\xc6\xe7\xa6\x7e\x29\x76\x1a\x7f\xc9\x57\x4e\x79\xcf\xeb\x06\x75\x1f\xc8\xa5\x95\x57\x3b\x17\x96\x06\x1a\x45\x9b\xb9\xe7\x31\x39\x9f\xc1\x42\xd7\x0d\x99\x9d\x80\x6a\x3e\x79\xf5\x1b\xb1\x45\xe1\xa5\x58\x9a\xfa\x6d\xb5\xeb\xe1\x75\x88\xef\xa3\x05\x35\xa3\xe1\xfc\xc5\xc0\x44\x1f\x2f\x4b\x6d\x77\x59\x8a\x7f\x5c\xed\xdf\x07\x71\x01\x37\x54\x13\xfc\x80\x5e\x65\x59\xf0\xa8\x13\x58\x14\xf6\xfd\x1a\xa8\x64\x94\x48\xd7\x86\x4a\x25\xce\xad\x2e\x5c\xc1\x96\xe1\x52\xb4\x18\xbd\xa7\x3d\x58\x7c\xdb\x56\xf1\x2a\x37\xc4\x1c\x79\x65\x4a\x75\xe0\x92\x4b\xb3\xc0\xbc\x02\x71\x4a\xff\x32\x56\xa7\xf0\xa1\xac\xe0\x63\xfb\xbd\x1a\xcf\xbf\x44\x09\xc1\xb7\x38\xe3\x0c\x6d\x99\x94\x23\x93\x47\x45\xf9\x59\x0c\x09\x8a\xd3\x77\x9c\xed\x8e\x97\x71\x1a\x58\x77\x6d\xdc\xda\xbc\x65\xa5\xba\x93\x4d\x91\xaf\xc6\x9f\xcb\x74\xf8\x0c\xb1\x23\x3e\x8f\xbd\xa1\xbf\xd8\x5f\xf1\x9b\x8d\x41\x54\x96\x03\xda\xdd\x1d\x36\x28\x3c\x21\x4e\xa7\xa9\x92\x6e\xeb\xfc\x97\xf1\xc1\x37\x14\xaa\xa3\x31\xa6\x6e\x5d\xf9\xb7\xbb\x4d\x5a\x38\x38\x14\x5b\x83\xe9\xd1\x23\x71\x71\x28\xfa\x96\xb9\xbc\xbb\x1d\x25\x21\xfe\x24\xad\x25\x0d\x39\xb4\xcb\xd9\x0c\x51\xe6\x88\xfe\x6b\xd5\x39\x85\x55\x9c\x49\xeb\x69\xa8\x4c\xd4\x9c\xe7\x69\x8c\x27\x71\x7a\x3b\x6e\xf8\x24\xfd\x80\xac\x57\xa0\x32\xde\xfb\x32\x0e\xe1\x77\xa9\x82\x50\x00\xc3\x7e\x60\x71\xea\x3b\x7c\x5b\xc4\x41\x36\xf6\x2e\xaf\xfd\x7f\x9e\xbf\xbb\x7e\x7b\x79\xe1\x9d\x35\x7d\x4c\x2d\xbc\x31\x84\x55\x69\x18\xc4\x1a\x81\x2b\x57\x63\x11\x34\xcb\x3c\x28\x8b\xfa\x97\x06\xa3\x8c\xe1\x70\x6b\x23\x66\x20\x38\x94\xe1\x9b\xb8\x0a\x93\x8e\x18\x95\x97\xd2\x05\xd4\x8c\xbc\x3f\x92\x35\x99\x93\xe1\xf9\xd7\x0c\x61\x40\x7c\x9d\xe8\xfd\x62\x37\x54\x49\xdc\x71\xb9\xc5\xe4\x8a\x9d\x0e\x74\x69\x2b\x0f\xa7\x06\xe0\xbf\x4b\x49\xe7\x30\x42\xf8\x3f\x54\x5a\x51\x91\xfa\x86\x85\x41\x6a\x95\x08\xd1\xd4\xda\x2a\xee\x51\xab\x45\xb0\x34\x37\xd9\x14\xf2\x9c\xce\x11\xcb\x55\x0c\xcc\x62\x67\xad\x8d\xfd\xe7\x08\xd2\x09\x00\x26\x08\xd4\x3a\x2a\x11\x65\xde\xb9\x90\xef\x29\xcf\x01\xff\x7f\x78\x77\x1a\xda\xc6\xde\x4d\x69\x70\x3b\xbe\x77\x2f\x0e\xe9\xf3\x20\xb4\x2a\x09\x3a\x5b\x47\xad\x0b\xa3\x76\x80\x93\xdb\x3b\x7b\xdb\x23\xe5\xf6\x50\xb9\x3d\x56\xba\x81\xe5\xab\xab\xab\x2e\xfb\x63\x0d\x97\xb2\xc1\x71\xc6\x4b\xc5\x6a\x21\x4f\xfa\xb9\x07\xc4\xec\x53\x47\x47\xc8\x6c\x9e\x98\xee\x04\x33\x0f\x01\x9a\x8d\x45\x70\x47\xcd\x6d\x61\xb3\x75\x20\xaa\xe0\x66\x8f\xe2\x7e\x97\xc0\xe9\x80\x91\xdd\x96\xdc\x04\x24\x5b\x57\x03\xaa\x32\xef\x5e\x37\x73\x98\xdc\x22\x8a\xe9\xdf\x89\xdb\x00\xa5\x3b\x52\x96\xe7\xb5\xb6\x10\xd9\x13\x85\x7d\xc9\x48\xf2\xf0\xd9\x90\xaf\x27\x92\xfc\xc7\xab\x8b\x37\xc2\xb8\xc0\xd2\x98\xc3\x21\x33\x16\xce\x38\x58\x9a\x1a\xe4\xc1\xdf\x3d\x20\x9f\xa2\x65\xee\x88\x27\x0e\x5e\xb7\x43\xba\x03\x20\x9c\x98\x5e\x17\x68\xdb\x12\xd3\xea\xfb\x3a\x0a\x98\xc9\x8a\x76\x44\x31\xa5\x76\x10\xc5\x1a\x56\xd6\x04\xbe\x5a\x97\xd4\x2a\xdc\xaa\xd6\xc6\x1c\xb0\x2c\x77\x92\xdb\x0e\x72\xd9\x39\xba\x04\x69\x79\x97\xc7\xe1\xec\xbe\x33\x70\xf9\xc6\x22\x38\x47\xcf\x7f\xa7\x31\xe0\xee\x22\xb8\xcb\x6b\xff\xe6\x5f\x57\xe7\x52\xae\x13\xd6\xc8\x2a\xd1\xc9\xb6\xff\x36\x59\x4e\x71\x02\xc7\x1f\xf7\x93\xdf\x74\x89\x99\x75\x32\x8b\xf2\xcd\x46\x6b\xd2\xe4\x3b\x46\x6a\xdb\x27\x37\xeb\xcb\xa4\xcd\xcc\xa6\xac\x98\x47\x5c\x53\x6a\x75\x19\xcc\x86\x85\x36\x4c\x5f\xaa\x57\xa9\x59\x99\xb7\xd7\xc9\x2a\x71\xa9\x6c\x3b\xa7\xac\x65\xfd\xdf\xed\x3b\x46\x62\xce\x48\x57\x5f\x19\xdd\xd1\xa9\xb2\x05\xd2\x1d\x3e\x90\xfa\x3f\x3a\xd3\xbb\x79\xfb\xcb\xf9\xbf\x2f\x2f\x2a\x63\x22\x96\xc7\x1c\xe6\xaa\x7d\xef\x8c\x73\xb5\xe5\x80\xff\x07\xae\x7c\xde\x03\xd2\xb9\x45\xf0\x5a\xa1\xcb\xf2\xad\x9e\xe4\xc9\x73\xef\x9a\x91\xdc\x12\xe8\xd4\x0f\x20\x14\xa4\x6b\x6a\xe7\x11\xea\x94\xda\x41\xa8\xeb\x30\xd1\x26\x58\xd7\xfa\x02\xa8\x2c\xf3\x8e\xb5\x32\x47\xbb\xd6\xd6\xb3\x87\x3b\x59\x2e\xf7\xcc\xa3\x33\xe0\xf1\xb5\xb5\xc7\xbb\x1e\x4b\xfd\x7d\x65\x1e\x0f\x04\x98\x1d\x78\x77\x71\x7e\xf3\xdb\xe5\xbb\xbf\x0b\x83\xc2\x57\xc7\x1c\xed\xc4\x1e\x77\x06\xbb\xca\x48\x20\x8f\x3f\xee\x01\xea\xdc\xdc\x03\x9d\xc8\xbc\x7c\xab\x50\x27\xcd\xfc\x61\x22\x3a\xe5\xa3\x3d\x05\xe7\x1a\x5a\x79\x84\x39\xa5\x76\x10\xe6\xda\x86\xd9\x04\xe5\x5a\x9f\xac\xb2\x32\x6f\xaf\x93\x39\xc6\x35\xf7\x9c\x3d\xc4\x49\x42\x7d\xa9\x88\x6e\xf0\x33\xf9\xff\x05\x00\x00\xff\xff\x4f\xab\x9b\xfc\xfb\x6f\x00\x00")
+var _webJsComponentsAppJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x5d\x6d\x6f\xe3\x36\x12\xfe\xee\x5f\xa1\x0a\x77\x6b\x1b\x75\xed\x0d\xee\x9b\x0d\xa3\x08\xf6\x82\xc5\xde\x5d\x93\x60\x93\xb6\xe8\x2d\x0a\x41\xb1\xe8\x58\x8d\x2c\x7a\x25\x2a\x2f\x4d\xf3\xdf\x8f\xa4\x48\x89\xd4\x2b\x49\x29\x71\xf6\x62\x7d\x68\x64\x91\x33\x1c\x0d\x87\xf3\xcc\x0c\xa9\xed\x6c\x76\xbc\xdb\xc5\x83\x5b\x37\xb2\xc8\x8d\xb5\xb4\xd6\x49\xb8\x42\x3e\x0c\x47\x11\x88\x61\x70\x0b\x26\x56\x04\xfe\x00\x2b\x34\xb6\x1e\x07\x16\xbe\x48\x57\x04\xb6\xbb\xc0\x45\xc0\x49\xa2\x00\x93\x0c\x57\x70\xbb\x83\x21\x08\x51\x3c\x73\x31\x97\xe9\x06\x6d\x83\xe1\x82\x76\x8f\x00\x4a\xa2\xd0\x5a\x03\xb4\xda\x8c\x44\xba\x31\x6d\x26\xd7\x14\x6d\x40\x38\xca\xc6\xcd\x3a\x61\x01\x76\x7c\x54\x7e\x31\x76\x52\x9f\x29\x02\xf7\x68\x34\x5e\x64\x1d\x9f\xc6\x35\x2c\xcb\xdc\xe8\x2b\x8e\xe4\xa7\xe4\xe2\x14\xf3\xec\x6e\x52\xea\x13\xc1\x84\x74\x28\x13\x93\xcb\x73\x91\x3b\xcf\x95\x89\x22\x37\x8c\x7d\x72\x3b\xae\x21\xe0\xba\xdd\xb9\x68\x83\x75\x1a\x26\x41\xb0\x68\xec\xf8\xe1\xec\xe7\xd3\x4b\xe7\xfc\xe4\xb3\x73\x7e\xfc\xf1\x04\x93\x1c\xbd\x5f\xb4\x70\xbe\x06\xe4\x7e\x69\xe5\xd2\x4c\x11\x9c\xee\xdc\xc8\xdd\xc6\x53\xd2\xdc\xcc\x00\x3d\xec\x2a\x19\x7c\x4d\x40\xf4\x30\x5d\x6d\x20\x8c\x81\x43\x3a\x35\xb3\xb9\x01\x0f\x77\x30\xf2\xea\xe4\x60\xcd\xf5\x3c\xfc\xb5\x35\xf2\xe3\x69\x8c\xdc\x08\xfd\xea\xa3\xcd\xa8\xc8\x06\x6d\x26\xd6\x90\x5a\xe2\xcc\x0d\x82\xe1\xb8\x49\xe5\xe4\x62\x2a\x1f\xce\xe0\x2e\xa3\x9a\x0d\xad\xef\x53\x8d\x7d\x8f\x1b\xc8\x0f\x59\xdf\xf5\xe2\x3d\x59\x20\x88\x81\x96\x94\x31\x70\xa3\xd5\xc6\x40\xd0\x94\xf0\xc7\xaf\x4b\x22\x60\xab\xe2\x0c\x24\x4b\x62\x10\x19\xc8\x45\xc8\xa8\xd2\x2a\x67\x98\xb4\x3a\x58\xda\x06\x49\x07\xb5\x4d\x92\x43\x49\x65\x7d\xb4\xb6\x00\x6d\xa0\x37\xb7\x86\x1f\x4f\x2e\x87\x13\x6b\x15\x01\x0f\x7b\x23\xdf\x0d\x62\xfc\x2c\x76\xb7\xe0\x07\x18\xf9\xd7\x7e\x38\xc4\xbe\xa1\xf1\x4d\x0a\x8e\x83\xac\xe2\x4a\x3f\xd4\x20\x59\x46\x33\xfd\x23\xc6\x2c\xc6\x8b\x46\xc2\x92\xaf\x22\xe4\x2a\xa3\x91\x59\x24\x7d\xad\x77\xef\xe8\x90\x53\xfa\xe3\xbb\xd4\x73\xa8\x30\xe0\xcb\x11\x4f\xd8\x42\xb9\x33\x59\x11\xce\x0a\x26\x21\x6a\xf5\x51\x15\xe3\x38\x44\x46\x35\x8a\xde\x17\xb9\x78\x71\x51\xf0\x3b\x64\xaa\x9b\x06\x7e\x8c\x16\xca\x2c\xb8\xfa\x53\x5a\x04\x91\x1b\x30\xbd\xfc\xbd\xe4\x99\x97\xd6\x7b\x1d\xe9\xd2\x15\x25\xe8\xb9\x7a\x9c\x99\xb2\x47\xaa\xf1\x03\x1d\x24\xfa\x09\xab\x7f\xba\x0e\x20\x8c\x46\x6a\xc2\x8d\xb1\x2f\x38\xd2\x90\x70\xf0\x2c\xef\x51\x35\xef\x6a\x42\xa9\x09\xb4\x86\x11\xb6\x5a\xcb\x0f\xb3\x91\x34\xad\x12\x0b\xc6\x29\xbf\xf8\xbf\xab\xeb\x0b\x45\x0f\x9a\xd3\x89\x87\x99\xba\xc9\xbd\xe3\x87\x6b\x88\x47\xfd\xd7\xc5\xd9\x29\x71\xcf\x31\x18\x89\x2d\x63\x1d\xa3\x5a\xb9\xd8\x25\x5b\x23\x30\xee\x26\xca\xe3\x53\xef\x76\x32\xe8\xde\x83\x39\x77\xb5\x37\xa3\x36\x26\x5f\xf3\x6c\x62\x27\x03\x55\x63\x28\xb1\x20\x0e\x57\x8d\x5c\x58\xad\x39\x79\xfe\x50\x8d\x09\x88\x22\x67\x1b\x5f\x9b\xca\xc0\xe3\x3c\x43\x72\x3f\xdc\x25\xc8\xc1\x32\xe0\x45\x65\x40\xbe\x85\x1e\xf6\x46\xc8\x47\x01\x30\x52\x20\x0e\x51\x78\xc0\x9c\x91\xcb\x3e\x4d\x8d\x51\x08\xee\x1c\x32\xf5\x21\x8e\x42\x0c\xe4\x10\xc9\x99\x32\x74\xc8\x03\xb8\x72\x03\x10\xcb\x73\xf0\xb7\xf4\xe9\x68\xdc\x71\x5d\xd4\xc4\x52\x65\xa2\xa7\xb2\xb0\x69\xc4\x16\xd7\xa5\x4f\xb1\x7b\x0b\x9c\x42\x0e\xd5\x96\x39\xdd\x6e\x49\x4a\xb1\xf1\xe3\xe6\xf4\xa3\xe8\xf5\x62\x14\xf9\xe1\xb5\xbf\x7e\x18\xdd\x6e\xa7\x8a\xce\x6f\x36\x3b\xf6\x3c\xb2\x46\x6b\x7b\xa4\xf1\x29\x8f\x88\x71\x4c\xda\xec\x39\xb2\x00\xf6\xfc\xec\x02\x47\xb0\x8d\x7d\xaf\xa0\xf7\x30\x2f\xca\xde\xee\x98\x6c\x62\x42\x36\x33\x01\xf6\xaa\xe4\x51\xbb\x21\xd9\x24\xa7\x2b\x50\x92\x47\x0a\x94\x5c\x97\x36\x71\x80\xec\xbe\xc5\xa6\x9a\xb9\xd6\x87\xf6\x03\xe5\x10\x9b\x04\xe7\x30\x8c\x5b\x81\x8a\xf9\x7c\xde\xbd\x35\x9e\x37\x8a\xe5\xb3\x40\x12\x87\xba\x28\x89\x49\xb4\x88\xa2\x44\x09\x44\x63\x80\x2e\xfd\x2d\x80\x09\x1a\xd9\x77\x7e\xe8\xc1\xbb\x29\x59\xd9\x34\x46\x8e\x40\x00\x5d\x6f\x34\xb6\x27\xd6\x3f\xde\xbf\x6f\x4b\x42\x54\x03\xa9\x52\x54\x4e\x25\x5f\x41\x0f\x4c\x2c\xfb\xca\xf5\x70\xde\xf3\x35\x01\x31\xb2\x95\xc3\x71\x6c\x4e\x1c\x66\x96\xb9\x63\xca\x8c\xd3\x01\xf7\x38\x2c\x4f\x1d\x5f\x7b\x58\x50\x97\xe0\x16\xa5\xdc\x41\xcc\x93\x3c\xec\x4f\x4e\xe2\x92\xfb\x14\x33\x84\xc8\xc1\x18\xb4\xf5\x11\x02\x1e\x15\xd3\xea\x85\x2f\x4e\xbd\x6f\x31\xb4\x51\x49\x7b\x79\x7b\x91\xe1\xa2\x2b\xa0\x34\x2c\xad\x6a\xde\x4f\xd5\xde\x62\x36\x3b\x05\x77\xb5\xfe\x39\xde\x40\x0c\xaa\x9e\x47\x80\x55\x11\x5f\x08\xac\x4c\xc5\x90\xa2\xa8\x06\xc6\x2e\x6d\x5d\x34\xf3\x11\xe3\xaa\xb6\x2c\x9a\x12\x88\xc1\xe0\xb2\x6d\xca\x04\x3f\x8f\x9d\xae\x6d\xb7\x78\x69\xc1\xb9\x93\xee\x11\x70\x83\x56\x92\xdc\xab\x5b\x73\x15\x0b\xb2\xa9\x56\x6c\x15\x71\x68\x77\x7f\x05\x43\xdb\x52\xee\x1e\xf8\xe1\x0d\xeb\x6e\x6a\x5e\x5a\xd6\x75\x15\x24\x91\x8e\xdd\x88\x91\x6c\xd3\x7c\xd7\x1a\xf3\x05\x2d\xf3\xd1\x9c\xe0\xea\x81\xc4\xd5\xd5\x66\x4d\x7b\x15\x8c\x9a\x05\xe1\x3d\xc4\x4e\xc4\xad\x64\xa5\xdb\xa5\x95\x84\x1e\x58\xfb\x21\xf0\xac\xbf\xfe\xb2\x84\xe7\xc3\x61\xe1\x81\x4a\x3d\x0a\xfb\x17\x59\x49\xf2\xe2\x12\xda\x5a\xab\x9b\x9a\x03\xb5\xd7\xb0\x84\xa2\x3c\x0f\xe7\x66\x4a\xe5\xd6\x3c\x0c\x3c\x94\x29\x4d\xc2\x9b\x52\x99\x4d\x32\x3b\x9d\x2a\x43\x8b\x79\x11\xbf\x8d\x67\xd3\x21\x98\x8b\xe3\x0d\xc5\x2a\xa0\x76\xf5\xa9\x24\xc5\x70\xa8\x5e\xe9\xa0\x9b\x4d\xd1\xf4\x1a\x8e\x1e\xa9\x35\xce\xd3\xe2\xe7\x0c\x7b\xca\xb5\x7f\x4d\x0d\x32\xaf\xc4\xd1\x5c\x93\xef\x5b\xe4\x8f\xf1\x3b\x4e\x2c\xba\x59\x83\xbd\xb6\xb5\x8e\xe0\x96\x38\x8b\x98\xe6\xb9\xf3\x23\xfc\x42\x4f\xe3\xc5\x8b\x14\x54\x1a\xac\xfb\x49\xc7\x33\x52\x20\x5f\x05\x30\x04\x05\xaf\x87\x7f\x69\xa1\x79\x86\xad\xb2\x61\x64\xac\xb5\x61\x3d\x63\x68\xdb\x1a\xd8\x9e\x51\xd1\x81\xe9\x5b\xb4\x50\x57\x54\x17\x14\xc6\xac\x28\x2a\xd4\x53\xd5\xa2\xd2\x07\x22\x24\x05\xa5\xca\x0e\xb9\xf2\x52\x1b\x7d\x9d\x99\xbc\x9c\xa7\xcf\xa8\xd0\xaf\x22\x5b\x27\xcb\xd3\x30\x5b\x87\x76\x56\xea\xc1\x94\xe2\x64\x1f\x32\xf6\x43\xc6\xde\x43\xc6\x5e\xe9\x3f\x5e\x77\xf2\xae\x2a\xf2\xf3\xe7\xf1\xfb\x4d\xe3\x15\xf4\xf0\x52\x19\xbd\x2a\xda\xc8\x3c\x9e\xa4\x83\x3e\x8b\x01\x4e\xdd\x06\x33\x72\x8a\xc9\xa2\x9e\x8a\x9d\x64\xfa\x94\xc2\x42\xf7\xc3\x4c\xd4\xff\x1d\x0e\x34\x75\x38\xd0\xc4\xa2\xeb\xba\xe3\x3e\xac\x79\xa1\x76\xe0\x43\xca\xc0\x18\xe9\x1b\xcd\xac\xc4\x33\x15\x7a\x1b\xda\x55\xb1\x53\xb6\xf7\x4b\xf9\xe9\x6c\x00\x97\x68\x58\x14\xca\x7f\x6a\x70\x48\x76\x1e\x59\x03\x08\x43\x24\x39\x8a\x48\x65\xfa\x14\xa2\x51\x65\x07\x05\xd1\xcc\x36\x6d\x0d\xb6\x6a\xd3\xf8\x3b\x27\x54\x0c\xd5\x28\x35\xd7\x5a\x1e\x6e\x69\xee\xce\x62\x0b\x1f\x4e\x5e\xf9\x56\x9e\x7a\xa5\xb5\xb7\xf8\x5f\x37\xf6\xd7\x88\xfa\x7f\x7e\xae\xa0\x1f\x7b\x33\x5b\x08\xdd\xb3\xac\xdd\x64\x73\xcf\x31\xdb\xdc\x4b\x8f\xf2\x98\xef\xee\x29\x90\x91\x6e\x0e\x5b\xca\xbe\x67\x0b\xe3\xca\x2d\x87\xb4\xe3\xff\x33\xed\x60\x13\x4c\xca\x02\x81\xbf\x32\xdd\x2c\xa4\x1c\xf1\x6d\x7f\x99\x45\x8f\x9b\x97\x69\xd8\x48\x43\x68\xa7\x57\x19\x7b\xdd\xba\x4c\xa5\x7c\x13\x79\x4f\xa3\x06\xf6\xbc\x87\xd9\x9a\xf1\x08\x89\xc0\xe0\x89\xa4\x3b\x1f\x02\x1f\xfb\x36\xba\x04\x68\xc6\xf3\xcf\x34\xe4\xa4\xe6\xdb\x3d\xed\x89\xbd\x9b\x34\x8a\x39\xa4\x3d\x7b\x4f\x7b\x9a\x0d\x9c\x0f\x92\x5d\xf3\xfc\x61\x33\x3c\xc2\xd8\xc1\x26\x1f\x63\xa1\x04\xca\xb6\xed\x56\xc2\x59\xa2\x52\x21\x0a\xdc\x50\xaa\x89\x2b\x11\x61\xf1\xf8\xd7\x26\xea\x44\x24\x2d\xf8\x93\xd4\xa4\x75\x88\x42\x80\xee\x60\x74\xa3\x37\x52\xe9\x80\xa7\xe2\x3b\x31\xe5\xa5\x39\x9b\xae\xca\x53\x2a\x55\x95\x3b\xa2\x8c\x1a\x2a\x77\x84\x3c\x48\x5d\xe5\x19\x95\x86\xca\xf5\x46\x8a\xd1\x43\x00\x1c\xc1\x6c\x31\x91\x9a\xf7\x1f\x7a\x7e\x8c\x97\xfe\xc3\x10\xc7\x81\x57\xd8\xf9\xdf\x0c\x9b\x1d\xb8\x8a\x1c\xc2\xb4\xcc\xf7\x28\x06\x99\xe8\x7d\x8e\xcf\x6c\x66\x9f\x22\x70\x0b\xdc\xa7\x0c\xdc\x87\xec\x47\x06\x62\x04\x49\xf1\xa8\x32\x7f\xd8\x92\x24\xa5\xdf\xec\xf9\x18\x1c\x71\xbe\x75\x9f\x92\xfe\x70\xd4\x46\x04\x56\x37\xb2\x7b\x56\x7d\x71\x69\xf9\x2a\x1d\xee\x91\x5d\xa0\x3a\x91\x80\x3a\xea\x44\x02\xea\xa8\x13\x09\xa8\xa3\x4e\x24\xa0\x4e\xfb\xb1\xa5\x49\xcb\xc7\x77\x71\x0c\x3c\x19\x8d\xd6\x38\xeb\x05\xba\x7b\xec\x3d\xd4\x7a\x58\x08\xaa\x73\x38\xaa\xe8\xd7\xa7\x6c\x6d\x90\x12\xe2\x30\x24\xbb\xb1\x0b\x15\x06\x82\x91\x64\x1c\xb4\x18\x10\x83\xc9\xc7\xe6\x5b\xe9\x1a\x0c\x98\xf1\x48\x3c\xb4\x18\x70\x43\x12\x39\x68\x31\x60\x46\xa5\x29\x81\x46\xc1\x2d\x3b\x06\xb5\xa2\xf9\x07\x3b\x7c\xf2\x23\xa9\xc5\x0f\x59\xd4\x49\xbf\x44\xe5\x07\x1b\x68\x6c\x8a\xdb\xde\x31\xdd\xe4\x8d\x7c\xa5\xb1\x46\x36\x73\x52\x3b\x7b\xd6\x68\xfb\x84\x5c\x98\x79\x79\x70\xee\x69\x48\x27\x7f\xb7\xb4\x6d\x2a\xe7\x3b\x32\xd1\x79\x47\xea\x27\xc8\x63\xa6\xbc\xbc\x85\x2f\x51\xd2\xc8\xa7\x26\x6f\xcd\x56\x3d\x69\xf6\xc0\xad\xbf\x22\x15\xab\xe5\xd1\xb0\xad\xdc\x78\x38\x0b\x66\x7a\x16\x8c\x7d\x3b\xee\xdc\xba\x41\x42\x0e\xe0\x86\xe0\xce\x3a\x8e\x22\xf7\x61\xa4\x78\x6c\x89\x7e\x9e\x47\x4c\xd2\x0f\x85\x53\x51\xa9\x11\xc7\x5a\xe7\xc9\xc8\x92\x61\x52\x94\x18\x7d\xc1\x23\x68\x7c\xba\x97\xf1\x4a\x17\xc4\xd2\xb2\xd3\x8a\xb1\xad\xf7\x2d\x2a\x21\x86\xf8\x2f\x65\x35\x26\xdf\x01\x73\x3e\xba\x9f\xe3\xb1\xfa\x8b\x1f\x22\x70\x0d\x22\xc6\x50\x97\x49\xfa\x66\xe2\x5b\x61\x7e\x1a\xaf\xa4\x55\x6c\x6c\x1e\x78\x1d\x40\x57\x7b\xe8\x41\xff\x3d\x33\xd3\x9d\xee\x92\x78\x33\x7a\x4c\xab\xfd\x73\x8b\xee\x60\xda\xb4\x05\xff\xa2\x7f\xf9\x6f\xfa\x0e\xfc\x61\xfa\x42\xfd\x1d\xd1\x63\x85\x31\xb6\x7d\x98\x49\xb7\x78\xa6\x9d\xa4\x9a\x13\x7c\x28\x0a\x00\x0e\xeb\xd2\xbf\xd5\x7d\x60\x62\x10\x50\x74\x06\xd3\x43\x44\x62\x12\x50\xd4\x4d\x73\x7a\x34\xdd\x0f\x3d\x70\x2f\xcc\x25\x4e\x39\x5a\xa7\xb3\x98\xa2\x2c\x2d\xfc\x5f\xad\xc1\x31\xac\x82\x48\x98\x4f\x45\x63\xc2\xee\x3c\x86\x01\x98\x06\xf0\x7a\x54\x88\x49\xc6\x1d\x0c\x28\xcd\xf7\x5e\x26\x20\x7c\x93\x26\xc8\x31\xec\x3b\x9a\xa7\x7e\x06\xd7\x49\xe0\x46\x23\xfb\xec\xc2\xf9\xe5\xe4\xf3\xc5\xa7\xb3\x53\x7b\x52\x8c\x31\xc7\x2a\x7b\x6f\x05\x1a\x0a\xb1\x4a\xe0\xca\xcc\x98\x27\xcd\x22\x0f\xcc\x22\xff\x65\xf7\xb4\xf7\xd6\xd9\x89\x29\x08\x9e\x4e\x83\x6d\x2b\x32\xa9\xc8\x51\x33\x6b\x22\x21\xe0\xc2\x74\x4b\x45\x45\x27\xcd\xfa\x6f\x79\x85\x06\xf1\xdb\x44\x7f\x6a\xb6\xef\x62\x39\x78\x69\x5d\x03\x74\x4e\x77\x07\xaa\xac\x95\xa5\x53\xe3\x66\xa3\x2f\x1a\xe9\x92\xbc\x21\xf9\x27\x62\x4a\x59\x91\xdc\x42\xd3\x20\xf9\x11\x4f\xd1\xe4\xa7\x59\xde\x23\x3f\xe6\xc9\xd2\x52\x65\x51\x88\x3a\x5d\x5a\xb4\x56\xd1\xf4\x59\x4f\xd5\xa5\xe3\xff\x19\x82\x54\x02\x80\x0a\x02\x95\xb6\x4a\x04\x03\xa8\x98\xc8\x2f\x98\xe7\xef\xa6\x21\x86\xa2\x6f\xac\x5d\x94\x0a\x87\xff\x6b\xd7\x62\x93\x3d\x37\x42\xab\x54\xa0\xd3\x0d\xd4\xaa\x30\xaa\x07\x9c\xec\x1e\xec\x75\x47\xca\xee\x50\xd9\x1d\x2b\xcd\xc0\xf2\xf8\xfc\xbc\xca\xff\x68\xc3\xa5\xe8\x70\x8c\xf1\x52\xf2\x5a\x96\x2d\xfc\x7c\x06\xc4\xac\x33\x47\x43\xc8\x2c\xee\x98\xf6\x82\x99\xfb\x00\xcd\xc2\x24\x98\xa3\x66\x57\xd8\x2c\x6d\x88\x4a\xb8\x59\x63\xb8\x6f\x12\x38\x0d\x30\xb2\xda\x93\xab\x80\x64\xe9\x68\x40\x11\x25\x8b\xf3\xa6\x0e\x93\x1d\xb2\x98\xfa\x95\xd8\x05\x28\xcd\x91\x32\xdd\xaf\xd5\x85\xc8\x9a\x2c\xec\x25\x33\xc9\xfd\x57\x43\x5e\x4f\x26\xf9\x9f\xe3\xd3\x8f\xdc\xb9\x90\xa9\x51\x87\x43\xea\x2c\x8c\x71\x30\x75\x35\x96\x4d\xfe\x3e\x03\xf2\x49\x56\x66\x8e\x78\x7c\xe3\xb5\x1b\xd2\xed\x01\xe1\xb8\x7a\x4d\xa0\xad\x23\xa6\xe5\xe7\x75\x24\x30\x13\x0d\xed\x80\x62\xea\x28\x56\xf0\xb2\x2a\xf0\x55\x3a\xa4\x96\xe1\x56\x36\x37\xea\x80\xa5\xb9\x92\xcc\x56\x90\xc9\xca\x69\x2b\x90\xa6\x67\x79\x0c\xf6\xee\x2b\x13\x97\x6f\x2c\x83\x33\x8c\xfc\x7b\xcd\x01\xfb\xcb\xe0\xce\x2e\x9c\xcb\xdf\xce\x4f\x84\x5a\x27\x99\x23\xad\x42\x27\x5d\xfe\x5d\xaa\x9c\x7c\x07\x8e\xdd\x3e\x4f\x7d\xd3\x24\x67\x6e\x93\x39\x9f\xbb\x6f\x34\x5b\x13\x94\x6f\x98\xa9\x75\x2f\x6e\xe6\x87\x49\x8b\x95\x4d\xd1\x30\x0f\xb8\xa6\x55\xc1\x2c\x78\x68\xc5\xf2\xa5\x7c\x94\xba\x50\xbb\xcc\xe6\x49\xab\x70\x29\x2d\x3b\xa3\xaa\x65\xfe\x7f\x13\x30\xcc\xc4\x8c\x91\x2e\x3f\x32\xda\xd3\xae\xb2\x06\xd2\x1d\xb6\x95\x7b\xcc\xc4\x2e\x3f\xfd\x74\xf2\xdf\xb3\xd3\xcc\x99\xf0\xe9\x51\x87\xb9\x6c\xdd\x1b\xe3\x5c\xee\x39\xc8\x3f\x73\x97\xde\x3f\x03\xd2\x99\x65\xf0\xad\x42\xf7\x88\x73\x7b\x80\x39\x51\xf7\xa6\x15\xc9\x8e\x40\x27\x7f\x00\x21\x21\x5d\xd1\x3a\x0f\x50\xa7\x0e\x75\x15\x2e\x5a\x05\xeb\x4a\x5f\x00\x49\x60\x27\xcd\x95\x3a\xda\x95\x96\x9e\x3e\xdc\x89\x72\x99\x57\x1e\x8d\x01\x8f\xcd\xad\x3e\xde\xd5\x78\xea\xb7\x55\x79\xdc\x13\x60\x56\xe0\xdd\xe9\xc9\xe5\xaf\x67\x9f\xff\xcd\x1d\x0a\x9b\x1d\x75\xb4\xe3\x6b\xdc\x18\xec\x32\x27\x61\xd9\xec\xf6\x19\xa0\xce\x2c\x3c\x68\x13\xf9\x1b\x87\x3a\x41\xf3\xfb\xc9\xe8\xa4\x8f\xf6\x24\x9c\x2b\x58\xe5\x01\xe6\xd4\x61\xae\xec\x98\x55\x50\xae\xf4\xc9\xaa\x08\x72\xe2\x3c\xa9\x63\x5c\x71\xcd\xe9\x43\x9c\x20\xd4\x4b\x65\x74\x8d\x9f\xc9\xff\x0f\x1d\xec\x9b\xb7\xda\x70\x00\x00")
 
 func webJsComponentsAppJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -560,12 +560,12 @@ func webJsComponentsAppJs() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "web/js/components/app.js", size: 28667, mode: os.FileMode(420), modTime: time.Unix(1469584226, 0)}
+	info := bindataFileInfo{name: "web/js/components/app.js", size: 28890, mode: os.FileMode(420), modTime: time.Unix(1469584226, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _webJsComponentsConfigJs = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xec\x7d\xff\x8f\x1c\x45\x76\xf8\xef\xfe\x2b\x9a\xd1\x7d\x98\xd9\x8f\x67\x7b\x76\x39\x45\x89\xc6\x2c\x92\x65\x19\xe2\x88\xd8\x96\x6d\xc8\x0f\xab\xd5\x68\x3c\x53\xbb\xdb\xc7\x4c\xf7\x5c\x77\xcf\xd8\x2b\xdf\x4a\x90\x3b\x12\xe0\x70\x20\x17\xe0\x80\x70\x08\x90\x43\xd0\x09\x9f\xef\x94\x88\x98\xb3\x81\x3f\x26\x9e\x5d\xfb\xa7\xfc\x0b\xa9\x57\x55\xdd\x5d\xdd\x5d\x5d\xf5\xaa\x67\xd6\x31\xe0\x92\xc0\xb3\xdd\xf5\xe5\xbd\x57\xaf\x5e\xbd\x7a\xf5\xde\xeb\x4e\xe7\x54\xe0\x0f\xbd\xd8\x0b\x7c\x67\xd6\x1f\x4d\x89\x73\x6c\xd6\x0f\x9d\x93\x93\x49\xfa\x3c\x72\x36\x9c\xed\xa9\x3f\x80\xdf\xad\x90\x44\xc1\x68\x46\xda\x4e\x48\x7e\x46\x06\xf1\x8a\x73\xed\x98\x43\x0b\xb4\x89\xc9\x78\x32\xea\xc7\xa4\x37\x0d\x47\xb4\x49\x73\x10\x8c\x27\x81\x4f\xfc\x38\xea\xf4\x27\x93\xde\x20\xf0\xb7\xbd\x1d\xf8\x87\x77\xeb\xee\xc6\xe3\x51\xf3\x04\x6b\x1e\x92\x78\x1a\xfa\xce\x36\x89\x07\xbb\x2d\xb9\x9f\x15\xf6\x1a\x8a\x1b\xef\x12\xbf\x95\xc2\x91\x56\xa2\x00\x4d\x12\x28\x92\x22\xba\xcb\xd5\x71\x63\x72\x35\x6e\xad\x9c\x48\x2b\xee\xaf\x54\x74\x59\xee\x8d\xa1\xdc\xca\x3f\x85\x92\xb4\xe8\xa6\xbf\xda\xa5\x3a\x61\x30\x85\x0a\xe5\xc6\x50\x86\xfd\xb8\xdf\xcd\x88\x1b\x87\x7d\x3f\x62\xc4\x29\xc2\x20\x17\xa0\x35\x50\xb1\x07\xad\x4f\x68\xab\x65\x1d\xf6\x3c\x7f\x3b\xa0\xb3\x92\x3d\x71\xe3\xc0\xfd\xf9\x94\x84\x7b\xd5\x5d\xe4\xa6\xa5\xd9\x09\x26\x1d\x3e\x89\x9d\xcb\x7b\x9d\xa6\x73\xbc\xd8\xbd\x2b\xa6\xf8\x25\xb2\xd7\x76\xae\x39\x63\x12\xef\x06\xc3\xae\xd3\x7c\xee\xf4\xa5\x66\xdb\x19\x84\x64\x48\x99\xc1\xeb\x8f\x22\xfa\x2c\xea\x8f\xc9\x6a\x10\x7a\x3b\x9e\xdf\xa4\x53\x51\x09\x02\x94\xc2\x3c\x01\xda\xca\x69\xd7\xa0\x90\xb6\x71\x7f\x16\xd1\x2e\x56\xaa\x71\x86\x52\x62\x0d\x68\x8e\x19\x4d\x4c\x0d\x10\x41\xd0\x1b\x5a\xba\xfa\x89\x92\x1b\xb3\x25\xd8\x8b\xf7\x26\xc4\x5c\x3f\x0e\xf7\x10\x20\x41\xf1\xb6\x9d\xd6\xdf\x5c\x3c\x77\xd6\x9d\xf4\xc3\x88\xb4\x24\x08\xdd\xd9\xca\x66\x13\x98\x69\x95\x8d\x1c\x35\xb7\x30\x68\x26\x25\xe5\x42\x8a\x68\x65\xff\x66\x44\x92\xd2\xe9\xcc\x5f\xbb\x71\xf0\xde\xcd\x8c\x08\x87\x7f\xba\x33\xff\xf8\xd7\xe8\x0e\x00\x4f\x68\x16\x6c\xb7\x52\xd0\x36\x9b\x43\xb2\xdd\x9f\x8e\x62\x8e\x21\x20\xb8\xb1\xe1\x34\xfc\xe9\xf8\x32\x09\x1b\x36\xd8\x26\x23\x78\x91\xeb\xf9\x31\xd9\x21\xa1\x76\x94\x27\x9f\x74\xa4\xd7\x39\x1a\xbb\x64\x46\xd7\x1d\xed\xe8\x8c\x1f\xaf\xd8\x82\x00\x25\xa3\x10\xa5\x7c\x83\x42\xd3\xc0\x53\x39\x29\xdb\x41\x48\x71\x71\x3c\xbf\x12\xcc\x3a\x90\x41\xa9\xea\x6f\xd3\xdb\x72\xf9\x1e\xb3\xe1\x30\x4e\xa1\xe8\xb7\x8c\x95\x2d\x18\x28\x29\xfb\x56\x2d\xf6\x1d\x32\x8a\xc8\xc2\xb3\xb0\x3d\x0a\xfa\x8f\xd6\x3c\xa4\x1b\x45\x42\x74\x23\xad\xed\xa1\x87\xa2\x5a\x14\x7c\xea\xea\x42\x0e\x05\xcd\x45\xcf\x02\xe1\xe5\x51\xe9\xce\xd4\x70\xd7\x6a\x4c\x05\x94\xda\xdc\xb0\x38\xdc\x75\x01\x76\x8e\x78\x81\x40\xc1\x0b\xbe\x25\x48\x8d\x42\x97\x0a\x82\x55\xc8\xdc\xba\x13\x6f\x29\x30\xd0\xb5\x6b\x31\x53\x5e\xac\x44\x71\xe8\xf9\x3b\x16\x38\xe1\xa0\x33\xd7\xda\x77\x06\x7d\xaa\xf7\x39\x2d\x50\x88\xcd\xd5\xe9\xe6\x7d\xfd\xdd\x7b\xb7\x7f\x7f\xf0\xbb\x4f\xef\xdd\xf9\xea\xfe\x7f\xfd\xc7\xfd\x6f\xbe\x34\x36\x62\x1b\x76\x41\x89\xec\x0f\x87\xd9\x21\x01\xf6\xea\x75\x2c\x47\x81\xbc\x8b\x28\xb1\x46\x84\x75\xc0\x49\x98\xd3\x79\xf9\x08\xc5\x3a\x38\xda\x16\x7b\x4f\xd6\x72\xb1\x77\x0b\x41\x0a\x5d\xfa\xe4\x8a\xe8\x2a\x29\x1b\xce\x1a\xae\x39\x50\xaf\x84\xef\x86\x50\x08\x6c\x96\xa1\x0a\xad\x74\x83\x2e\xbd\xb4\x90\x53\x75\xf5\x14\xb1\x6e\x2a\x11\xe4\x7b\xad\x0d\x8a\xc5\xae\x04\x9a\x42\x6f\xe5\x8b\xcc\xdb\xde\x6b\x69\xd0\xb6\x96\x6c\x95\x64\xe5\x82\xac\x3c\x82\xb5\xfc\xc2\x4b\x22\x25\x9b\xb9\xeb\xb5\xa6\x32\xed\xc0\x4a\xe7\xb1\x96\x85\x0b\xca\x41\x09\x63\xda\x1c\x4b\x55\x1c\x45\xe5\x83\xcf\x35\xa7\x21\xed\xf3\x8d\xae\xb3\x29\x9e\x30\x99\x40\xff\xa6\x7f\xf6\xc3\x9d\xe9\x18\x2c\x21\xe2\x75\xb4\x37\xbe\x1c\x8c\xe8\x1f\x8d\xe7\x4f\x9e\x7d\xae\xe1\xec\xb7\x9d\x06\xf1\x1b\x5b\xf4\x1f\x38\x73\xc2\x0b\x8a\xef\x13\x1b\xfc\x0d\xeb\x99\x3e\xcb\x30\xda\x87\x9a\xb9\xdd\x8f\xbe\x2e\xb3\xdb\xbe\x19\x67\x33\xbe\xa9\x16\xe9\x0d\xaf\xf6\xfa\x61\xd8\xdf\xa3\x48\x53\x50\x9c\x93\xf0\x9b\x1e\xa5\x93\x8a\x9d\x4e\x8a\xe6\xe1\x87\xbf\xf2\xfc\x21\xb9\x7a\xf0\xee\x1f\x0f\xef\xfc\x0a\x7f\xf2\x8d\x2a\xba\xce\x86\x60\xd5\x2c\xba\xcd\xa8\xa1\x81\xbf\xd3\xe1\xc3\x5b\x82\xbd\x3c\xcd\x3d\x3b\xfa\xab\x00\x44\x6f\x06\x3a\xd5\x33\xb3\xbb\x01\x87\x39\x4f\xd0\x35\xd1\xf7\x87\x0d\xdd\x31\x35\xd7\x8c\xb5\x08\xec\x4e\xcc\x12\x4a\xee\x64\x1a\xed\xb6\x56\xd7\x91\xd8\x58\x0b\x0b\x36\x17\x63\xdd\x5c\xe4\x89\x90\xb2\xaa\xad\x50\xaf\x8f\x13\xc3\x6b\xd9\x42\x28\x65\x6a\x0e\x8c\x04\x1d\x12\x2a\x4e\x1e\xde\x7a\x59\x67\x71\xd5\xa2\xb3\x20\x16\x46\x26\x29\x81\x2e\x9e\x07\xcc\x43\x09\xf3\x20\x8e\x05\x98\x11\x31\x5b\x10\xa2\x74\x33\x8e\x6b\x27\xcf\x3a\x1d\xae\x06\xc3\x43\x54\xd7\x67\xce\x5e\xbc\x74\xf2\xd9\x33\xcf\xe5\x9f\x76\xd3\xe7\x69\xcf\xb4\x6b\xbe\x79\x44\xd8\x99\x48\x8d\x91\x72\xbf\xd2\xf3\x76\xd2\x2f\x7f\x76\xef\xbb\x4f\x0f\x5e\xb9\x85\xea\x3c\xd3\x7c\xf3\x40\x67\xcf\xdb\x29\xd0\xd9\x33\x8b\x01\x46\x81\xbf\x23\xf8\x88\x84\x21\x5d\xe1\x62\x00\x7f\x3a\x1a\xb5\x0b\x75\x3b\x1d\x98\xfd\x11\x9d\x7e\xde\xe2\xc1\x3b\x1f\xdc\xbf\x75\xeb\xe0\xfd\x6f\x0f\x6f\xfc\xb9\x36\xd3\xa6\xb3\x5b\x78\xde\xe6\xe4\x4a\x56\xc8\xbd\xdb\x37\xeb\xec\x1f\x85\x51\x8d\x28\x66\x7c\x45\x07\x92\x90\xc4\xaf\xf3\x9c\xfe\xc6\x07\x92\x9e\x97\x2f\x2f\x94\x10\xef\x92\xc1\x4b\x25\x90\xbb\x0e\x25\x7e\x44\xda\x79\x68\xb9\x3d\xf7\xfe\xb7\xff\x32\x7f\xf5\xdf\x0e\xde\xbf\x35\x7f\xfb\xf3\xc3\x2f\x3f\x9f\xbf\xfd\xda\xc1\x27\x77\xe7\x77\xdf\xaa\x21\xe5\x32\xb8\xf3\xcf\xdb\xb9\x01\xc5\x19\x74\x91\x61\x47\xc1\xa0\x3f\xa2\xfa\x48\xbe\x74\x9d\x9f\xf0\x17\xad\x15\x1c\xb1\x38\x7f\x70\x55\x51\xee\x46\x68\x3b\x12\xb9\xe8\xd4\xbe\xfe\xf2\xc1\x47\xaf\x27\x53\x3b\x7f\xf9\xae\x59\x50\x9a\xae\x31\xd4\xef\xf7\xcb\xb0\x2b\x1e\xf1\xeb\x9b\xa8\xea\xea\xca\x8b\x7a\x93\x7e\x14\x91\x61\x37\xfd\xa5\x26\x09\x55\x64\x47\xc4\xa7\x38\xf3\x1f\xea\x4a\x71\x40\xcf\x5c\x20\x3a\xe0\x5f\x55\x15\x98\xda\x4f\x1e\x7c\x70\x63\x7e\xf3\x6d\x3e\xbb\xea\xfb\x34\x32\xea\x45\xd3\xcb\x4c\x48\x4b\xf7\x6a\x30\xac\x43\x79\xa5\x9d\x72\x0d\xe6\x7e\x4d\x18\x20\x9c\x78\xd7\x8b\xdc\xbc\xec\x2f\xed\x8f\xc3\xab\x92\x72\x51\x3d\x2b\xa0\xa4\x01\x31\x9e\x71\x9e\x32\xe9\x1d\x0a\x55\xc5\x8d\x26\x23\x6f\x40\x5a\x09\x12\x6d\x47\xb7\x9b\x4a\x67\x68\x18\x92\x1e\x75\x8f\x7e\xcc\x04\xc7\x82\xb2\x49\xc7\x6e\xfa\x41\xdc\xc4\xe8\x5a\xc5\xa6\xbc\xa5\x81\xd1\xb1\xda\xa2\x3c\xad\x0a\x64\x37\xd7\xb6\x0c\x03\x55\x53\xbb\xf2\x0d\x63\x9f\xbc\xb4\xe2\xfc\x62\x45\x59\x6b\x26\x94\x31\xac\xee\x96\x4a\x1d\x26\x13\x85\xac\xfc\xe8\x75\xba\x9d\xfc\xcf\xdd\x37\x0f\xde\x7c\xfd\xc1\x6f\xfe\x70\xef\xf6\xf5\xfb\xbf\xfc\xe6\xf0\xef\xbf\x9e\xff\xe3\x1d\x03\x7a\xa5\x3d\x61\x83\xef\x13\x67\x62\x32\x8e\x5a\x0a\x12\xe0\x45\x13\x5b\xfb\x9f\x7d\x3c\x7f\xe3\x13\xfd\xda\x07\xbb\xa1\x62\xed\xa7\x44\x68\x23\x96\xbd\x60\xb9\x3c\x0f\xea\xa8\x37\xbf\xf1\xe1\xbd\xdb\xbf\x4f\x18\xa8\xa2\x9a\x56\x1c\x6c\xb2\x63\x58\x9b\x9d\xad\xb6\x5c\xa6\x3e\x9c\xdb\x66\x37\xcf\x2b\x70\xe4\x5a\x35\x5a\x3f\x55\x8b\x96\x29\xc6\x5a\xbb\xc3\xc9\xf3\xe7\x7b\x2f\x9e\xbe\x70\xf1\xcc\xb9\xb3\xdc\xc8\xb0\xee\xae\xb9\xeb\x39\x0b\xc4\x8c\x84\x60\x80\xc0\x89\x18\xab\x75\x5e\x5e\xe3\x94\x06\x86\x35\xfe\x7f\x8f\xa6\x1e\xa7\xc4\xfe\x9d\xc0\x82\x43\x86\xdb\x8f\x72\xf0\x67\x0c\xab\xb7\x1c\xe1\x51\x93\x1f\xb1\x33\xbf\x46\x6f\xa8\x21\xc7\xcc\x07\xbb\x23\x10\x5e\x4a\x41\xc1\xe4\xd9\x3b\x5f\xcf\xdf\x7a\x9f\xa2\x39\xff\xcd\x9b\x41\xa8\xac\x34\xd8\xed\xfb\x3b\xa4\x07\x24\x91\x04\x05\x88\xe0\x74\xd0\x25\x0b\x0a\x79\x85\xb0\x29\x30\xad\x90\x4e\x87\x2d\x09\xaa\x0f\x39\xcd\x20\x6c\xda\xad\x26\x10\x25\x0b\x73\x33\x0c\xcb\x86\x07\x38\x2c\xc7\x07\x0c\x6b\xb3\xd8\x43\xe2\x93\xda\x27\x05\x69\xaf\x53\x70\x0f\x2c\x0e\xaa\xd6\xf3\x65\xda\x76\x84\x5d\xbf\x9a\xd8\x4c\x5b\x82\x1e\x2f\x90\x9d\xe9\xa8\x1f\xb6\x44\x4b\xdc\xfd\x79\xe5\x9a\xfc\x49\x44\x62\x49\xb3\xd0\xae\x4e\x14\x43\x60\x47\xd2\x0e\xf4\xfd\x56\x43\x32\x45\xc4\xa0\x85\x70\x43\x8a\xc4\x1c\x26\x69\x92\x1a\xf6\xf5\x12\x84\xc1\x2f\xdf\x8a\xa0\x6f\xc2\xb2\x9b\x03\xc3\xad\x0f\x8a\x17\x72\xbd\x1d\xcd\x3a\x4f\xb7\xf6\xa3\xbe\x48\x31\xed\x5a\x2a\x23\xeb\xe6\xea\xba\xce\xb6\x98\x4d\x13\x3f\xf8\x27\x8a\xfe\xea\x7a\x7b\xad\x9d\x0e\x8d\x1a\xd8\xce\x86\xaa\x91\x75\x70\x74\xd6\x9f\x9b\x49\x4c\xca\x8c\xcb\x55\xe6\x0a\x30\x17\x99\x5e\x41\x12\xdc\xb1\xa7\x30\x07\x52\x5b\x63\x53\xc5\x34\xa0\xda\x2d\x4d\x92\xe4\x91\x28\xce\x27\x02\xa4\xca\x49\xbd\x77\xfb\x8d\xc3\x7f\xbf\xa3\x99\xd4\x71\x30\x23\xbd\xe9\xa4\x6a\x56\xb5\xa2\x06\x76\xb1\x67\x9c\x35\x93\x5c\x99\x4e\x2e\x90\x41\x10\x0e\x5b\xe6\x29\x67\x06\x18\x83\xe9\x20\xdf\x9d\xda\xf0\x6a\xdb\x8b\xc4\x01\x35\x41\x40\x0f\xae\x16\x78\x9a\x09\xfc\x35\x62\x02\x87\xc1\x15\xbf\xf6\x14\x3e\x8d\x59\x8b\x23\xe2\xef\xc4\xbb\xce\xaa\xd9\xd1\x06\x60\x59\xea\x84\x17\x3b\xb4\x9c\xac\x62\x73\xbb\xe9\xaa\xec\xc1\x92\xe7\x2c\xa7\x3d\x1b\x82\x6b\x3b\x5c\x11\xd5\xa8\x9a\x59\x03\x3c\x03\x94\x5c\x3f\xb1\xaa\x75\x4d\x35\x04\xe3\x71\x94\xaa\xba\xa9\xdb\x2f\xde\x49\x14\x25\x42\x9d\x75\x83\xa6\x5b\xd5\x5b\x22\xe1\xe1\xd2\x05\xd9\x5a\xe2\x54\x46\xb8\x92\x4b\x33\xe6\xbe\x14\x6d\xb7\x44\xa3\x6f\xe6\x78\x1d\xfe\xec\xb9\xb8\xf9\x70\xc7\xd3\xd8\x63\xd1\x33\xd9\xf9\x0e\xa6\x9b\x57\xae\x6d\x26\xcd\x2c\x46\xf5\xb5\xd9\x94\x91\x4a\x1e\xb4\x75\x58\xa9\xc6\xe4\x2f\x97\x11\xab\x58\xc9\xd2\x3f\xb8\x36\x33\x2d\x7b\xf6\xad\xc8\x64\x66\x58\x23\x33\x21\x0e\xae\x0a\x12\x67\xc0\xe9\x87\x5f\xde\x84\xd7\x63\x3c\xcb\xdd\x45\xb8\x40\x58\x6d\x30\xb9\x36\x16\xc7\x56\x7e\xe1\x1e\xeb\xf7\x98\x82\x4f\xc6\x43\xd8\x5a\x72\x60\xe1\x45\x42\xc9\xd9\xa0\xee\x7e\x20\x9d\xf7\xd6\xdb\xe9\xa6\x50\x80\x6a\x99\x6b\x59\x01\xf9\xf7\x53\x92\x3f\x7a\x33\xa7\x84\xeb\x87\x32\x77\x08\xc1\x59\x8b\xb4\x5a\xb2\xe6\x69\xb9\x34\xb9\x97\xb1\x1f\xbf\x00\x38\xf8\xed\x57\xdc\x8d\x42\x27\xfc\x82\x20\xe2\x6d\x7e\xb4\x42\x4f\x3d\x4b\xb0\x9d\xd5\x13\x5c\x69\xc7\xb5\x18\xe7\xfb\xb3\x74\x7e\xa8\x62\x2f\x9d\xf9\x1f\xb1\xe0\xcb\xa2\x88\x9d\x02\x01\x6c\x23\x87\x0d\x84\x2e\x84\x9e\xd8\x11\xfb\x28\x65\xf7\x92\x94\x54\x28\xe9\x42\x41\xd9\xa0\x9e\xd8\x30\xdb\x1c\x33\xaf\x7b\x73\xbf\x16\x31\xfb\x76\xa6\x12\x74\xbc\xec\x22\x02\x5e\xee\xa3\xae\xe1\x24\x29\xe5\x63\x50\x3d\x93\x45\xa9\x4f\xed\xd1\x08\x7f\x12\xae\xea\xd3\x4e\xb2\x41\xb1\x0e\x1d\x40\xa2\x91\xd2\xca\xc3\x6c\x82\x26\x7c\x96\x79\xd8\x66\x58\x1b\x6b\x2d\x65\xe7\x4a\xca\x62\x26\x98\xa4\x3c\x9a\x0c\x94\xf6\xa2\x5e\x2e\x35\xc3\xb6\x1f\xb3\x65\x45\x0d\x1b\xba\xc8\x5b\xb3\x34\x03\x75\x33\x7a\x54\x5e\x11\xaa\xb6\x67\x99\xc7\x97\x85\x7c\x0a\xc4\x12\x17\xc1\xe2\x0b\x60\x31\xed\x52\x8f\xb5\xad\x07\x6d\xf5\xed\xd9\x77\xbf\x9b\xdf\x7c\x5f\x73\x7b\x16\xf5\x67\x12\xe7\x22\x4f\x78\x8b\x6c\xd7\x05\xc5\x44\xe2\xab\x3a\xba\x62\x61\x83\x56\xbc\x5e\xd4\x6e\xba\xd8\x4e\x70\x64\xd8\x72\xf9\xaa\xc2\x77\xa9\x8c\x05\x85\x85\xf4\x03\x9b\x30\x6d\x0f\x82\xa1\xaa\x49\xca\x42\x34\xd3\x68\xe4\x41\x30\x24\x1a\x17\xb7\x47\x5f\xeb\xb5\x50\x72\x93\x6d\x21\x3f\x1b\x0b\x1f\x4f\x0c\x36\x13\x3a\x6a\x79\xd8\x92\xc0\xce\xee\xae\x0d\x00\xe5\xa7\xd9\x29\xc5\xfd\x2b\x40\x5a\xd4\x65\x4e\x27\x4b\x9c\x5f\xfc\xc2\x59\x4c\x0b\x43\x21\x24\xd3\x6e\xcd\x28\x30\xb0\x3b\x71\x69\x68\xc5\x58\x8b\xb0\x47\xba\xcc\x0a\x14\xd2\xcd\x87\xba\x54\x36\xd0\x2f\xf3\xb1\x18\x5b\x2f\x0d\xbc\x98\x24\x15\x93\xcc\x77\x7a\x11\x02\xa5\x94\x4b\xaf\xd9\x46\x50\x3c\x4d\xa5\x77\xfe\x85\x4b\x4d\x73\xe4\xd9\xe5\x60\xb8\xd7\x2d\x32\x04\x4e\x2d\x6a\xbc\x44\xf6\x1a\xec\x57\xd7\x61\x18\xba\x90\xcd\x0f\xd9\xb4\x21\x7e\xa5\x4d\x91\x0d\x67\x52\xc3\x3c\x73\x61\x3b\x60\xfc\xd1\x60\x1d\xf0\xdf\xc8\x86\x90\x98\x92\x61\x9c\x80\x2c\x1e\x20\x9b\x0f\x49\x94\x27\x16\x7d\x80\x6c\x1a\xc5\xfd\x78\x1a\x35\xb2\xa6\xfc\x81\x59\xbd\x42\x44\x1e\x56\xa7\x5a\xd4\xaf\xca\x62\xda\x43\x48\x98\x18\xf8\x91\xd6\x81\x39\x29\x22\x92\x3a\x69\x72\xb4\x79\x16\x41\xb4\x72\x85\x9d\x11\x0d\x44\x67\x1c\xea\x1d\xad\x73\xb0\x42\x4e\xce\xd0\xdd\x09\x5a\xd7\xa8\xce\x11\xef\x52\x22\x41\x8a\xd2\x24\xbb\x25\xa4\xb6\x9c\x8d\xdd\x7c\xaa\x4c\xaa\x9b\xf4\xc7\x11\xe3\x0f\x9f\x12\x94\x56\x69\x1a\x2b\xa2\x19\x49\x2e\x2c\x27\x27\x38\xbb\x6e\x87\xc1\xb8\x47\xbb\x81\xf0\xca\x1d\xd2\x2d\x8f\xc4\x6a\xba\xf9\x6a\xfb\x3a\x8f\xd6\xa4\x18\x54\x74\x3b\x4f\xc4\x51\xb0\xe3\x0d\x58\x30\x45\x2f\xf5\xce\xa5\x9b\xe8\x94\xc5\x73\xa6\x33\x3b\x36\x69\xdd\x49\xa4\xbb\xfb\xfc\xe9\x93\xcf\xf6\x9e\x7d\xe1\xec\xa9\xde\xa5\x33\x97\x9e\x3f\x7d\x71\x73\x5c\xc8\x5d\xb1\x05\x0a\xd3\xd4\xa7\xfa\x82\xe7\x93\xa1\x69\xce\x05\x5f\xea\x7c\x9e\x2b\xdf\x88\xb6\x63\x55\xde\x08\xa1\x7a\xa0\x49\x95\x1f\x65\x3f\x97\x88\xf6\xc4\xb1\xfd\x13\xc7\x8e\xb1\x54\xbc\x94\xff\xe6\x7f\x7a\x17\x62\xd6\xb3\x34\xbc\xf4\xd9\x52\x53\xf0\x8e\xbc\x28\x7e\x9c\x7d\x77\x81\xec\xbb\x62\x6d\x97\xd2\xe9\xe6\x97\x7e\x35\xc7\x55\xe5\xd5\x8d\x98\x44\x49\x24\x87\x81\xb1\xd3\xf8\x6c\x9e\x5f\x57\x5b\xb7\xc6\x86\xa0\x94\xcd\xa8\xb4\xbb\xd6\x29\x77\x6b\x6d\x03\xec\xec\xb6\x1b\x08\x6f\xfa\x44\x63\x2c\x24\x0f\xca\xdd\x0b\xb3\xa4\x0c\xdd\x26\xd7\x88\x9a\xff\xfd\xf2\x2b\x4d\xaa\x89\xb3\x7f\x99\xd6\x0d\xbf\xd6\x90\x67\xb0\x34\xab\xaf\x65\x52\xe0\x99\x9c\x11\xd8\x78\x88\x4a\x1a\x73\x1c\xfa\x22\x01\x1a\xe4\x48\xda\x21\xb1\x84\x77\x92\x70\x77\xe6\xce\xda\x05\x92\xb4\xb3\x57\xec\x6f\xc4\xe6\x50\x26\x6a\x61\x7c\x66\x04\xd4\xe8\xc6\x49\x91\x11\x75\x67\x8a\x7e\x10\x57\x28\xfa\x9d\x0a\x9d\xbd\x25\x59\xb0\x72\xe9\x3a\xe8\x2d\xba\x90\xbe\x41\x34\x67\x5e\xca\x88\x09\x64\x46\x3d\x88\xeb\x95\xda\xc2\x0d\x60\xbe\xe4\x92\x1f\xf3\x50\xb4\xf9\x5b\xb7\x0e\xef\xbe\x77\xff\xdb\x7f\x36\x0e\x42\xb1\x90\xd3\x87\x88\x41\xca\x39\x44\x3a\x1d\x5a\x13\x99\x34\x44\xb6\xfd\xe9\xfb\x94\x7c\xcd\xac\xfa\xce\x8c\xc9\x86\xbe\xef\xdd\xfe\xa7\x2c\x1b\xf4\xbd\xdb\xd7\x0f\xbf\xfc\xdc\xac\x22\xfa\x93\x69\x8c\x02\x9f\xd0\xad\x9d\xa5\x9a\x11\x09\xf0\x59\x1b\x64\xff\x79\x22\x69\x50\xe0\x13\x89\x24\x8f\x64\x7b\x85\xd5\xa8\x81\x7d\xe8\x31\x89\xd9\x0f\xf7\x78\x5a\x1d\xa1\x3b\x98\x06\x50\xa5\x1b\x5a\x2c\xd9\x50\x4c\xa2\x84\x1a\x29\xaf\xab\xd9\x9c\x53\x82\x13\x45\xe4\xcb\xb1\x96\x49\xbc\xf7\xa2\xac\x4b\x89\xed\xc0\x9f\xe6\x53\x12\x73\x7e\x84\xcd\xa9\x17\x7b\x63\x9e\xeb\xa5\xeb\xac\x21\xd2\xe0\x24\x42\x95\x8d\x25\x8a\x66\xee\xcd\xc7\xfb\x60\x48\x4f\xe8\xbd\x81\x9c\xac\x49\xdd\xdf\xc1\xbf\xfe\xe7\xc1\x3b\x5f\x3f\x78\xf5\xfa\xe1\x37\x7f\xa0\xd4\x9b\xbf\xfa\xc5\x83\x5f\x7e\x01\x0d\xb0\x63\xe4\x24\x85\x79\x8c\x83\xdf\x7e\x85\x97\x18\xaa\x9c\x39\x72\xc6\x9c\xe4\x99\x79\xba\x49\x38\x23\x61\x7e\xf1\x72\x58\x9d\x22\xac\x1f\x5d\x9f\xbf\xf1\xe9\xfc\x83\x2f\xe6\xff\xf0\x2a\xa5\x04\x12\x50\xfd\x96\xa3\x53\x54\xd4\xda\x7e\x59\xd9\x2f\xf3\x10\x57\x36\xd5\x5b\x15\x4c\x7c\x71\x7f\x12\x73\x53\x5d\x3f\xc7\x7d\xd8\xfa\xf2\xf2\x31\xd4\x1f\xe6\x67\x52\x57\x9f\xe9\xbd\x84\x1e\xa9\x7b\xd9\x46\xcb\xeb\x63\x28\x63\x48\x34\x24\x16\x91\x7e\x33\x04\x19\x6e\x1b\x23\x23\x59\x17\xb9\xc8\xda\x00\x6f\xf4\xea\xd9\x97\x0d\x7d\x25\x5f\x11\x37\xf5\x2d\x77\x67\x56\xc7\xe7\x64\xdf\xe7\xc8\xc9\x31\xe8\x9a\x8f\x1f\xc8\xe1\x89\x05\xff\x45\x5c\x60\x50\x8a\x43\xce\x84\x9b\x47\x44\x7f\xbd\x51\xea\xc4\x9d\x15\x4c\xdc\x4f\x3e\x99\x77\x5b\x29\x56\x5f\xd9\xd8\x60\x39\xc3\x70\x51\xee\xe5\xfd\x76\x23\x13\x2d\xae\x7c\xb2\xe5\x75\xfc\x20\xee\x45\xde\x8e\x8f\xc8\xf0\xcc\xfa\x2f\x6f\x60\x06\x76\x90\x6e\xd1\xb4\xb4\xe0\x26\xfd\x8c\x1a\xa9\xff\xc0\x0f\x93\x1e\x35\x41\x47\x7a\xa6\xa9\xa0\x5a\xb5\xb6\xed\x54\x2f\xc5\x57\x3e\x3a\xb8\xf9\x99\x46\xce\x0c\xfa\xfe\x80\x8c\x7a\xc1\x84\x84\xcc\xf2\x60\x13\x90\xa9\x90\x1f\x15\x02\x04\xc5\x00\x79\xc9\xb5\x44\x22\x48\x0a\x9a\xb2\x86\xd0\x24\x2c\xa5\xad\xed\xc5\x0a\x9c\x17\xc7\x68\x89\x94\x1e\x93\x81\x4b\xc1\x9a\x4e\x85\xb0\x76\x32\x28\x01\xa3\xb8\x1f\xc6\x7f\xe7\xc5\x22\x6b\x6a\xdb\x69\x5c\x6b\xac\xc0\xc5\x1c\x7d\x47\xfc\x61\xee\xcd\x7e\xfa\xa6\xdc\x6a\xb3\xb2\xd5\x56\xc3\xbc\x96\x51\x1f\xf1\x91\x3e\xae\x83\xf5\x39\xa2\xc4\x2b\x29\xb8\x1b\xce\x53\x27\xe0\xd0\x10\x4c\x2f\x8f\x08\xdf\x44\x30\xfd\x94\x8e\x23\x38\x5f\x6e\xe9\xf3\x01\xb5\xa0\x3d\xbe\xa1\xb3\xda\xea\xe1\x53\x4b\x42\x3e\x44\x56\xfd\x48\xdd\x88\xd5\x13\xf0\x53\x98\x00\x3f\x70\x7c\x42\x86\x90\x5e\x47\x3f\x09\xfb\x7a\x26\x56\x0d\xf1\xb4\x39\xcd\x1f\x37\x9b\xd4\x31\x88\xab\x71\xd2\xe4\x00\x51\xcc\x8e\x89\x79\xec\x6f\x48\x2d\x6e\x47\x6b\xde\x8c\x36\xc4\x1d\xa1\xcd\x85\x28\x5c\x87\x76\x2d\xee\x41\xe1\x16\x54\xd4\x9f\x19\xea\xa7\xf7\x9d\x49\x7d\xc4\x95\xa7\x7c\xd5\x69\x77\xd3\xc9\x6f\x39\xbb\xd8\x0b\xce\xf4\x66\xb3\x8b\xbe\xd7\x34\xdd\x69\x2e\xc3\x7c\x8d\xbd\xcb\xb4\xbd\xc7\xac\x65\xbc\x5e\xe0\xfe\x92\xae\xa8\x92\x69\x0b\xeb\x9a\x47\xdb\x96\xcc\x91\x7a\xd5\x41\x6a\x59\x52\xbd\xcc\x2d\xd1\x1e\x25\x6c\x47\x66\x9c\x9c\x78\xf4\x3c\xe3\xfc\x85\xd1\x27\x4a\x02\x4e\x26\x89\x5e\x0f\x96\x6a\x22\x2e\x4a\x6d\x9c\x53\x4b\x60\x1c\xb1\xbf\xa4\x6d\xc2\x98\x83\xf7\xfe\x38\xff\xec\x63\x6e\xd7\x51\x56\x49\x3c\x26\xed\x92\x57\x21\x94\xba\x9c\xf6\x9b\xe9\xbe\xe8\x3b\x5c\x49\xdf\x45\x4d\x71\xc4\x4e\x3b\x86\x90\x15\x2b\x17\xae\xa2\x05\x45\x7d\xc8\x2d\x54\x65\x7e\xc9\xb8\x23\x5d\x0d\x2c\x2d\x0e\x74\x45\x87\x4f\x15\x36\xea\x63\xea\x23\x8e\x91\x19\x84\x7a\xfe\x56\x50\x32\xad\xe2\xdc\x45\x8c\xd3\x15\x94\x05\x1c\xaf\xa0\xc8\x1e\x54\x19\xe5\xad\xbc\x48\x64\x5f\xaa\xc2\xe4\xd9\x74\x92\xf9\x53\xa9\x18\xc6\xa2\x27\x49\xeb\x60\x3d\x59\xbb\xc5\xc8\x3e\x56\x19\x2c\xf4\x21\xaa\x07\x8c\xb7\x14\x94\x9a\x1e\x53\x7c\x88\x05\xbc\xa6\xa0\xd4\xf1\x9c\x52\x0e\x8c\xbd\x90\x86\xb2\xa0\x07\x15\x94\x88\xc4\x97\xe8\x09\x20\x98\xc6\xad\xc6\x15\xaa\x12\x04\x57\x58\xe4\x08\x73\x86\x08\x09\x15\x1f\xc3\xd6\x4a\xa3\xed\xfc\x74\x6d\xcd\x2e\x44\xa6\x74\x3c\x67\x70\x82\xc7\x35\x3d\x52\x5f\xee\x0f\x7b\x93\x80\x6a\x22\xf0\xd0\x7c\xbc\x96\xcb\x8c\xa9\xee\x06\x49\x04\x35\x50\xfb\x87\xa2\xf7\xd2\xad\x66\x8d\xb0\x35\x23\xf2\x21\xf9\xf9\x94\x6a\x62\x76\xa8\xab\x7b\x1d\x47\x3b\x6d\x96\x61\x11\x1c\x8c\xc0\x6a\xbf\xdb\x8f\x1c\x72\x95\x92\x81\x50\xb9\xd7\xe0\xce\x6d\x89\x08\xb2\xfe\xfa\xd9\xa2\x04\x91\xfa\x31\x4f\x1b\x85\xbc\xc7\x20\xd7\x3b\xde\x14\x8b\x96\xe8\x39\xf2\xbc\xc8\x94\x16\xca\x16\x0e\x0f\x05\x29\x1a\x83\x74\x2d\x84\x53\x77\x0d\x02\xca\x88\xd7\xa2\x9c\x62\x06\x74\x3b\xb0\x6d\x78\x17\xa3\xe1\xd2\xd8\x1b\x16\xdd\x84\x84\x63\x2f\xa6\x0c\x88\xa7\x17\xa6\x6b\xf9\x46\x71\x21\xa9\xf1\x70\x85\x82\xe8\x23\x77\x1f\x5a\x35\x83\x72\x25\x2b\x91\xf3\xf0\x68\x51\xc0\x63\x19\xb4\x5c\x26\x37\x23\x82\x15\xeb\xc7\x5c\x59\x5e\x44\xdc\xf8\x90\x87\xb6\x69\x8e\x6b\x99\xd7\x40\x29\xc4\x0d\x73\xf3\xa9\x70\x3a\xd8\x70\x06\xa3\xc0\x27\x2d\xc5\xa5\x84\x75\x76\x52\x16\x9e\x27\x9c\x08\x98\x43\x41\xd5\x91\xb3\xdf\xe3\x90\x58\x1c\x39\x33\x75\x14\x1f\xa1\x51\x46\xd7\x70\x9b\x30\x76\x9c\xf4\x60\xab\x3f\xd9\x8a\x80\x54\x6e\xc0\xe0\xc1\xa8\xc1\x65\x70\xcd\x35\x06\xf2\xc8\xe7\xe1\xe2\xb1\x41\x74\xb7\xe0\x09\x36\x37\x42\x72\x33\x52\xd9\x63\x35\x9e\xcc\x8c\x09\xeb\xcd\xa5\x00\x8e\x39\x70\x2f\x69\x80\x7b\x74\xad\xb8\x50\x6c\x2c\xb9\xf2\xa1\x0c\x6b\xcd\x95\x4f\x61\x36\x07\x30\xf9\xe0\x85\xb6\xec\xb2\x86\x0b\x5a\x77\x25\xf4\x50\x21\x2c\x72\xf8\x4a\x16\x81\x9e\x99\x7b\x0d\xb1\x9a\x8f\xed\xbd\xe8\xd3\xd6\xd2\x4e\x59\x47\x74\xba\xa2\xdb\x72\xc9\xb5\x6c\x29\x87\xab\x23\x39\x10\xa9\xa1\x15\xd2\xed\x78\x05\xd8\xfc\x60\x81\x49\x3c\xb4\x7c\x25\xf7\x68\x94\x5b\x35\x19\xd0\x97\x08\x4b\xd6\x47\x75\x36\x6e\x3b\xad\x83\xef\x06\x0f\xde\xfd\x6e\xfe\x67\xb5\x87\x30\xff\x06\x5d\x57\xf7\x29\xba\x4e\xe7\xfe\x37\x5f\x1e\x5c\xff\xec\xe0\xb5\xb7\xbd\x8a\xcf\xf8\xb0\xaf\xd4\x69\x3e\x56\x07\x5f\xc7\x3b\x7d\xd5\x8b\x4f\xb1\x0f\xcd\x5c\x08\xae\x74\x8b\x0f\x2a\xfc\xee\x92\xd7\x67\xa7\xe3\x6e\xee\xaf\x52\xf5\x62\x38\xd1\x31\xe9\xe7\x7e\x16\x4b\xe4\x50\xf5\x25\x0e\xc2\x3d\x16\x4d\xc4\x47\xff\x6b\xfe\x64\xf1\x88\x22\x31\xd9\x62\x84\xc7\x01\x45\x0b\x04\x14\x41\x10\xa2\x5e\x1d\x3d\x75\xee\x85\xb3\x97\x7a\xe7\x4f\x5f\xe8\x9d\x3f\xf9\xdc\x69\xc3\x55\x8b\x22\x4a\x50\x4c\x16\x58\x79\xe4\xe8\x39\x16\x87\x6e\xac\x0e\x82\xc1\x24\x56\x00\x05\x60\x90\x4c\xe3\xeb\x08\xce\x60\x41\x4d\xfa\x41\x96\x70\x4d\x93\x93\x8e\xc5\xb0\xac\x78\xb7\x4d\x21\x9b\x52\x91\x24\x60\x13\xa0\x35\x51\xd2\x32\x4f\x7b\x8a\xe4\xfa\x9a\xc6\xe5\x41\x43\x10\x00\xa0\x9a\x28\x22\x7a\x0c\x2a\x31\xc2\x27\x11\xa6\xca\x5a\x10\xeb\x99\xd6\xc0\x32\x07\x9a\xa0\x4a\xac\xff\xaa\x16\xce\x54\x11\x35\xa2\x9c\x78\x0d\x1f\x11\xc6\x35\xce\x3b\x39\x09\xc6\xf9\x07\x79\x7a\x79\x38\xc1\x78\x8f\x94\x76\xcb\xd2\xa2\x9a\x33\xa8\x24\x55\x61\x22\x45\x80\xb0\xd6\x77\x08\x0a\xac\xec\x56\x6a\x89\x70\x3c\x9f\xae\x71\x7f\x00\xc7\x6d\x16\xe5\xb7\x62\xa3\x49\xcb\x19\x2b\xd2\x2e\x97\xb4\x58\x94\x7d\xbb\xa0\x07\x99\x17\x4d\x7a\x38\x60\xff\x8b\x83\xb8\x3f\x12\xe4\xf9\x7f\xa5\x45\x88\xc8\x14\x93\x94\x1c\x99\xd5\xfd\x77\xac\xd6\x91\x15\x41\x4a\x10\xfc\x2d\x5d\x44\xee\xf6\x28\x08\xc2\x0a\x64\x8b\xc0\xac\xd0\x65\x8e\xf0\x4c\xa9\xeb\x3e\x01\x65\x11\x37\x1c\xd0\x8d\xc0\xbe\xc8\x3f\x4b\x5d\x8c\x3c\xed\x74\xd8\x6f\x87\x32\xea\x6c\xd4\xc7\x46\x24\x89\xd8\x62\x33\xd2\xf9\x48\x54\xc6\x78\x68\x67\x99\x72\x34\x2a\x6b\x8f\x8a\x46\x85\x22\x3b\xbc\x83\xe4\xb6\x8a\xc6\x97\x0b\xce\xf5\x55\x2e\xf2\xb0\x6e\x7f\x7a\x35\x97\xfa\x86\xbb\xc7\xca\xf8\xe4\xaa\x59\x24\xc7\xb3\x73\x5d\x95\xcb\x92\x2f\x4d\xa0\x00\x85\x37\x72\x98\x2f\xcb\x98\x0d\x25\xf7\xf5\x39\x54\x88\x31\xab\xdd\x96\x79\xbf\xed\xe4\x5f\x62\x03\x8d\xa1\x14\x97\x50\x11\x10\x6c\xac\x31\x94\x3c\x14\x15\xfd\x09\xab\x31\x9a\x38\xc1\x68\x68\x41\x1c\x56\xbb\x8a\x38\xec\x65\x6d\xe2\x28\x00\xa9\x45\x1c\xd6\x4f\x45\x7f\x08\xa7\x68\x28\x66\xd6\x42\x07\x66\x43\x91\xa2\xab\xbb\x8e\x65\xe6\x1f\x69\x8b\xe9\x4a\x7f\xe0\x1a\xc3\xd2\x4a\x07\xa6\x7f\xe0\x5a\x49\xb3\xd2\x95\xfe\xc0\x35\x96\x02\x6f\xa5\x78\x5b\x54\x53\x29\xba\x52\x0a\xaa\x3c\xb2\xfd\x71\x91\x58\x47\x43\x44\x9f\x30\xc2\x38\x3a\x2b\x8c\x6c\x62\xb1\x37\x7e\xbc\x48\x77\xdc\xd4\x52\xe1\xac\xd2\x3d\x0b\x6e\xc9\x8e\xd1\xc7\x6e\xfa\xb8\xd5\xe4\x4f\x9b\xed\x45\x0d\x21\xbc\x9f\x1f\x89\x01\x64\x12\x06\x13\x3a\xb3\x9b\xcd\x71\x30\x24\xa3\xe6\x56\x55\xa4\xab\xf1\x32\xcf\x2c\x23\xd2\xc5\x82\x5a\x2a\xe9\xfa\x30\xad\x0e\x14\x0b\xc3\xf4\x4e\x99\x1f\x8c\x1a\x40\x2f\xea\x8d\x48\x7f\xbb\xf8\xb5\x79\xe4\x77\xa3\x93\x1b\x49\x32\x32\x7c\x38\x5a\x10\xa9\x94\x13\xa9\x9c\x07\xc9\xea\x7e\x76\x3b\x3c\x42\x98\x99\x16\x1b\xee\x44\xf9\x36\x69\xe2\x24\x7d\x43\x8a\xb0\xd9\x9f\x1d\x54\x50\x04\x49\x10\x87\x63\x3a\xd4\x26\xc0\xba\xb9\xb6\x05\x9f\x5b\xe7\x71\x7d\x81\xa0\x0e\x7c\x33\xf6\x9a\x23\xe2\xdc\x58\xad\xf5\x2d\x67\x5f\xa3\x26\x2b\xbe\x87\x0f\xf6\x7e\xe3\x35\xb0\x00\x44\x35\xbe\x00\x0e\x37\xe8\x26\xfb\xf2\x76\x9b\x7d\x00\x7c\xcb\x65\x5e\xfa\xe7\xb6\x19\x28\x2b\x40\x98\x55\xe3\x07\x1e\x05\x20\x86\x93\x00\x3b\x7d\xb0\xfb\x75\x76\x80\xa7\xff\x3c\xcd\xe0\x4c\x52\x62\x39\xde\xf1\xe3\xc8\x94\x71\xfc\x8b\xb6\x0c\x49\x63\x42\x5b\x28\xcc\xd2\x07\xb8\x48\xe3\x61\xbe\x5c\x59\x1a\xb2\x4c\xeb\xc5\xf3\xa6\xd5\xb7\x33\xd1\x7f\x2a\xd6\xaf\x71\xef\x93\x37\x80\x13\xc7\xe0\x3f\xc5\xfe\xa7\xda\xfd\x96\xb3\xf7\x3d\xde\xf9\xa4\x62\xda\x34\xa2\xe9\xe5\x53\x94\x5e\x11\x42\xf8\x2e\x71\xe2\x99\x21\xb5\x97\xe0\xa5\x62\x83\x7c\x8d\xe5\x30\x45\xbe\xcf\xc7\x2c\x22\xca\x23\xaf\x1c\x95\xe5\xd0\x23\xaf\x1d\x55\xa7\x8e\x2c\xeb\x04\x36\x5a\x12\x40\xcf\x13\x1a\x23\x71\x48\x58\x31\x85\xdf\x4a\x29\xdb\x25\xde\xce\x6e\xdc\xa3\xbb\x2b\xcb\xa6\x69\x41\x37\xde\x12\xa2\x8b\xc1\x66\xfa\x97\xce\xff\x17\x71\x3e\x05\xcd\x2b\xdb\x2f\x9f\xd2\x6c\x76\x02\x09\xde\x27\x7a\x57\x6a\x9b\xa4\xd3\xff\x06\x00\x00\xff\xff\x61\xbe\x75\xbc\x69\xaa\x00\x00")
+var _webJsComponentsConfigJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x3d\x6b\x8f\x1b\x47\x72\xdf\xf7\x57\xb4\x89\x8b\x49\x46\xb3\xc3\xa5\x0f\x41\x02\xd2\x6b\x40\x10\x64\x47\x07\x47\x12\x24\xd9\xf9\xb0\x58\x10\xd4\xb2\x97\x3b\x67\x72\x86\x37\x33\xa4\xb4\xd0\x11\xb0\x73\xe7\xc4\xf6\x59\xb1\x73\xb1\x7d\xb6\xe3\x33\x6c\x43\x71\x8c\x83\x75\xba\x43\x02\x47\x3e\xc9\xf6\x8f\xc9\x92\x2b\x7d\xca\x5f\xb8\x7e\xcc\xa3\x67\xa6\x67\xba\x7a\x38\x94\xa5\xb3\xf8\x41\xe2\x0e\xfb\x51\xaf\xae\xae\xaa\xee\xaa\x69\xb5\x4e\x39\xf6\xc0\xf2\x2d\xc7\x46\xb3\xfe\x68\x8a\xd1\xc6\xac\xef\xa2\x93\x93\x49\xf4\xdc\x43\xdb\x68\x7f\x6a\xef\xd1\xef\x0d\x17\x7b\xce\x68\x86\x0d\xe4\xe2\x9f\xe2\x3d\xbf\x89\xae\x6d\x20\xf2\xa1\x7d\x7c\x3c\x9e\x8c\xfa\x3e\xee\x4d\xdd\x11\xe9\x52\xdf\x73\xc6\x13\xc7\xc6\xb6\xef\xb5\xfa\x93\x49\x6f\xcf\xb1\xf7\xad\x21\xfd\x8f\x0f\x6b\x1e\xf8\xe3\x51\xbd\xcb\xba\xbb\xd8\x9f\xba\x36\xda\xc7\xfe\xde\x41\x43\x1c\xa7\xc9\x7e\xa6\x1f\xd3\x3f\xc0\x76\x23\x82\x23\x6a\x44\x00\x9a\x84\x50\x84\x9f\x60\xb8\x44\x1b\xd3\xc7\x57\xfd\x46\xb3\x1b\x35\x9c\x37\x73\x86\xcc\x8e\xc6\x50\x6e\x24\x9f\xd2\x4f\xd8\xa3\x13\x7d\x33\x32\x6d\x5c\x67\x4a\x1b\x64\x3b\xd3\xcf\xa0\xef\xf7\x3b\x31\x71\x7d\xb7\x6f\x7b\x8c\x38\xcd\x9c\x0e\x21\xad\x29\x15\x7b\xb4\x77\xb7\xb0\x59\x3c\x60\xcf\xb2\xf7\x1d\xc2\x95\xf8\x89\xe9\x3b\xe6\xcf\xa6\xd8\x3d\xcc\x1f\x22\xc1\x96\x7a\xcb\x99\xb4\x38\x13\x5b\x97\x0f\x5b\x75\x74\x22\x3d\xbc\x19\xb0\xf8\x25\x7c\x68\xa0\x6b\x68\x8c\xfd\x03\x67\xd0\x41\xf5\xe7\x4e\x5f\xaa\x1b\x68\xcf\xc5\x03\x22\x0c\x56\x7f\xe4\x91\x67\x5e\x7f\x8c\x37\x1d\xd7\x1a\x5a\x76\x9d\xb0\x22\x17\x04\x09\xeb\x29\xda\x52\xb6\x17\xa0\x10\xf5\x31\x7f\xea\x91\x21\x9a\xdd\xc2\x8e\x19\xd1\xa0\xdd\x21\xb3\x05\xac\xa1\x44\x08\xe8\x4d\x7b\x9a\xc5\x8c\x12\x3b\xb3\x25\xd8\xf3\x0f\x27\x58\xdd\xde\x77\x0f\x01\x20\xd1\x8f\xb5\x8f\x1a\x3f\xb9\x78\xee\xac\x39\xe9\xbb\x1e\x6e\x08\x10\x9a\xb3\xe6\x4e\x9d\x0a\xd3\x26\x9b\xd9\xab\xef\x36\x81\x63\xd2\x4f\x24\x85\x04\xd1\xdc\xf1\xbb\xe0\xe1\x5a\xad\xc5\x6b\x37\x96\xef\xdd\x8c\x89\x70\xfc\xc7\x3b\x8b\x8f\x7f\x05\x1e\x80\xe2\x49\xbb\x39\xfb\x8d\x08\xb4\x9d\xfa\x00\xef\xf7\xa7\x23\x9f\x63\x48\x11\xdc\xde\x46\x35\x7b\x3a\xbe\x8c\xdd\x9a\x0e\xb6\xe1\x0c\x96\x67\x5a\xb6\x8f\x87\xd8\x2d\x9c\xe5\xc9\x27\x91\xf0\x73\x82\xc6\x26\x9e\x91\x75\x47\x06\x3a\x63\xfb\x4d\x5d\x10\xb8\xa8\x84\x14\x22\x94\xaf\x11\x68\x6a\x5d\xed\x31\xf6\x1d\x97\xe0\x82\x2c\x3b\x17\xcc\x32\x90\x25\xc4\x22\x35\xde\x8e\xb5\x6b\xf2\x3d\x66\x1b\x31\x49\x21\xe8\x37\x94\x8d\x9b\xfa\xa8\xcd\xb5\x7a\xcc\x11\x1e\x79\x78\x65\x2e\xec\x8f\x9c\xfe\xc3\xc5\x87\x68\xa3\x08\x89\xae\xa4\x75\xb7\xd4\x3c\xb2\x45\xc1\x59\x57\x16\x72\x2d\x29\x7a\x96\x12\x5e\x9c\x95\xec\x4c\x35\x73\xab\x56\x0e\x99\xd2\xd2\xb0\x3a\xdc\x65\x01\x46\x6b\x5e\x20\x7a\x8a\xaf\x02\xad\x91\x1a\x52\x42\xb0\x1c\x9d\x5b\x96\xf1\x9a\x0a\x63\x63\xad\xc2\x94\x54\x2b\x9e\xef\x5a\xf6\x50\x03\x27\x18\x74\xea\x56\x73\xb4\xd7\x27\x76\x1f\x6a\x50\x83\x58\xdd\x9c\x6c\xde\xd7\xdf\x3d\xba\xfd\xbb\xe5\x6f\x3f\x3d\xba\xf3\xd5\xbd\xff\xfd\xef\x7b\xdf\x7c\xb9\x01\x91\xaa\xb4\x11\xd9\x1f\x0c\x62\x27\x81\xee\xd5\x6d\xa8\x44\x51\x7d\xe7\x11\x62\x8d\x30\x1b\x80\x93\x30\x61\xf3\xf2\x19\xd2\x6d\xba\xa5\x46\x0f\xd7\x72\x7a\x74\x0d\x45\x4a\x87\xb4\xf1\x95\x60\xa8\xf0\xb3\x8d\xb6\xba\x60\xb3\x2e\x83\xef\x76\x60\x10\xe8\x2c\x43\x19\x5a\xd1\x06\x9d\xf9\x51\x43\x4f\x95\xb5\x53\x82\x75\x93\x8b\x20\xdf\x6b\x75\x50\x4c\x0f\x15\xa0\x19\xd8\xad\x7c\x91\x59\xfb\x87\x8d\x02\xb4\xb5\x35\x5b\x2e\x59\xb9\x22\xcb\xce\xa0\xad\xbf\xe0\x9a\x48\x2a\x66\x66\xbb\x14\x2b\xa3\x01\xb4\x6c\x1e\x6d\x5d\xb8\xa2\x1e\x14\x30\x26\xdd\xa1\x54\x85\x51\x54\x74\x7c\xae\xa1\x9a\xb0\xcf\xd7\x3a\x68\x27\x78\xc2\x74\x02\xf9\x9b\xfc\xd9\x77\x87\xd3\x31\x8d\x84\x04\x3f\x7b\x87\xe3\xcb\xce\x88\xfc\x51\x7b\xfe\xe4\xd9\xe7\x6a\x68\x6e\xa0\x1a\xb6\x6b\xbb\xe4\x3f\xea\x73\xd2\x1f\x08\xbe\x4f\x6c\xf3\x5f\xd8\xc8\xe4\x59\x8c\xd1\x9c\xb6\x4c\xec\x7e\xe4\xe7\xac\xb8\xcd\xd5\x38\xcf\xa1\x3e\xed\xa0\x67\x0d\xae\xf6\xfa\xae\xdb\x3f\x24\x48\x13\x50\xd0\x49\xfa\x9d\xb8\xd2\xf1\x0e\x10\xa1\x79\xfc\xe1\x2f\x2d\x7b\x80\xaf\x2e\xdf\xfd\xc3\xf1\x9d\x5f\xc2\x3d\x5f\x2f\x67\xe8\x78\x0a\xd6\x4c\x63\xd8\x98\x1a\x05\xf0\xb7\x5a\x7c\x7a\x4d\xb0\xab\xb3\xdc\x63\xd7\x5f\x06\x20\x78\x33\x28\x32\x3d\xe3\xb8\x1b\x95\x30\xf4\x04\x59\x13\x7d\x7b\x50\x2b\x72\x53\x13\xdd\x58\x0f\x47\xcf\x63\x16\x50\x32\x27\x53\xef\xa0\xb1\xd9\x6e\xae\x49\x59\x30\x5e\x8c\x8b\x78\x91\x24\x42\x24\xaa\xcd\x52\xd6\x59\x19\x9c\xaa\x34\xce\x22\x25\x14\x09\x35\x07\x46\x80\xae\x09\xb5\x41\x28\x79\x78\xef\xaa\x7c\x71\xd9\xa2\xd3\x20\x16\x44\x27\x49\x81\x4e\xfb\x03\x5d\x68\x78\x10\x26\x02\x2c\x88\x18\x2f\x88\xe0\xd3\x89\x25\xce\x88\xf5\x14\x37\x83\xe9\x43\xd0\xd0\x67\xce\x5e\xbc\x74\xf2\xd9\x33\xcf\x25\x9f\x76\xa2\xe7\x86\xa0\x02\xf9\xe6\xe1\x41\x39\x11\x05\x23\xc5\x71\x85\xe7\x46\x38\x2e\x7f\x76\xf4\xdd\xa7\xcb\x57\x6e\x81\x06\x8f\x2d\xdf\x24\xd0\xf1\x73\x23\x02\x3a\x7e\xa6\x31\xc1\xc8\xb1\x87\x81\x1c\x61\xd7\x25\x2b\x3c\x98\xc0\x9e\x8e\x46\x46\x66\x6b\xa0\xdc\x1f\x11\xf6\xf3\x1e\xf7\xdf\xf9\xe0\xde\xad\x5b\xcb\xf7\xbf\x3d\xbe\xf1\xa7\xd2\x42\x1b\x71\x37\xf5\xdc\xe0\xe4\x0a\x57\xc8\xd1\xed\x9b\x65\xf6\x8f\xd4\xac\x4a\x14\x63\xb9\x22\x13\x09\x48\xc2\xd7\x79\xc2\x7e\xe3\x13\x09\xcf\x0d\x18\xc4\x07\x78\xef\xa5\x0c\xc8\x1d\x44\x88\xef\x61\x43\x16\xcf\xbd\xf7\xed\xbf\x2f\x5e\xfd\xcf\xe5\xfb\xb7\x16\x6f\x7f\x7e\xfc\xe5\xe7\x8b\xb7\x5f\x5b\x7e\x72\x77\x71\xf7\xad\x12\x5a\x2e\xb5\xe8\x04\x86\x88\x13\x06\x3e\xe8\x2a\xd3\x8e\x9c\xbd\xfe\x88\xd8\x23\xe9\x05\xf9\x23\xfe\x43\xa3\x69\x68\x68\x57\x6e\x2a\x66\xa8\xee\x21\x23\xc1\xda\xd7\x5f\x5e\x7e\xf4\x7a\xc8\xda\xc5\xcb\x77\xd5\x8a\x52\x75\x8c\x21\xff\x7d\x9e\x85\x5d\xf2\x88\x1f\xdf\x78\x79\x47\x57\x96\xd7\x9b\xf4\x3d\x0f\x0f\x3a\xd1\x37\x39\x49\x88\x21\x3b\xc2\x36\xc1\x99\x7f\x91\x37\xf2\x1d\xe2\x73\x51\xd5\x41\xff\x97\x35\xa1\xac\xfd\xe4\xfe\x07\x37\x16\x37\xdf\xe6\xdc\x95\x9f\xa7\xe1\x51\xcf\x9b\x5e\x66\x4a\x5a\x38\x57\xa3\xd3\x22\x22\x2b\x46\x24\x35\x90\xf3\xb5\x20\x00\x81\xfc\x03\xcb\x33\x93\xba\x3f\xb3\x3f\x0e\xae\x0a\xc6\x45\x3e\x57\xa8\x91\x46\x89\xf1\x0c\x7a\x4a\x65\x77\x48\x4c\x15\xd3\x9b\x8c\xac\x3d\xdc\x08\x91\x30\x50\xd1\x6e\x2a\xf8\xd0\x74\x4a\xe2\xea\xae\x7f\x4e\xd1\x10\x15\x8c\x4d\x32\x77\xdd\x76\xfc\x3a\xc4\xd6\x4a\x77\xe5\x3d\x15\x82\x0e\xb5\x16\x45\xb6\x4a\x90\xdd\xd9\xda\x55\x4c\xb4\xa1\xff\x0b\x13\x9f\xa4\xb6\xe2\xf2\xa2\x45\x59\x6d\x21\x14\x31\xcc\x1f\x96\x68\x1d\xa6\x13\x03\x5d\xf9\xd1\xeb\x64\x3b\xf9\xff\xbb\x6f\x2e\xdf\x7c\xfd\xfe\xaf\x7f\x7f\x74\xfb\xfa\xbd\x5f\x7c\x73\xfc\x4f\x5f\x2f\xfe\xe5\x8e\x02\xbd\xcc\x9e\xb0\xcd\xf7\x89\x33\x3e\x1e\x7b\x0d\x09\x09\xe0\xaa\x89\xad\xfd\xcf\x3e\x5e\xbc\xf1\x49\xf1\xda\xa7\x71\x43\xc9\xda\x8f\x88\x60\x00\x96\x7d\x20\x72\x49\x19\x2c\xa2\xde\xe2\xc6\x87\x47\xb7\x7f\x17\x0a\x50\x4e\xb3\x42\x75\xb0\xc3\xdc\x30\x83\xf9\x56\xbb\x26\x33\x1f\xce\xed\xb3\x93\xe7\x26\x75\xb9\x36\xdb\x65\x16\x2d\x33\x8c\x0b\xe3\x0e\x27\xcf\x9f\xef\xbd\x78\xfa\xc2\xc5\x33\xe7\xce\xf2\x20\x43\xdb\xdc\x32\xdb\x89\x08\xc4\x0c\xbb\x34\x00\x01\x53\x31\x5a\xeb\x3c\xbb\xc6\x09\x0d\x14\x6b\xfc\xfb\x47\xb3\x18\xa7\x30\xfe\x1d\xc2\xb2\x01\xd5\x43\x69\xf8\x63\x81\x45\x15\xa1\x26\x3e\x62\x3e\x7f\x81\xdd\x50\x42\x8f\xa9\x1d\xbb\x35\x28\xaf\xb9\xdc\x8e\x20\xfa\xec\x9d\xaf\x17\x6f\xbd\x4f\xd0\x5c\xfc\xfa\x4d\xc7\xdd\x90\x5b\xb0\x7d\x7b\x88\x7b\x94\x24\x82\xa2\xa0\x2a\x38\x9a\xb4\x62\x45\x21\xae\x10\xc6\x82\xa6\x52\x9a\xd8\x92\x20\xf6\x10\xaa\x3b\x6e\x5d\x6f\x35\x51\x55\xb2\xb2\x34\xd3\x69\xd9\xf4\x14\x0e\xcd\xf9\x29\x86\xa5\x45\xec\x01\xc9\x49\x69\x4f\x41\xd8\xeb\x24\xd2\x43\x17\x07\x31\xeb\xf9\x32\x35\x50\x10\xd7\xbf\x56\x28\x19\x6c\xc4\x0b\x78\x38\x1d\xf5\xdd\x46\xd0\x13\x76\x7e\x9e\xbb\x26\x7f\xe4\x61\x5f\xb0\x2c\x36\xdb\xab\xaa\x37\xe8\x4c\x85\x13\x3d\xda\x66\x48\x6c\x88\x28\xac\x10\x1e\x48\x11\x84\x43\xa5\x4d\xa2\xc0\x7e\xb1\x06\x61\xf0\x8b\xa7\x22\xe0\x93\xb0\xf8\xe4\x40\x71\xea\x03\x92\x85\xc4\x68\xeb\x59\xe7\xd1\xd6\xbe\xee\x83\x14\xd5\xae\x25\x0b\xb2\xee\x6c\xb6\x77\x55\xfd\x04\xc7\x3f\x34\xf4\x37\xdb\xc6\x96\x11\x4d\x0d\x9a\x58\x2f\x86\x5a\xa0\xeb\xa8\xeb\x5c\xec\x37\x63\x1f\x67\x05\x97\x9b\xcc\xba\x66\x2d\x80\xbd\x01\x49\x60\x6e\x4f\x8a\x07\x42\xdf\x76\x09\x36\x80\xfa\x55\xa6\x49\x8a\xf9\x09\x00\x29\x97\xa9\x47\xb7\xdf\x38\xfe\xaf\x3b\x05\x4c\x1d\x3b\x33\xdc\x9b\x4e\xf2\xb8\x5a\xa8\x6a\xe8\x2e\xf6\x0c\xda\x52\xe9\x95\xe9\xe4\x02\xde\x73\xdc\x41\x43\xcd\x72\x16\x80\x51\x84\x0e\x92\xc3\xc9\x03\xaf\xba\xa3\x08\x12\x50\x12\x04\xf0\xe4\x73\x5d\x06\xfe\x0a\xc0\xc0\x81\x73\xc5\x2e\xcd\xc2\xa7\x21\x6b\x71\x84\xed\xa1\x7f\x80\x36\xd5\x17\x6d\x28\x2c\x95\x32\x3c\x3d\xa0\x26\xb3\xd2\xdd\xf5\xd8\x95\x3b\x82\xa6\xcc\x69\xb2\x3d\x9e\x82\x5b\x3b\xdc\x10\x2d\x30\x35\xe3\x0e\x70\x01\xc8\x5c\xfd\x84\x9a\xd6\x25\xcd\x10\xc8\x8d\xa3\xc8\xd4\x8d\xae\xfd\xc2\x2f\x89\x82\x54\x28\x6a\x2b\x2c\xdd\xbc\xd1\x42\x0d\x4f\x0f\x5d\x80\xbd\x05\x49\x65\x84\xcb\x5c\x69\x86\x9c\x97\x82\xe3\x96\x60\xf4\xd5\x12\x5f\x84\x3f\x7b\x1e\x9c\x7c\x98\xe3\xa9\x6f\xb1\xec\x99\xd8\xbf\xa3\xec\xe6\x8d\x4b\x87\x49\xe3\x88\x51\x79\x6b\x36\x12\xa4\xcc\x0d\xda\x32\xa2\x54\x82\xf9\xd5\x0a\x62\x9e\x28\x69\xde\x0f\x2e\x2d\x4c\x55\x73\xbf\x62\x81\x9d\x57\xe0\xb8\x4a\x48\x1c\x03\xd7\xdd\x78\x30\x0c\x2f\x27\x78\x9a\xbb\x4b\x70\x05\x42\x6b\x83\x49\xf4\xd1\x70\x5b\xf9\x81\xbb\x5f\xbc\xc7\xa4\xee\x64\x3c\x80\xad\x25\x01\x16\x5c\x25\x64\x2e\x1b\x94\xdd\x0f\x04\x7f\xaf\x6d\x44\x9b\x42\x0a\xaa\x2a\xd7\xb2\x04\xf2\x47\x53\x93\x3f\x7c\x9c\x93\xc2\xf5\x97\xc2\x3b\x80\xe2\x2c\x45\xda\x42\xb2\x26\x69\x59\x99\xde\x8b\xc5\x8f\x1f\x00\x2c\x7f\xf3\x15\xbf\x46\x51\xa4\xfc\x1c\xc7\xe3\x7d\x7e\xb0\x4a\x4f\xce\x25\xba\x9d\x95\x53\x5c\xab\x09\xce\x63\xb5\xf7\x7d\xab\xbd\x88\xf3\x3f\x60\xc5\x17\x67\x11\xa3\x14\x01\x74\x33\x87\x15\x84\x4e\xa5\x9e\xe8\x11\x7b\x9d\xba\xbb\x22\x23\x35\xa1\xe0\x40\x31\xa8\x27\xb6\xd5\x31\xc7\xf8\xd6\xbd\x7a\x5c\x8d\x9c\x7d\xbd\x50\x09\x38\x5f\x76\x15\x05\x5f\x45\xe0\x24\xdf\x0d\x2a\x17\xb2\xd0\x73\x8d\xe0\x9e\x70\x35\xde\xb9\x96\x1a\xd2\x44\x23\xa2\x95\xd5\xac\x00\x9f\x2a\x9d\x6d\xb5\x3e\xa8\x6c\xe7\xaa\x26\x04\xf3\x70\x0b\x90\x62\xb9\x94\x4c\xdb\x7e\x2c\x96\x15\xd0\x45\xdc\x9a\xc5\xbc\xc9\x92\x15\x3d\x72\x8f\x08\x65\xdb\xb3\x28\xe3\x55\xe6\x12\x56\xbd\x08\x56\x5f\x00\xab\x59\x97\xf3\x4a\x6f\xd0\xe6\x9f\x9e\x7d\xf7\xdb\xc5\xcd\xf7\x0b\x4e\xcf\xbc\xfe\x4c\x90\x5c\xa0\x87\xb7\xca\x76\x9d\x32\x4c\x04\xb9\x2a\x63\x2b\xa6\x36\x68\xc9\xcf\xcd\xef\xd5\x87\x59\x1b\xb6\x5c\xbf\xca\xf0\xad\xfc\x6a\x36\x4b\xe9\xa7\x62\xc2\xac\x3d\x9a\x0c\x55\x5c\x4e\x6b\x16\x65\x23\xef\x39\x03\x5c\xeb\x3e\xc2\x56\xaf\x86\x91\x1b\x6e\x0b\x49\x6e\xac\xec\x9e\x28\x62\x26\x64\xd6\xec\xb4\x19\x85\x1d\x9f\x5d\x2b\x00\x4a\xb2\x19\x65\xf2\xfe\x25\x20\xad\x7a\x65\xae\x48\x97\xa0\x9f\xff\x1c\xad\x66\x85\x81\x10\x12\x69\xb7\xb5\x5b\x95\xff\x9e\x99\x5a\x32\xd7\x2a\xe2\x11\x2d\xb3\x14\x85\x8a\xf8\x21\xff\xe4\x76\x28\x5e\xe6\xe3\x60\xee\x62\x6d\x60\xf9\x38\x6c\x68\x0a\xf9\x94\xc5\x98\x67\x6a\xe9\xd5\x0d\x00\xc5\xa3\x52\x7a\xe7\x5f\xb8\x54\x57\x67\x9e\x5d\x76\x06\x87\x9d\xb4\x40\xc0\xcc\xa2\xda\x4b\xf8\xb0\xc6\xbe\x75\x10\xc3\xd0\xa4\xd5\xfc\x80\x5d\x6b\xc1\xb7\xa8\x2b\xb0\xe3\x4c\xe8\x98\x14\x2e\xe8\x00\x4c\x3e\x6a\x6c\x00\xfe\x1d\xd8\x91\x16\xa6\x64\x18\x87\x20\x07\x0f\x80\xdd\x07\xd8\x4b\x12\x8b\x3c\x00\x76\xf5\xfc\xbe\x3f\xf5\x6a\x71\x57\xfe\x00\xd8\x7b\xd4\xf7\xfc\xde\x74\x32\xa0\xd6\xb9\x35\xa0\xe0\xb3\x31\x92\x8f\xd5\xa6\x1a\x20\x8b\x31\xbf\x6c\xa3\x5e\x09\x45\x5a\x7c\xd1\xb1\x3d\xac\x51\xb4\x31\xec\xb2\xde\x9a\x8d\x54\x4d\x73\xe3\x9f\x31\x80\xaa\x61\xdf\x9d\x62\xa8\x1f\xc1\xea\x7b\xba\xe6\xd0\x69\x5c\x23\xf6\x8b\x7f\x40\x88\x44\xcb\x9d\x86\x95\x32\x69\x99\xcc\xd9\xd8\x4c\x96\xdd\x24\x76\x4e\x7f\xec\x31\x59\xb3\x09\x41\x49\x93\xba\xb2\x21\x58\x28\xc5\x0f\xab\xef\x49\x2f\xce\xee\xbb\xce\xb8\x47\x86\xa1\xa9\x9a\x43\xdc\xc9\xce\xc4\x5a\x9a\xc9\x66\xf3\x39\x24\x59\x3f\x36\x26\x89\x1e\x24\x34\x74\xfd\x7f\xb4\xfc\x03\x4e\x52\x6a\x26\x19\xa8\x16\x48\x24\x25\x09\xf1\x68\xc8\xfe\x06\x25\x2e\x71\x43\x5d\x9f\x0f\x35\xf6\x86\x2b\x97\x0e\x98\xeb\xdd\xb1\x1c\x39\x43\x6b\x8f\xa5\x89\xf4\xa2\x7b\xc7\x04\x89\x29\xcb\x54\x8d\xe4\x6c\xac\xf2\x27\xc2\x1c\x7e\xf3\xf9\xd3\x27\x9f\xed\x3d\xfb\xc2\xd9\x53\xbd\x4b\x67\x2e\x3d\x7f\xfa\xe2\xce\x38\x55\x95\x63\x97\x9a\x82\x53\x9b\x58\x42\x96\x8d\x07\x2a\x22\x05\xab\xa4\x5d\x26\x2c\x1b\xf4\x1d\xcb\x2a\x62\x04\x46\x15\x3c\x77\x78\x23\x8f\xc6\xf4\xfb\xbc\xbb\xb1\xd1\xa2\x45\x86\xc9\x6a\x58\xfc\xf1\x5d\x9a\x8d\x1f\x17\x18\x26\xcf\x2a\x2d\x2e\x3c\xb2\x3c\xff\x71\x5d\xe1\x15\xea\x0a\x07\x9a\x26\x53\x28\x38\xa9\x88\xf4\x2b\x06\x7b\x4c\xbf\x85\x7a\x4c\x21\xd8\x51\xe6\x39\xaf\x1c\xbc\x51\xf1\xf6\x24\xdd\x29\x40\x05\x85\xb5\x8b\x09\x97\xda\x94\x98\x57\x7a\xe0\x04\x79\x02\xa1\x2d\x9c\x2a\x8b\x94\x38\xf1\x66\xe5\x26\x3a\x75\x6e\xeb\xd5\xff\xef\xe5\x57\xea\xc4\xc7\x60\xff\x33\x7f\x82\x7e\xdb\x02\x7a\x97\x51\xbd\x62\xcd\x72\xc7\x33\xb1\xd6\xb1\xd2\x3d\x8c\x2c\x6d\x5e\xae\x26\x28\xed\x46\xab\x3f\x0d\xb1\x2f\xe0\x1d\x96\x12\x9e\x99\x33\x23\x45\x12\x23\xfe\x89\xfd\x0d\xd8\x1c\xb2\x44\x4d\xcd\xcf\xc2\x9b\x9e\x7a\x20\x11\x51\x73\x26\x19\x07\x70\x38\x34\x87\x48\x9a\x9a\x05\xe1\x82\x15\x3f\x1d\x04\x36\x18\x52\x85\x29\x82\xee\xec\xfe\x35\xac\x08\x4f\x8f\x65\x2c\x0b\x7d\xe9\xd9\x66\x3a\x56\x27\x94\x75\xe6\x49\x76\x8b\xb7\x6e\x1d\xdf\x7d\xef\xde\xb7\xff\xa6\x9c\x84\x60\x21\x16\x46\x09\x26\xc9\x56\x47\x69\xb5\x48\x4b\x60\x39\x94\x4c\xb9\x95\xdc\x31\x85\x5b\x74\x5a\x63\xc7\x61\x72\xc5\xd8\x47\xb7\xff\x35\xae\x73\x7d\x74\xfb\xfa\xf1\x97\x9f\xab\x0d\x56\x7b\x32\xf5\x41\xe0\x63\xb2\xb5\xb3\x22\x3a\x41\x69\x7f\xd6\x07\x38\x7e\x92\x48\x05\x28\x70\x46\x02\xc9\x23\x44\x95\xe9\x6a\x2c\x80\x7d\x60\x31\x8d\xd9\x77\x0f\x79\xc1\xa0\xc0\x76\x50\x4d\x20\x2b\xa4\xb4\x5a\x19\x25\x1f\x7b\x21\x35\x22\x59\x97\x8b\x39\xa7\x44\xa2\x12\x90\xb6\x4e\xe2\xa3\xa7\x75\x5d\x44\x6c\x44\xff\x54\xfb\x6c\xec\x5a\x27\xdd\x9c\x7a\xbe\x35\xe6\x55\x6c\x3a\x68\x0b\x50\xe0\x27\x54\xaa\x62\xa5\xc8\x02\xde\xab\x03\x17\xce\xc0\xda\x3f\xec\x25\xca\x50\xc9\xc7\x5b\xfe\xc7\xff\x2c\xdf\xf9\xfa\xfe\xab\xd7\x8f\xbf\xf9\x3d\xa1\xde\xe2\xd5\x2f\xee\xff\xe2\x0b\xda\x01\x3a\x47\x42\x53\xa8\xe7\x58\xfe\xe6\x2b\xb8\xc6\x90\x55\x03\x12\x6b\x01\x29\xc3\x4a\x11\xbb\xb1\x3b\xc3\x6e\x72\xf1\x72\x58\xd3\xc2\xb4\xfc\xe8\xfa\xe2\x8d\x4f\x17\x1f\x7c\xb1\xf8\xe7\x57\x09\x25\x80\x80\x16\x6f\x39\x45\x86\xca\x06\x6c\x93\x92\xd8\xff\xdc\xd8\x94\x6f\x55\x94\xf1\xe9\xfd\x29\xe0\x4d\x7e\xfb\x64\x9d\x52\x60\x7b\x71\xf9\x28\xda\x0f\x92\x9c\x2c\x6a\xcf\xec\x5e\x4c\x1c\xfc\x5e\xbc\xd1\xf2\xf6\x15\x94\x50\x0a\x16\x51\xf1\x66\x48\x75\xb8\x6e\xf6\x8f\x10\x37\xe5\x2a\x6b\x9b\xde\xb3\x57\x27\x28\xc6\x4b\x5f\xb8\x05\x63\x46\xb7\xe6\xcd\x59\xa9\x1c\x7b\x8e\x9c\x98\x5d\x5f\xf0\x5a\x07\x31\xf1\x32\x75\x33\x13\x96\xf2\x14\xe1\x90\x08\x4e\x27\x11\x29\x3e\xb8\xc9\x0c\x62\xce\x52\xc1\xfb\x27\x9f\x4c\x5e\xc8\x49\x37\x6f\x6e\x6f\xb3\x6a\x68\xb0\xfc\xfd\xec\x7e\xbb\x1d\xab\x16\x53\xf4\x6c\x79\x1b\xdb\xf1\x7b\x9e\x35\xb4\x01\xb5\xab\xd9\xf8\xd9\x0d\x4c\x21\x0e\x42\x48\xa7\x90\x16\xfc\xb0\x22\xa6\x46\x74\x33\xe2\x2f\x93\x1e\x25\x41\x07\xde\xb9\x93\x41\xb5\xd9\xae\xec\x7e\xf4\xe2\x95\x8f\x96\x37\x3f\x2b\xd0\x33\x7b\x7d\x7b\x0f\x8f\x7a\xce\x04\xbb\x2c\xf2\xa0\x93\x6a\x2a\xd1\x1f\x39\x0a\xa4\x09\xce\xd6\x89\x34\x57\x85\x44\x10\x0c\xb4\x8d\x02\x4b\xa2\x44\xae\xa5\xce\x91\x11\xf5\x17\xc7\x60\x8d\x14\xb9\xc9\x54\x4a\x69\x8c\x9f\x28\xe1\xe2\xbc\x5f\x31\x0a\xcb\x7a\x1a\xa8\x76\xad\xd6\xa4\x47\x8e\xe4\x37\x6c\x0f\x12\xbf\xcc\xa3\x5f\xb2\xbd\x76\x72\x7b\xed\xaa\x63\xb8\xb0\xd7\x13\x09\xaf\x0d\x82\xde\xa6\x22\xc4\xcb\x18\xb8\xdb\xe8\xa9\x2e\x75\x1a\x9c\xe9\xe5\x11\xe6\x9b\x08\x64\x9c\x8c\x3b\x02\xbb\xa5\x2e\xbc\x18\xa1\x14\xb4\x27\xb6\x51\xbb\x5b\x12\x3e\xb9\x26\xe4\x53\xc4\xcd\xd7\x7a\x41\x5a\xce\x80\x1f\x53\x06\xd8\x0e\xb2\x31\x1e\xd0\xc2\x41\xc5\x4c\x98\x17\x0b\xb1\x6c\x8a\xa7\xd5\x05\x0c\x79\xd8\xa4\x4c\x40\x5c\x8e\x53\x41\x75\x13\x09\x77\x54\xc2\xa3\x7f\xf6\xab\x71\xee\x5b\xf2\xcc\xb7\x16\x9c\x7e\xea\x1c\xf5\xd2\x83\xde\x8e\xc6\x09\x2f\x3d\xdf\x0d\xda\xcf\x14\xed\xa3\x93\xdc\xb0\x3d\xe0\x30\x57\x3c\xc4\xd5\x3b\xc3\xe5\xe7\xb7\x1d\xe8\xd1\x6d\x74\x66\xdb\x01\x9c\xd8\x56\x70\x44\xab\x3a\x9e\xad\x22\xf6\x0d\x3d\x96\xd5\x3d\x92\x2d\x15\xf9\x5e\xe1\x28\x56\x42\x5b\x31\x22\x9d\xfa\x09\xa4\x7c\x33\x71\x36\xe8\x0d\x48\xd2\x37\x13\x1b\x2d\xb6\x63\x84\x9e\x19\x3b\x50\xdd\x73\x6d\x47\xb0\x5a\xc7\xaf\xe0\xeb\x43\x0c\x4e\xb6\xb8\xc3\xeb\x5b\xcf\xa0\xbf\xd9\x02\xbf\x2a\x21\xc9\x98\x62\xd7\x40\x68\xd9\xad\xf6\x26\x72\x06\x8c\x35\x5f\x8e\xd5\xad\x0e\xb4\x7c\xef\x0f\x8b\xcf\x3e\xe6\xa1\xae\xc2\xeb\xb1\x7a\x95\xca\x00\x76\x6e\xc2\x21\x88\xdd\x01\xf0\xb1\xb6\xe0\x02\x80\x58\xec\x31\x07\x50\x91\x9f\xa4\x75\x5f\x2f\x1d\x54\x92\xfb\xfd\xa9\xa6\xec\x12\x3a\xcc\xcb\x2d\x81\xa5\x86\x8f\x9b\xbe\xdd\x2b\xc3\x46\xee\xb9\x3f\xe4\x18\xa9\x41\x28\x77\xb9\x2e\x69\x68\x9d\xbb\x08\xb9\x61\xb7\x82\xc5\x25\x5a\x51\x61\x00\x32\xa6\xbc\xd6\x35\x1f\xf1\xe2\x5c\x8a\x79\x3a\x83\xc4\x97\xe7\x64\x02\xa3\x31\x92\x60\x88\xb1\x91\xb4\xef\x2d\x89\x17\xea\x62\x58\xc8\x43\x58\xbe\x05\xb0\x28\x7f\xc9\x2b\x6d\x2b\xd9\x4f\x65\xed\xa8\x95\xec\xa9\x0a\xec\xaa\xf8\xe0\xc2\xbf\x44\x9c\x22\x67\xea\x37\x6a\x57\x88\x61\xe2\x5c\x61\x69\x42\xec\x7e\x88\x8b\x89\xfa\x18\x34\x9a\x35\x03\xfd\x78\x6b\xab\xa9\xfd\x8a\xb7\x5c\xa3\xe5\x72\x7f\xd0\x9b\x38\xc4\x1e\xa2\x0f\x6b\x5a\xa9\x65\x33\xe6\xcd\x28\x34\x11\x6d\x01\xda\x3f\x72\x2d\x3c\xe1\xa0\xb7\x44\x8e\xa2\x12\x79\x17\xff\x6c\x4a\xec\x41\x3d\xd4\xe5\xa3\x12\x03\xce\x60\xe5\x34\xe9\x9d\x2b\x7a\x90\x71\xd0\xf7\x10\xbe\x4a\xc8\x80\x89\xde\xab\xf1\xdb\x87\xa1\x0a\xd2\x7e\xd5\xdd\xaa\x04\xd1\x62\x1b\x81\xbc\xc7\x20\x2f\xbe\x8b\xa4\x45\xf4\x04\x79\x5e\x64\x46\x0b\x11\x0b\xc4\xf3\x7e\xd2\xf1\xb1\xa2\x1e\xc1\x0d\xfe\x12\x04\x14\x11\x2f\x45\x39\x09\x07\x8a\x76\x60\xdd\x5c\x3e\xb5\xfd\xaa\x23\xde\x74\xd1\x4d\xb0\x3b\xb6\x7c\x22\x80\x70\x7a\x41\x86\x16\x0f\x59\x57\xd2\x1a\x0f\x56\x29\x04\x63\x24\x8e\x88\xf3\x38\x28\x36\x5a\xd7\xeb\x17\x57\xa4\x45\x0a\x8f\x2a\x68\x59\xa5\x34\x03\x32\x53\x9b\xdd\x07\x93\xca\xb8\xb8\xf1\x21\xcf\x63\x2c\x70\xd7\xe2\x8b\x14\x99\x7c\x46\xc8\x61\xb0\xe4\x1e\xc6\x36\xda\x1b\x39\x36\x6e\x48\xce\x69\xb4\x4b\xd1\xb2\x5c\xcc\xe0\x5e\x05\xbb\x63\x91\xe7\x72\xf6\x7b\x1c\x12\x0d\x97\x33\x36\x47\xe1\xe9\x38\x59\x74\xbb\x2a\xb7\x16\x45\x8e\x6d\xb1\x67\x1b\x64\x1f\xf3\x00\x06\xcf\x3c\x76\x2e\xd3\xdb\xca\x35\xc0\x5b\x2f\x22\x7f\x38\xed\x36\x04\xc3\xad\xe8\xc1\x26\x66\x08\x0f\x8b\xf4\xc5\x97\x47\x76\xe9\x7a\x33\x09\x80\x63\x0e\xdc\x4b\xcd\x47\x31\xb0\xcd\x5d\x09\x78\x70\x5b\x74\xca\xa0\x01\x6e\xd1\x0b\xd3\x71\xc0\x44\xc7\x0b\x1c\xec\xae\x22\xe0\x2d\xa0\x07\xca\x57\x12\x73\x95\xe2\x72\x03\x71\x04\x1c\xe0\x76\x3d\x8e\x86\x3f\xd0\x68\x78\x65\xde\xda\xda\x42\xcb\x64\x83\xcf\xdc\xdb\x0b\xe2\xf5\xc4\xb0\x5e\x1d\xa8\x12\xae\xa3\x1c\xa4\x0a\x3c\xc7\xb5\x78\x7b\x72\x68\x03\xd5\x7d\x22\x07\x6c\xee\x35\x41\x4a\x68\x55\x6f\xc1\xaf\xc7\x72\x97\x93\x01\x7c\x4e\x53\xb1\xb1\x3d\xd7\xbe\x60\x99\x67\x52\xf1\xad\xee\xfe\xbb\xdf\x2d\xfe\xf4\x79\xc1\x6b\x15\x3b\x45\x2f\x55\x6c\xb5\xee\x7d\xf3\xe5\xf2\xfa\x67\xcb\xd7\xde\xb6\x72\x5e\x48\xc5\xde\xb7\x58\xf0\xda\x45\xfa\x9e\xc7\xd3\x57\x2d\xff\x14\x7b\x65\xd2\x05\xe7\x4a\x27\xfd\xc0\x28\x78\xc5\x12\xf9\xf9\xec\x74\xdc\x49\xfc\xa5\x4c\x1f\x13\x12\xa9\x36\xe6\x71\xee\x18\x22\xb6\x99\xef\xb8\x87\x2c\x7b\x8c\xcf\xfe\xf7\xfc\xc9\xea\x19\x64\x01\xb3\x83\x19\x1e\x27\x90\xad\x90\x40\x46\x53\x60\x8b\x6d\xed\x53\xe7\x5e\x38\x7b\xa9\x77\xfe\xf4\x85\xde\xf9\x93\xcf\x9d\x56\x9c\x23\x49\x72\x54\x03\x66\xd1\x10\x96\x98\x2d\xc9\x2a\x2a\x28\x9b\x53\xc5\xa0\x52\x2b\x14\x05\x2a\x20\xb1\x39\xdb\x0a\x24\x83\x25\xb1\x15\x4f\x52\xc1\x19\x54\x42\x3b\xa6\xd3\xf0\xfc\x03\x83\x40\x36\x25\x2a\x29\x80\x2d\x00\xad\x0e\xd2\x96\x49\xda\x13\x24\xdb\x5b\x5b\x6a\x3d\x29\x21\x08\x05\x20\x9f\x28\x41\xb6\x20\x6d\xc4\x08\x1f\xe6\x37\x4b\x5b\xd1\x4c\xe3\xa8\x05\x54\x38\xb4\x22\x1a\x19\xac\xff\xae\x14\xce\xc4\xca\x56\xa2\x1c\xde\x12\x5f\x13\xc6\xe5\x33\x7d\xb9\x06\xe3\xf2\x03\x74\xcd\x1e\x4c\xf2\xe5\x43\x65\x72\xb3\x02\xbf\xea\x5a\x40\xb1\xb2\x1b\xe2\x20\x21\xbc\xf0\xae\x58\xb8\xb2\x1b\x51\x98\x05\x59\x36\x59\xe3\xf6\x1e\x8d\x25\xb0\xac\xce\xa6\x8e\x79\x2f\xd6\x5e\x89\x86\xac\x68\xb1\x48\xc7\x36\xa9\x1d\xd4\x85\x97\x52\x60\xff\xf8\x8e\xdf\x1f\x05\xe4\xf9\xab\xcc\x22\x04\xd4\x3c\x8a\xd7\xa2\x40\x66\xf9\xf8\x2d\xad\x75\xa4\x1d\x0f\x4d\x40\xf0\x0f\x64\x11\x99\xfb\x23\xc7\x71\x1b\x30\x60\x9a\x64\x99\xb7\xd7\x77\x37\x64\x45\x5f\x91\xd9\x46\x34\x78\xca\x5f\xb0\x9e\xce\x34\x6e\xb5\xd8\x77\x44\x04\x75\x36\xea\x43\x33\xd0\x82\x5c\x72\x35\xd2\xc9\xcc\x63\x26\x78\xe0\x9b\x40\xd9\xec\x63\xd6\x1f\x94\x7d\x1c\x12\x2d\xca\x22\x26\xd0\x6a\x55\x5f\x48\x98\x5b\xa0\xab\xce\xe9\x48\x5d\x38\xad\xd9\x9f\x5e\x4d\x14\x71\xe2\xd7\xa1\x45\x7c\x12\xcd\x9a\x3a\x47\x5e\x3a\x57\x95\xc5\x4f\xc5\x27\x42\xf4\x43\x29\xbc\x9d\xc0\xbc\xca\x32\x8a\x89\xf7\x28\x82\x52\xca\x59\x6b\x43\x94\x7d\x03\x25\x7f\x84\x26\x96\x33\x19\x48\x2d\xa1\x5e\xc9\xdc\xf2\x84\x74\xb0\x71\x72\xc6\x0b\x42\xe2\x60\xe2\x38\xa3\x81\x06\x71\x58\xeb\x3c\xe2\xb0\x1f\x4b\x13\x47\x02\x48\x29\xe2\xb0\x71\x72\xc6\x03\x56\x67\x9e\x43\x4b\x11\xc1\x16\x8f\x90\x4d\xdf\x41\x9a\x35\xac\x84\x2d\xa6\x23\xfc\x01\xeb\x4c\x97\x56\x34\x31\xf9\xc3\xd0\xe5\x4a\x47\xf8\x03\xd6\x59\x48\xb4\x16\xf2\xab\x41\x5d\x85\x6c\x5a\x21\x89\x76\x8d\x77\x27\xcb\xe7\xb6\x2a\x32\x38\x83\x20\x0c\x2a\x8a\xc2\x88\x21\x16\xfd\xe0\xc7\x8b\x64\xc7\x8d\x22\x15\x68\x93\xec\x59\xf4\x08\x70\x83\x3c\x36\xa3\xc7\x8d\x3a\x7f\x5a\x37\x56\x0d\x84\xf0\x71\x7e\x20\x01\x90\x89\xeb\x4c\x08\x67\x77\xea\x63\x67\x80\x47\xf5\xdd\xbc\xcc\x66\xe5\x49\xa5\x5a\x47\x44\x8b\x05\xb4\x54\xa2\xf5\xa1\x5a\x1d\x20\x11\xa6\xec\x9d\xb2\x4b\x3e\xd7\x72\x02\x7c\xbd\x11\xee\xef\xb3\xa3\x65\x8d\x63\xd9\xe0\x5d\xdf\xe1\x71\x2b\x1e\x29\x5e\x81\x1e\x10\x29\x53\x03\x2b\x5b\xf7\x4a\xeb\xf0\x79\xdf\x5d\x23\xcc\xcc\x8a\x75\x87\x5e\xb2\x4f\x54\x28\xab\xb8\x23\x41\x58\x9d\x32\x40\x4d\x50\x00\x49\x00\xce\x31\x99\x6a\x87\xc2\xba\xb3\xb5\x4b\x3c\x7c\xc4\xf3\x38\x9d\x80\x3a\xf4\xed\xc7\xd7\x50\x90\xd7\xc8\x5a\xb5\x77\xd1\x7c\x17\x94\x02\x1c\xbd\xb7\xde\x76\xd4\x67\xdc\x01\x20\xb2\xf9\x03\xe0\x60\x93\xee\xb0\x77\xc8\x1b\xec\x55\xf6\xbb\x26\x4b\x84\x38\xb7\xcf\x40\x69\x52\xc2\x6c\xb6\x81\x80\x28\x3c\x01\xe6\x7d\xb0\xcb\x03\xcc\x81\x27\xff\x3d\xcd\xe0\x0c\x4b\xa0\x21\xeb\xc4\x09\x60\xc1\x42\xfe\x6e\x66\x86\xa4\xb2\x34\x73\x14\xe9\xa3\xb8\x08\xf3\x41\xde\xc1\x9a\x99\x32\x4b\xeb\xe6\x83\x2e\xd2\x9d\x88\xcf\xe4\xc4\x09\xe6\xba\x75\xe3\x9a\x5d\xd9\xfe\x27\xdb\xfd\xaa\xd9\xfb\x1e\xef\x7c\x1a\x9b\x86\x37\xbd\x7c\x8a\xd0\xcb\x03\x28\xdf\x0a\x19\xcf\x02\xa9\xbd\x10\x2f\x99\x18\x24\x5b\x54\x23\x14\xc9\x31\x1f\x8b\xc8\xa3\x62\x1c\x75\x1f\x3d\xeb\x28\xbf\x54\x68\xd6\x26\xd0\xb1\x92\x28\xf4\xbc\x34\x37\x10\x87\x50\x14\x23\xf8\xb5\x8c\xb2\x03\x6c\x0d\x0f\xfc\x1e\xd9\x5d\x59\xf5\x54\x0d\xba\xf1\x9e\x34\x9b\x9c\xc6\x4c\xff\x16\xfd\x75\x90\xc4\x94\xb2\xbc\xe2\xfd\xf2\xa9\xa6\x92\xa8\x7c\xcc\x6e\x39\x0b\x5a\xa2\x9d\xfe\x0c\x46\xbc\x3d\x36\x33\xad\x00\x00")
 
 func webJsComponentsConfigJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -580,12 +580,12 @@ func webJsComponentsConfigJs() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "web/js/components/config.js", size: 43625, mode: os.FileMode(420), modTime: time.Unix(1471241434, 0)}
+	info := bindataFileInfo{name: "web/js/components/config.js", size: 44339, mode: os.FileMode(420), modTime: time.Unix(1471241434, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _webJsComponentsOthersJs = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xec\x5d\x6d\x8f\xe3\xb6\x11\xfe\xbe\xbf\x82\xa7\x06\xb1\x8d\x78\xbd\xb7\x17\xb4\x05\x7c\x30\x8a\x14\x69\x83\x14\xcd\x0b\x7a\x57\x04\xc5\xe1\x20\x68\x2d\xae\xad\xac\x2c\xb9\x92\xec\xbd\x45\x72\xff\xbd\x1c\x92\x92\xa8\x57\x0e\x29\xc9\x39\x34\x3b\x5f\x6e\x2d\x71\x86\x43\x72\x48\x3e\x33\x1c\xf1\x6e\x6e\x7e\xa2\x77\xfb\x38\x7e\x48\xaf\xce\x5e\x42\xf2\x1f\x64\x43\xee\x4f\xd1\x36\x0b\xe2\x88\xcc\x13\x9a\xc6\xe1\x99\x2e\x49\x42\x7f\xa6\xdb\x6c\x41\x7e\xb9\x22\x8c\xa0\x7c\x46\x0f\xc7\xd0\xcb\xa8\x7b\x4a\x42\xc6\x33\xdb\xc6\x87\x63\x1c\xd1\x28\x4b\x6f\x1e\xa5\xa8\xd5\x3e\x3b\x84\xb3\xd7\x9c\x25\xa1\xd9\x29\x89\xc8\x3d\xcd\xb6\xfb\xb9\xca\xbb\xe0\xaf\x81\x56\xd9\x9e\x46\xf3\xb2\xf2\xa2\x14\xd3\xe2\x98\x57\x9d\x93\x94\x57\x29\xb3\xca\xe8\x87\x6c\xbe\x78\x5d\x14\xfc\xb8\xe8\x92\xd9\x14\xc7\x1b\x3a\xaf\x3e\x05\xca\x39\xd6\xc5\x5f\xcb\x46\x99\x24\x3e\x41\x81\x26\x33\x90\xef\x65\xde\x5a\xe9\xd3\x2c\xf1\xa2\x34\x80\xbf\xeb\x4a\xa8\x04\x5d\x7c\xf4\xb2\xfd\x6b\xd2\x5b\xc4\x3b\x1e\xdd\x07\xfa\xc4\x06\xa0\x14\xbb\xca\xe2\xd5\xd1\x4b\xbc\x43\xba\x92\xaf\x5f\x77\xca\x08\xee\xc9\x3c\x48\x57\x69\xe6\x25\xd9\x4f\x41\xb6\x9f\xd7\xc5\x64\xfb\xe5\xec\x86\x89\xc9\x07\x75\xb6\xe8\xd3\x1a\x08\x78\xc0\x20\x6e\xe2\x82\x29\xe5\x12\x66\xe4\x0b\xa2\x55\xe8\x23\xa1\x61\x4a\x6d\xaa\xd8\x85\xf1\x9d\x97\x9b\x5b\xab\xe8\xce\x37\x15\xe3\xe4\x6d\xd6\x28\x70\xa0\xd9\x3e\xf6\xd7\x64\xf6\xcd\xdf\xde\xce\x9a\xf6\xa0\xd2\x36\xa1\x3e\x9b\x15\x81\x17\xa6\xac\x7c\xea\x1d\xe8\x75\x9c\x04\xbb\x20\x9a\x31\xf3\xec\xe5\xac\xdb\x2e\x18\x52\xeb\x5c\xe8\x69\x52\xc1\xb3\xfa\x39\x8d\xa3\x79\x7f\x85\xcd\xe9\x02\xec\x06\xb5\xe9\x0b\x02\x81\xd0\xf2\xd7\x9a\xff\xee\xef\xc3\x9c\x0e\xb1\xef\x85\x6e\x16\x64\x21\xe5\x9c\xd1\x29\x0c\x71\x9c\xd2\x4a\x8a\x3a\xf1\x9c\xf9\x0c\xcb\x39\xe5\x6f\x1c\x33\x5b\xdf\x5c\x9a\x24\x71\x62\x5c\x6d\x4a\x93\x33\x4d\x72\x66\xc1\xa9\x65\xfc\xd8\x6d\xfc\xfc\xf5\xa2\xfd\x7d\x73\x62\x7c\x6c\x2a\x29\x4c\x3e\xed\x5a\xe5\x3c\xdf\x77\x65\x27\xab\x8b\x1d\x4c\xa6\x3e\x03\xca\xf6\x6c\xf5\x51\x87\x75\x43\x3e\x0b\xe3\xad\x17\xd2\xf9\x62\x25\xe5\xa9\xef\x57\x4a\x3d\xdd\x8d\xe5\x52\xd5\x21\xdf\x20\x4c\xd3\x91\x23\xeb\xac\x89\xe3\xe8\x07\xc9\x49\xb7\xf1\x91\x3a\x30\x36\xa8\xe2\x6c\x85\xdd\xd1\xcc\x81\xe2\xc7\xd3\xdd\x09\xc3\xc2\xac\xc7\x81\x3f\xb0\x0a\x65\x5e\x76\x4a\xa1\x86\xdb\x7e\x33\xe8\xdf\x0e\xc4\xe2\x0a\xab\xab\x58\x51\xdd\x62\xe5\xd7\x74\xa2\xda\xeb\x2b\xde\x3b\x04\x7a\xfe\xe5\xc0\xc5\xbe\x43\xec\x6d\xbf\xad\x57\xb8\x94\x5d\x12\x1e\xeb\x37\xa1\xf6\x69\xd2\x3e\x06\xd4\x0f\xb2\x36\xd3\x97\x8f\x46\xb5\x7e\xf6\x77\x70\xff\x64\x35\x01\xb6\x21\x43\x67\x85\x52\x1d\x0b\x41\x7b\x0b\x53\xef\x4c\xdb\x5a\xa8\xc3\x2f\xe7\x83\xec\xf1\x6e\x45\xa1\x98\x58\x59\xfa\xcb\x24\xf4\xbf\xa7\x20\xa1\xee\x5d\xec\x6b\xd0\x4c\xb3\x4f\x0d\x96\x14\x9d\x89\x0b\x55\xe5\x0f\x36\x45\x7e\xfc\xe1\xcd\xdb\x1e\xc4\x01\xa4\x6a\xce\x58\xfe\xf1\xe6\x87\xef\x19\xdc\x4a\x82\x68\xc7\x46\xb2\x05\x6f\xd6\x49\x59\x96\xce\x87\xba\x45\x1b\x2d\x53\x0c\x13\xa6\xf4\xdb\x28\x9b\x2b\x72\xf8\xdb\x85\xd1\xf2\xa5\x70\x8b\x87\x46\x2b\x99\xc2\xcd\x1e\x1a\xad\x6a\xad\xfa\xf3\xb7\x3a\x58\xa3\x5d\x81\xec\x0c\xa7\x3a\x1b\x8d\x6d\xe7\xdf\x93\x9b\x0e\x98\x0d\xfc\x51\xe9\x76\x9c\xd9\x3c\x5b\xdd\x94\x56\xd7\xf9\x46\xb8\x20\xaa\x63\x33\xc3\xfa\x22\xe2\xdf\xfe\xb6\x81\x25\xad\x2b\x76\x65\xeb\xbc\x74\xb7\xad\xe6\x44\xe0\xdd\x96\x0e\x97\xa5\xa7\x1b\xdb\xaa\xd2\xd5\x02\x53\x1d\xca\xc9\x41\x84\x59\x9e\x25\xa7\x46\x40\xa0\x8d\x52\x9a\xbd\x0d\x0e\x94\x39\xfb\x73\xe7\x31\x88\xfc\xf8\x71\x05\xeb\x03\x77\x96\x13\x1a\xc6\x9e\x3f\x5f\x38\x4b\xf2\xe5\xcb\x97\x3d\x4a\x73\xc5\xcb\x55\xa7\xe2\x7c\x73\xbd\xb6\xb1\x4f\x97\xc4\xb9\xf3\x7c\xf7\x18\xa7\x99\x0b\x0f\x1d\xad\xd7\x0d\xc4\x2c\xb4\x74\x38\xda\x16\xaf\xe2\xed\x70\xf5\xa2\x38\x73\x8f\x34\x39\x04\x59\x46\x7d\xbd\x7a\x18\x91\xaa\xcb\x83\x6e\x70\xc5\x4f\x6a\x6b\xb3\x5a\x60\x9c\x51\x81\xe9\x43\xd3\x0c\xd1\xe8\x1e\xcb\xc5\x3a\x62\x57\x55\x36\x35\xb6\xf5\xfa\x8a\x21\xf9\xab\x9b\x9b\x37\xbc\x85\x44\xd8\x33\x8f\xe5\x89\x27\x6f\xa4\x81\x17\x88\xcd\x32\x9c\x27\x7b\x50\xc8\x1f\x14\xd3\x9b\x20\xa4\xf7\xdb\x47\xf4\x90\x01\xbd\x4a\x67\xf1\x05\x3e\x62\x26\x95\xc2\xf2\x3e\x5a\x8c\x68\x94\x10\x91\x2e\x86\x60\xb1\xe6\x02\x71\x23\x7b\x3a\x52\xf7\xe0\xa5\x19\x85\xb9\x1a\xd1\x47\xf2\x55\x92\x78\x4f\xba\x3a\x2b\xec\x31\xab\x3e\x21\x36\xec\xd0\xdf\xf9\xef\x0d\x0f\xa8\xe8\xf9\xee\xd9\xaa\x32\x07\xe6\xc0\xff\x40\x02\xd1\x59\x2b\x6c\x93\x81\x78\xa5\x9b\x92\xef\x1d\x13\xf4\x5e\x5f\x2f\x10\x2c\x44\x2f\x80\x9f\x33\xba\x6c\x0e\xa6\x1a\x1b\x6b\xab\xbc\xc2\x9c\xa3\x48\x0e\x6a\xe6\x8d\xd7\x6c\x8e\x27\x88\xce\x04\xea\x5e\xdb\xea\x4d\xa8\xac\xa5\xbc\x4a\x18\xc7\x25\x99\x09\x43\xd0\x07\x94\x55\x52\x2c\x68\x75\x3c\xa5\x42\x20\x56\x65\x4c\xb4\xa1\x51\x19\xb7\x37\xf3\xba\xf4\xb1\xba\x2b\x6d\x11\xa5\xfe\x34\x4e\xb2\x72\xce\x79\xb7\x4b\xe2\xbd\xc2\xf6\x1b\x0c\x82\xf7\xaa\x32\xd0\xab\xc2\x1e\x36\xc4\xbb\x6d\x7d\x65\x32\x2a\x72\x25\x61\x95\x84\x6c\x68\xdc\xed\x9e\x6e\x1f\xdc\x53\xb6\x25\xd7\x20\xbd\xfa\x6c\x4c\xf3\x2a\xeb\x6d\x6d\xdc\x75\x57\xdb\xf4\x3a\xc0\xee\xaa\x2d\x54\x4c\x6a\x08\x73\x28\x86\xb9\x8d\x23\x86\x0a\xe7\xe5\xf0\x21\x8c\xc6\x38\x82\x2e\xe2\xe6\x9f\x72\x48\x38\x48\x5d\x86\x94\xc0\x5b\x8e\x65\xcf\x28\x9b\x26\x9f\x4b\x3d\xcd\x05\xa3\x6d\xac\x4f\xaa\xd9\x72\x77\xbd\x5c\x56\x5f\xbe\x6f\x2d\xd9\xdb\xf8\xcf\x3f\x6f\xae\x90\xab\x34\xd8\x61\xe4\x43\x31\xa4\x43\x03\xee\xc5\x90\x53\x29\x86\x0a\x3a\xf8\x2d\xf1\x22\x03\x72\x41\x9a\x05\x5b\x09\x16\xf3\x9f\x5e\xf8\xb5\xb0\xe5\xa1\x78\xb1\x90\xcf\x7d\x97\x67\xc4\x68\x89\x18\xa1\x9b\x19\x23\xc7\x2c\xf5\xf3\x5d\xe6\x81\x24\x4f\x60\x91\x3d\x96\x35\xc6\xf9\x30\xd7\x01\x0b\xb6\xa4\xc2\x0c\x4d\x7a\xd1\x8e\x16\x3e\x59\x3f\xd2\x02\x26\xde\x1a\xe5\xa0\x0c\xc5\xc4\x41\x85\x9b\x31\x87\x9c\xe0\x99\x68\xe4\xab\x2c\x38\x26\x9f\xdb\x16\xb4\x09\xc9\x24\x63\x0c\x3c\x84\x78\x8a\x7c\x7a\x1f\x44\xd4\x27\xbf\xfe\x4a\xf2\x87\x33\xed\x61\x4a\xad\x85\x1b\xb2\xa3\xd9\x5f\x29\x83\xa3\x94\x4d\x52\x3a\xff\xb3\x66\x4b\xa9\xb5\xb5\xce\xae\x0b\x50\xd4\x5a\xbc\x51\x55\xf9\x82\xcc\xd8\xce\x0a\x87\xf9\x79\x05\x03\x0f\x78\x94\xca\x38\x50\xee\x91\x07\x54\xe9\x95\x92\x77\x95\x9e\xee\x18\x7c\x9d\xbf\x5c\x92\x5b\x5d\xf3\x2a\x3d\xd3\x14\x71\xfb\x6a\x49\x5e\xdd\x5a\xc5\xef\x0a\x73\xe1\x5a\xc2\xb1\xcb\x1e\x24\xf3\x4e\x2f\x15\xd7\xcf\x21\x36\x7b\x22\x9f\xab\xa7\x08\xc8\xd5\xee\x61\xc7\xe4\x73\x90\x99\xb2\x44\xeb\xe1\xf7\xef\xdd\x71\x1d\x10\x30\x04\xe2\x1e\x64\x60\xe5\x3b\x02\xf9\xc2\xf5\x50\xfc\xc7\xf7\x68\x77\x07\xe1\x83\xe8\x4a\xe4\x69\x48\x79\xb6\x0d\xf4\x01\x1f\xff\x6b\x06\x72\xef\x83\xdd\x35\xf4\x7f\x1c\xa5\x94\x67\xf7\xf8\x00\x93\x00\x10\xb0\x35\xe2\x2f\xa5\xb9\x6f\xf8\xbb\x72\x56\xb0\xb7\x9f\xe7\xb6\x5c\xbe\x03\x83\x87\x37\xa7\x28\xc8\x36\xb7\xbe\xe6\x6c\x04\xa8\x2d\x6d\xa7\x96\x9a\x33\xc0\x42\x73\x1a\x62\xa9\x35\x4d\xcd\x2c\x36\x27\x6b\xcb\xcd\x89\xef\x25\x1c\x8b\xe1\x2a\x04\x1a\x68\xf5\x39\xb5\xec\xec\x33\xc4\xc8\xaa\xd4\x82\x28\x4c\x45\x88\xc6\x2b\x0f\x94\x80\x0c\x5e\x92\x71\xfc\x00\xa8\x27\x9a\x6c\x1a\x43\x6f\xa3\x11\x3a\xa7\x5b\xcb\x43\xba\x63\x9b\x45\xb1\x57\x30\x67\x29\x63\x5b\xa7\x77\x17\x52\xc2\x7c\xb0\x54\x86\x9d\x03\x9f\x9a\xc5\x70\x54\x52\xed\x43\x8d\xde\x57\x63\xce\x4a\x29\xf3\xb6\x21\xd6\xc1\x6a\x71\xdd\x29\x80\xd9\x21\x40\x1b\x75\x77\xb7\x13\x47\xe1\x13\x39\x78\x1f\xc8\x97\x2f\xd9\xe0\x3e\xa5\xc4\x3f\x25\xfc\x84\x89\xa4\xa7\xe3\x31\x4e\x2c\x6b\x04\x6a\xb1\x95\xae\x0e\xaf\x17\xb5\xe8\xf5\x91\x3b\xdd\xe6\x78\xa8\x4e\x06\x1d\x80\x3f\x35\x6a\x36\x05\xbf\xa0\x98\xee\x1f\x66\x4d\xae\xad\x79\x70\x0e\x2e\x16\x42\x5c\x06\x62\x4e\x72\x9e\xab\x62\x34\xa7\xcc\x0d\x09\x75\x4f\x6d\xad\x3c\x32\x13\xd5\xf0\xdf\xd6\xc5\x23\x33\x41\x0d\x9f\x6e\xad\x3c\x32\x13\x05\x80\xa5\xf2\x80\x01\x0d\x86\x5f\xcc\x84\x00\xa6\xe2\xb1\x94\x52\x48\x8e\xa6\xcc\x04\x35\x76\xdc\xb5\xfa\xc8\x4e\xa9\x42\x1c\x6b\x99\x61\xbb\x1a\x73\x6e\xdd\x78\x34\xf2\x7c\x41\xc0\x3a\x4d\x91\x61\xee\x6c\xd3\x5f\xe2\xa0\x19\xa2\x46\x69\x96\xc3\x66\x9f\x9e\x83\x2d\x80\xe8\x53\x94\xa9\x89\xf1\xa3\x23\xd8\xa1\xe8\xd5\x1a\xb9\x0e\x42\xad\x23\xa0\xcf\x2a\xb2\x30\x01\x45\x30\xef\xcc\x11\xa2\x31\x3a\x1c\x33\xbd\x02\x27\x7b\x62\x3c\x37\x31\x96\xc3\xef\x96\xd3\xa3\x89\x5e\x24\xc1\xa6\xb2\x25\x7e\x40\x62\x07\x5c\x4f\xa0\x0a\x35\x7c\x7b\xa6\x7c\xab\x6b\x9f\x07\x92\x2f\xe3\xd9\x03\x5d\xc6\xbb\x07\x1a\xc3\xc3\x57\x34\xb6\xf3\xf2\x81\x06\x7b\xfa\x40\x23\x79\xee\x40\x23\x78\xef\x40\x23\x39\xa9\xfe\xbb\x5b\x8b\x75\x39\x27\x2b\xef\x1d\x68\xca\x75\x3a\xa7\x91\x7a\xa8\x5b\xdd\x0b\xb8\xf2\x40\x17\x70\xe7\x81\xcc\x9c\x4b\xce\x31\x76\x6e\x5f\x17\xf5\x74\xff\x94\xbe\x3d\xd0\x45\xfd\x7b\xa0\x69\x86\x61\xb8\x9f\x0f\x74\x11\x5f\x5f\x34\x69\x22\x14\x03\x64\xe5\xf7\x03\x71\xc7\x42\x7d\x20\xdc\x49\x33\xdf\x0d\xa8\x25\x88\x00\xeb\xb0\xb9\xa0\xea\x77\x94\x5c\x50\xdf\x91\xb4\x79\x05\x23\x46\x19\x80\x46\x8b\x34\x00\x8d\x18\x6d\x00\x1a\x25\xe2\x00\xd4\x12\x75\x30\x75\xf0\x81\xc6\x8c\x39\x00\x8d\x19\x34\x00\x42\x64\xe3\x0d\x0a\x1a\x74\x7c\x2f\xd8\x78\x6a\x9e\x43\x95\x63\x70\xb7\x96\x41\xa2\xe4\xd8\xe8\x3e\xb1\x2d\x6e\x12\xc0\xe6\x38\xb0\x95\x72\x3e\xfb\x03\xab\x99\xad\x8b\x7c\x7b\x9a\x2d\x20\x12\x75\xf6\xc2\xbe\x3c\xa6\xda\x07\x7f\xd8\xd4\x0d\x9b\x23\xfc\x46\x72\x83\xc5\x11\xfe\x27\x77\x50\x5f\x1b\x51\xd3\x53\x7a\x90\x77\x3e\xac\x8a\x09\xcd\x93\x4c\x20\xe5\xa4\xf6\xb0\x48\x47\x41\x7e\x5f\x51\x0b\x01\x76\xef\xa2\xd5\x82\x9a\xb3\x78\x2c\x16\x6f\x53\xc0\x71\xf4\x9b\x34\xfe\xc8\x5a\xed\x9e\xc1\xbe\xad\x14\x08\x7f\x6b\x3a\x60\x58\x88\x11\xed\xb5\x33\x65\x46\x73\xdc\x51\x8d\xd3\x7d\xd9\x36\xba\x1f\xff\xbb\xc9\x23\x61\x5d\x2f\x53\x8d\x0d\x1d\x60\xc6\x38\xc0\x8f\x97\xd5\x5a\x38\xa7\x46\x0e\xf7\x54\x4e\xb6\xbd\xfa\xfd\x9a\x4d\xe8\x4f\xd7\xc6\x6b\x64\x37\x1a\x79\x86\x32\xa5\xbb\x7c\x61\x17\xb9\xdd\x04\xc6\xf7\x8c\xc7\xeb\x59\x7b\x0f\xd8\xac\xad\xe6\x8e\xef\xe0\x9c\xb2\xce\xef\x0d\xda\x9d\x84\x63\xb0\x7d\x00\xe8\x4b\x15\xe8\xab\xc3\xba\xcc\x7a\xde\xb6\xa5\xcc\x5e\xeb\xe0\xe0\x21\x88\x5a\x19\xbf\xfc\xd3\x1f\x7b\x38\x1b\x58\x99\x67\x65\xf3\x01\x00\xed\x69\xa2\xf9\x50\xdf\xe1\x36\x00\x15\xc1\xd7\xe6\xfc\xab\x03\xac\xdb\xea\xb0\xfd\x59\x30\x92\x9c\x15\xe7\xa2\x3a\xac\x6d\x07\x2f\x93\x1f\xc7\xcf\xfe\xc3\xe8\xfa\xbb\xef\xae\xbf\xfe\x5a\xe3\xfb\x39\xac\x6f\xcb\x0a\x65\x47\xeb\x58\x82\x48\x61\x11\x5d\xdc\x0d\x1f\xf0\x9f\xa3\x5c\x75\xf1\x95\x1f\x3a\xfc\x08\x11\x05\xca\x06\x43\x7c\xe8\x50\xfe\x1c\xfe\x8d\xc3\xb1\x90\xf5\xfc\x79\xc3\x80\xcf\x1b\x78\x37\xba\x91\xc7\x67\x5d\x6b\x38\xa8\x2c\xd1\xef\x4b\xbd\x28\x0b\xea\x2f\xa8\x53\x2a\x75\xfe\xf9\xd5\xf7\xdf\x38\x16\x78\xb6\x9e\x19\xbb\x0d\x03\x66\x19\xc2\x30\x52\x0e\xbe\x95\x7a\x00\x7c\x4b\x20\xbe\x71\x9c\x1e\xf4\xf1\xdb\xdc\x44\xd7\x33\x1f\x3f\xed\xcb\x1c\x0a\x1b\x82\xa9\xe8\x9a\xe1\xe3\x66\x9e\x86\xf0\xa0\xea\x83\xa7\x1b\x01\xa0\x22\x8a\x83\x18\x06\x20\x8b\xa1\x00\xb2\x1f\x0e\xa5\xc1\x17\x72\x77\x78\x3c\x08\x42\x46\xa9\xcd\xb7\xda\x1c\x93\xb8\xf0\xd1\xb5\x21\x73\x99\x6e\x5f\xb5\x0b\x93\xcc\x93\x2a\xe7\xbb\xe0\x3d\xf8\x6a\x8e\x63\x02\xc4\xaa\x22\x56\xe9\x31\x0c\xb6\x74\x1e\x2c\x49\x1f\x08\x51\x09\x87\x26\x45\x07\x8b\x4f\x04\x98\x82\x38\xd1\x45\xdf\x0a\xbe\x5e\x5c\x84\x57\x67\xc0\x77\x0e\xcd\x3e\x5f\x05\x91\x4f\x3f\xfc\x70\x5f\xf9\xee\x61\xf5\xb0\x20\x2f\x36\x84\xe9\x6b\x3f\x10\x9f\x25\xf4\x10\xb3\x4d\xb0\x26\x77\xac\x31\xd1\x97\x18\x70\x53\x26\x27\x93\xeb\x32\x8b\xb6\xab\xec\xca\xc2\x86\x37\x31\x97\x8f\x47\x29\xe4\xfa\x16\xc7\xcc\xf6\x3b\x35\xec\x20\x98\xf1\x97\x60\x96\x83\x17\x44\xf7\xb1\x64\xb7\x39\x0f\x74\xc4\x55\x56\xf8\x9a\x2b\xcc\xbc\x0b\x2c\xd9\x95\x8b\xb4\xec\x6a\x27\x8e\x4f\xf9\x45\x54\xa8\x1b\x40\x81\x3a\x7c\xa9\x3a\xc9\xdb\xcb\x78\x1f\xcb\x47\xf8\xd3\x1f\xc9\xac\x8e\x2f\x9e\x99\x2d\xe8\xee\xb9\xfa\x68\x2d\x97\x32\x9c\x80\x72\x77\x50\x04\x14\x0f\x4d\x6d\xab\x98\x65\xeb\xda\x43\x93\x09\x52\xb1\x72\x93\xb1\xfe\xf6\xfb\x37\x6f\xbf\xfa\xfb\xb7\xdf\x10\xb5\x2d\xf9\x43\xa3\xc1\x50\x15\x31\xd1\x80\x5f\x60\x59\x6d\x85\x09\x7b\xbe\x40\xc8\x09\x4a\xc8\xd4\xd7\xd5\x8a\x77\x93\x1d\xb9\x95\xdd\xa1\xb8\x34\xcc\xac\xb4\x17\x79\xaa\x2b\xe5\x06\x2e\x8c\x31\x0a\x75\xf0\x4b\x36\xeb\xd5\x8e\x74\xc3\x66\xc0\xfc\x3a\x56\x50\x46\x87\xc4\xbe\xc7\x7e\x28\x0a\xf7\x5c\x49\xc3\xbd\x2b\x7e\x7d\xc7\xbf\xe8\xee\x14\x7a\xc9\x1c\xc4\x15\xe7\xf4\x5c\xf8\xea\xac\x0d\x53\x55\xa1\xba\x9c\xac\x60\x34\x6a\x7c\xaa\x2c\x10\x06\xa9\xe6\x0c\x85\x35\xa0\x61\xb9\x9b\xd6\x2a\x64\x43\x6b\xaf\xf8\x87\xcc\x05\xd4\xaf\xb3\xec\x4e\x81\x4f\xdd\xc7\x38\xf1\xbb\x4a\x94\x76\xff\x4e\xed\x90\x9e\x9e\x44\x45\xc4\xdb\x9b\xa5\xbf\xad\xa8\xe1\xc7\x68\x2f\x0e\x04\x2a\xdc\x47\xb8\x8d\x12\x33\xdf\xc5\x0d\x82\xc6\x57\x51\x4a\x2a\x6e\xa4\x64\x4b\x04\xef\x34\x93\x4c\x0f\xe7\xc1\x91\x7f\xe5\xcc\x78\xd6\x73\x8d\xf5\x6c\xc0\xea\xc2\x45\x2e\x4e\xc9\xca\x7f\xe3\xf9\x73\x00\x90\xf3\x9b\x26\xb8\x88\xfd\x5f\xd1\xdd\xc7\x6e\x93\x44\xb9\xc8\x32\x67\x96\xb7\xc4\xe9\xf8\x3e\x22\x6e\xe7\x1c\xcb\x89\xcd\x4f\x2c\x0d\x7c\xd8\x9c\xe5\x53\x3f\xb1\xab\x6e\x09\xd7\x9a\x3b\xab\xb9\xb2\x9a\xa6\x18\x46\xaa\x3a\x36\x1b\xb8\xf2\xb8\x58\xd4\x90\xfb\x0d\xdf\xe3\xaa\x88\xbc\x7e\xb1\x76\xf1\xb6\xbb\x9d\x95\x9d\xb2\x77\xf5\x57\x17\xd8\xaa\xf4\x8e\x25\xb6\xa3\xad\x22\x2f\xc3\x95\xaa\x9a\x34\x76\xa0\x9a\x95\x2e\x32\xd3\x59\xc5\xe6\xb5\x21\xc2\x20\x91\x0a\xb4\xcf\xaf\xfe\xae\x5d\x68\x04\xf7\xd0\xf5\x4c\x9c\x01\x1d\xa0\xd6\x6e\xd7\x7c\x8e\x85\x84\x98\x29\xc1\x90\xaa\xa8\x35\xfe\x11\x3b\x91\x1e\xfe\x14\xd5\x95\x3e\x53\x4f\x9f\x42\xa1\x1a\xea\x18\x8e\x2b\x5a\x14\xe8\xc7\x15\xdd\x8b\x91\x4c\x37\x6a\x4a\x14\x22\x75\xbd\x71\x11\xb0\x32\x00\xaa\x28\x37\x67\x9b\xc1\x94\x02\xa3\x98\x41\x94\x02\x9f\x98\xc1\x93\x02\x9b\x58\x40\x13\x25\x30\x61\x0e\x4b\x0a\x4c\x62\x0a\x49\xda\x2e\xd6\x56\x70\x09\x26\x0b\xf3\x19\x98\x0c\x05\x26\xa3\xdd\x63\xcd\x1b\x31\xcd\x5d\xd6\x40\x16\x6b\x66\x90\xba\x90\x2d\x84\xbb\xdc\x75\xb2\x2c\x17\x0b\xc5\x91\x49\x18\x13\x5c\xcd\x8d\x15\x6b\x97\x95\x62\xd8\x15\x66\x29\x29\x17\x03\xcb\xe3\xa2\x10\xb9\x79\xd6\x80\x22\x87\x51\xd8\x0d\xb4\xf0\x2d\xb4\x3d\x2a\xd1\x19\x66\x5e\xe0\x70\x04\xe4\x6f\x3f\x1d\xee\xe2\x50\x80\xa8\x76\xb8\xab\x09\xdd\xe4\x67\x83\xee\x43\xab\x10\x03\x01\xe7\x56\x01\x9a\x5c\x75\xa0\x26\xac\x13\xad\x5a\x4a\xc5\xb0\x17\xd0\x8f\x0f\xe7\x14\xe1\xa6\x41\x20\x65\x1e\xf7\xb4\xed\xbc\x58\x18\x37\x0d\xb7\xa2\x9a\x9a\xa5\x51\x9f\x98\x64\x8d\x8f\xa0\x3a\xa2\xb7\xb5\x06\x56\xd5\x44\xc8\x84\x6b\x67\xeb\x95\xe1\x26\x3d\x90\x1d\x72\x06\x2a\xd1\x33\xfc\xa7\x45\x38\xb8\x36\x2c\xda\xa7\xa2\x61\x39\xab\xf0\xe7\x5f\x0a\x24\x96\x56\x6b\xc2\x5b\xe0\x62\x47\xe8\x8d\xf8\xff\xd3\x0a\xe6\x12\x18\xcb\x59\x83\x66\x9d\xc1\xbd\x88\x52\xe7\xc6\x9a\xc4\xde\xe1\x8e\xef\x10\x08\x17\xc8\x12\xe5\x8a\x2a\x06\x20\x5d\x20\x1b\xb4\xdb\x5a\xf1\x85\x2f\x05\x81\x64\xcd\xc3\xd3\x77\xf0\x7f\x38\xcd\x16\xe2\xff\x72\x9a\xcf\xf6\x3c\x4d\x1b\x9f\xe8\xcb\x53\xe1\x92\xd5\x2e\x9e\xff\xc2\x33\xc0\x58\xef\x2b\xf9\x80\xf9\x77\x17\xed\xfb\x22\xf2\x56\x82\x09\x41\x35\x90\xc9\xb6\x65\x84\xa8\x4d\x74\xb7\xca\x1d\x37\xd1\xdc\x28\xaf\x79\x12\x58\x8d\x15\x3d\x28\xe1\x7b\x02\x5c\xcd\xf5\x46\x4c\x66\xdb\xed\xd1\x38\x56\x0d\x19\x03\x6d\xc1\xd0\x38\xf4\xd9\xbe\x20\x12\x0a\x96\x44\x13\x1a\xe5\x57\xca\xf3\xd4\x83\x17\x98\x74\x2e\x24\x98\xe7\x45\x0b\x27\xc7\x14\x42\x34\x91\x9a\xba\x74\x2c\xb9\xba\x58\x24\xda\xaa\xc2\x98\x00\x4c\xd6\x53\xe6\x8f\xe5\x69\x6d\xfe\x87\x25\xb9\x15\xfd\x3f\x1e\x84\xb3\xec\x51\x9d\x92\xda\x74\x37\x14\xaa\x6b\x53\xee\xff\x1f\xc4\x89\xe9\x66\x03\xe1\xc4\x0c\xbd\x38\x80\xab\x4c\x26\x1b\x18\x87\x4f\xba\x7a\x46\x6d\xed\x34\x02\x6a\x1b\x35\x5e\xc9\x1b\x34\x1d\xbc\x9a\x1a\xfd\x0c\x58\x7c\x4d\x54\x7c\x06\x3b\xf5\x12\xf6\x60\x07\xfd\x9f\x5c\x0f\x18\x5b\xf5\xe0\x75\x82\xc0\xa0\xd1\xe1\xed\x63\xc0\x76\x37\x37\xf3\xee\x14\xa0\x86\xfb\x3a\xa7\x7a\x7e\x9e\x67\x73\x69\xbe\xfe\xe9\xcf\x4b\xd0\x49\x30\xff\xb2\xec\x7f\x01\x00\x00\xff\xff\x55\x64\x90\xe9\x38\x85\x00\x00")
+var _webJsComponentsOthersJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x5d\xeb\x6f\xdb\xc8\x11\xff\xee\xbf\x82\x61\x0f\xa1\x88\xa3\xe5\x28\x87\xb6\x80\x04\xa1\xb8\x22\x6d\x90\xa2\x97\x1c\x9a\x14\x87\x22\x30\x08\x5a\x5c\xcb\x3c\x53\xa4\x8e\xa4\x94\x18\x77\xfa\xdf\xbb\x0f\x3e\x96\x0f\x71\x67\x96\xa4\x9d\xf6\xa2\x2f\xb6\xc8\x9d\xd9\xd9\xd9\xd9\xd9\xdf\xcc\x3e\x74\x75\xf5\x13\xb9\xb9\x8b\xe3\xfb\xf4\xe2\xe8\x25\x46\xf1\xc5\x58\x1b\xb7\x87\x68\x93\x05\x71\x64\xcc\x12\x92\xc6\xe1\x91\x38\x46\x42\x7e\x26\x9b\xcc\x36\x7e\xbd\x30\xe8\x87\x95\xcf\xc8\x6e\x1f\x7a\x19\x71\x0f\x49\x48\x69\xac\x4d\xbc\xdb\xc7\x11\x89\xb2\xf4\xea\x53\xce\x6a\x7e\x97\xed\x42\x6b\xc5\x49\x12\x92\x1d\x92\xc8\xb8\x25\xd9\xe6\x6e\x26\xd3\xda\xfc\x35\xfb\xcc\xb3\x3b\x12\xcd\xaa\xca\xcb\x52\x54\x8a\x7d\x51\x75\xf1\xc9\xf9\xd5\xca\xcc\x33\xf2\x39\x9b\xd9\xab\xb2\xe0\xc9\x3e\xc7\xb3\xcd\x8e\x37\x74\x56\x7f\xca\x3e\x05\xc5\xb2\xfc\xcf\x69\x95\x49\xe2\x03\x2b\xd0\x26\x66\x1f\xdf\xcb\xbc\xa5\xa4\xd3\x2c\xf1\xa2\x34\x60\xff\xdb\x67\x28\x0a\x15\xef\xbd\xec\x6e\x65\xf4\x16\xf1\xf6\x7b\xf7\x9e\x3c\xd0\x0e\xa8\xd8\xce\xb3\x78\xbe\xf7\x12\x6f\x97\xce\xf3\xd7\xab\xb3\x3c\x82\x5b\x63\x16\xa4\xf3\x34\xf3\x92\xec\xa7\x20\xbb\x9b\x35\xd9\x64\x77\x8e\x75\x45\xd9\x14\x9d\x6a\xd9\x7d\x52\xb3\x0f\xa3\x61\x06\x71\x15\x97\x44\x29\xe7\x60\x19\xdf\x1a\x4a\x81\x4e\x06\x09\x53\xa2\x53\xc5\x36\x8c\x6f\xbc\xc2\xdc\x3a\x59\x9f\x7d\x53\x33\x4e\xde\x66\x85\x00\x3b\x92\xdd\xc5\xfe\xd2\xb0\x5e\xff\xed\x83\xe5\xf4\x16\xdd\x24\xc4\xa7\xa3\x22\xf0\xc2\x94\x96\x4f\xbd\x1d\xb9\x8c\x93\x60\x1b\x44\x16\x35\xcf\x5e\xca\xa6\xed\x32\x43\xea\x1c\x0b\x3d\x4d\x2a\x69\xe6\x3f\xa7\x71\x34\xeb\xaf\xb0\x3d\x5c\x18\x39\xa2\x36\x75\xc1\x62\x40\x54\xdf\x96\xfc\xbb\x03\xa2\xdc\xc5\xbe\x17\xba\x59\x90\x85\x84\x53\x46\x87\x30\x84\x51\xe6\x56\x52\xd6\x09\xa7\x2c\x46\x58\x41\x99\x7f\x87\x11\x53\xff\xe6\x92\x24\x89\x13\x74\xb5\x29\x49\x8e\x24\x29\x88\x05\xa5\x92\xf0\xb4\x52\xf4\x6f\xf7\xfb\xf6\xc0\x38\xb5\x85\x14\x26\x9f\x9e\xf3\x72\x9e\xef\xbb\xb9\x92\x65\x67\xc7\x06\x53\x9f\x01\x65\x77\xd4\xfb\xc8\xdd\xba\x36\xbe\x09\xe3\x8d\x17\x92\x99\x3d\xcf\xf9\xc9\xef\xe7\x52\x3d\xab\x7e\xae\x72\x97\xaf\x01\xa6\x69\xe6\x3d\x6b\x2e\x0d\xd3\x54\x77\x92\x99\x6e\xe2\x3d\x31\x59\xdf\x80\x8a\x53\x0f\xbb\x25\x99\xc9\x8a\xef\x0f\x37\x07\x08\x09\xb5\x1e\x53\xd8\x0d\x4c\xa0\xcc\xcb\x0e\x29\xab\x61\x71\xa1\x69\x25\x6c\x3a\x10\xce\x95\x79\x57\xe1\x51\xdd\xd2\xf3\x2b\x94\x28\x6b\x7d\xce\xb5\xc3\x35\xff\x62\xa0\xb3\x3f\xc3\x76\xb1\x82\x53\x49\xb3\x24\x7b\xac\x9e\x84\xba\x87\x49\x77\x1f\x10\x3f\xc8\xba\x4c\x3f\x7f\x34\xaa\xf5\xd3\xff\x83\xdb\x07\xad\x01\xb0\x09\x29\x3a\x2b\x85\x5a\x61\x5a\x98\x7a\x47\xd2\xd5\x42\x15\x7e\x39\xee\x72\x8d\xaf\x7a\x8b\x09\xcf\xd2\x5f\x26\x21\xbf\x1c\x82\x84\xb8\x37\xb1\xaf\x40\x33\x6d\x9d\x22\x5c\x8a\x0d\x9a\xf7\xf3\x2f\x74\x88\xfc\xf8\xee\xfd\x07\xab\xdf\x10\x65\xc9\x29\xc9\x3f\xde\xbf\x7b\x4b\xe1\x56\x12\x44\x5b\xda\x93\x33\x94\x5b\x3a\xee\x9a\x16\x8d\x72\x53\x14\x13\xa6\xe4\x4d\x94\xcd\x24\x3e\xfc\xad\x8d\x72\x5f\x12\xb5\x78\x88\xf2\x64\x12\x35\x7d\x88\xf2\x6a\x9d\xf2\xf3\xb7\xb6\xd6\xb4\x27\x79\x20\x3d\xc3\xa9\x8f\x46\xb4\xed\xfc\x7b\x72\xd3\x61\x66\xd3\x52\x3b\xcc\x6c\x42\x2f\xcd\xdc\xc3\xde\x67\xb1\x55\xe0\xd7\x8d\xaf\xfe\xce\xf9\x6a\xc2\x4f\x6b\xc2\x67\xdf\x88\x78\x46\x8e\x92\x2c\x68\x60\x23\xfe\xf6\xb7\x8d\x99\xe5\xb2\x66\xa4\xba\x91\xd0\x05\x34\x22\x81\xc7\x40\x67\xe2\x9f\x15\xae\x2a\x55\x2d\xcc\x6f\xb0\x72\x79\x27\x32\x97\x91\x25\x07\x02\x09\x99\x52\x92\x7d\x08\x76\x24\x3e\x64\x33\xf3\x53\x10\xf9\xf1\xa7\x39\x73\x36\x3c\xf2\x4e\x48\x18\x7b\xfe\xcc\x36\x1d\xe3\xbb\x17\x2f\x6c\x05\xaa\xaf\x5c\x58\x2d\x92\xe7\x72\x6d\x62\x9f\x38\xd4\x78\xc5\x60\xdd\xc4\xd1\x6d\x18\x6c\x32\xd3\x86\x48\x48\x6d\xb4\x16\x85\xac\xb9\x36\xe7\xbb\x74\x3b\x5c\xa0\x1b\xcf\x77\xf7\x31\x75\x23\xec\x21\x58\x9c\x2a\x9c\xea\x72\xcd\xe5\xdb\xe1\xe2\x45\x71\xe6\xee\x49\xb2\x0b\xb2\x8c\xf8\x6a\xf1\x20\x2c\x65\x55\xea\xea\xbf\xdd\x66\xb9\xc0\x38\xbd\xc2\xc6\x33\x49\x01\x26\x72\xea\x19\x4a\xd0\x30\xf3\xe2\x9c\x93\x63\xff\xd3\x38\xe5\xe2\xea\xea\x3d\x6f\xa1\x21\x06\x18\xcf\x54\x8a\x27\xef\xf3\x11\x57\xe2\x51\xcd\x64\x65\xae\x41\xc1\x7f\x50\xc6\x72\x82\x84\xe5\xd3\xe7\x2b\x81\xe9\xca\x9a\xb2\xf8\x8c\x13\x51\x93\x4a\xd9\x7c\x33\x5a\x06\x6c\x94\x04\xd8\x0a\x95\x01\x03\x27\xc0\xb8\x91\x3d\xec\x89\xbb\xa3\xd8\x88\xb0\xb1\x1a\x91\x4f\xc6\xf7\x49\xe2\x3d\xa8\xea\xac\x91\xc7\xb4\xfa\xc4\xd0\x21\x67\xfa\x36\x4a\x74\xc9\xd2\x45\x6a\xba\x5b\xea\x55\x66\x8c\x38\xf0\x3f\x1b\x81\x50\xd6\x1c\xda\x64\xf6\xe1\x95\xae\x2b\xba\x8f\x94\xd1\xf5\x0a\x44\xca\x1c\xd1\x33\x46\xcf\x09\x5d\x3a\x06\x53\x85\x8d\x75\x55\x5e\x23\x2e\x30\x32\x47\x59\xb3\xd6\x6b\x3a\xc6\x13\x1b\x26\xdc\x09\xdc\x84\x9a\x2f\xe5\x55\xb2\x7e\x74\x0c\x4b\x18\x82\x65\x63\x5a\x24\x59\xd0\x7c\x7f\x48\x05\x43\xa8\xc8\x90\x5c\x4a\xab\x32\x6e\x6f\xf8\xba\xd4\x99\xc8\x8b\x0b\x44\xfd\x69\x9c\x64\xd5\x98\xf3\x16\x8e\xe1\xbd\x84\xea\x8d\x75\x82\xf7\xb2\xd6\xd1\xf3\xd2\x1e\xd6\x86\xb7\xe8\x7c\x85\xe9\x95\xdc\x93\xd0\x4a\x78\xe0\xb3\xb9\x23\x9b\x7b\xf7\x90\x6d\x8c\x4b\xc6\xbd\xfe\x6c\x4c\xf3\xaa\xea\xed\x6c\xdc\xe5\xb9\xb6\xa9\x65\x60\xb3\xab\xb2\x50\x39\xa8\x59\x12\x47\x32\x4c\x0a\x22\x29\x4c\x9d\x55\xdd\x07\x30\x1a\xf4\xfa\x80\x58\x15\xf8\x92\x13\xde\x41\xea\x52\xa4\xc4\x72\x01\x71\xae\x19\x69\xd2\xe4\x63\xa9\xa7\xb9\xcc\x68\x5b\xfe\x49\x36\x5b\x9e\x8c\xa8\xdc\xea\x8b\xeb\xce\x92\xbd\x8d\x7f\xfe\xbc\xed\x21\xe7\x69\xb0\x85\xf0\x67\xc5\x80\x11\x16\x8b\x77\x86\xac\xb9\x51\x54\x40\x56\x63\xe2\x45\x0a\xe4\x82\x34\x0b\x36\x39\x58\x2c\xbe\x7a\xe1\x2b\x61\xcb\x43\xf1\x62\xc9\x9f\xc7\x2e\x5f\x11\xa3\x26\x62\x64\x6a\x66\x41\xa9\xc0\x2b\xf5\x65\x67\x1a\x81\x24\x0f\xcc\x22\xc9\x6a\xd2\xd5\x6f\x2e\x03\x14\x6c\xe5\x02\x53\x34\xe9\x45\x5b\x52\xc6\x64\xfd\x48\x8b\x11\xf1\xd6\x48\xcb\x80\x20\x22\x0e\x2a\xdc\x2c\xd8\x11\x03\x4e\x44\x22\x5f\x26\x81\x11\xf1\xcc\x00\x6f\x13\x90\x28\x4f\x7a\xf0\x04\xe9\x21\xf2\xc9\x6d\x10\x11\xdf\xf8\xed\x37\xa3\x78\x68\x29\x97\x8a\x1a\x2d\x5c\x1b\x5b\x92\xfd\x95\x50\x38\x4a\xe8\x20\x25\xb3\x3f\x2b\xa6\x94\x46\x5b\x9b\xe4\xaa\x8c\x49\xa3\xc5\x6b\x59\x94\x6f\x0d\x8b\xce\xac\x6c\xab\x42\x51\xc1\xc0\xe5\x2b\xa9\x32\x0e\x94\x49\xbf\x6c\x35\xad\x54\xb4\xf3\xf4\x70\x43\xe1\xeb\xec\x85\x63\x2c\x54\xcd\xab\x69\xa6\xcd\x62\xf1\xd2\x31\x5e\x2e\xb4\x12\x8a\xa5\xb9\x70\x29\xd9\xa2\xd2\x1d\xe3\xcc\x95\x5e\x09\x6e\x03\xec\x8d\xca\x28\x56\xa5\x2a\x06\x85\xd8\xf6\xb0\xdd\x2a\x86\x25\xb9\x68\x35\xfc\xfe\xbd\x07\xae\x03\x32\x98\x65\x04\x19\x68\xc5\x8e\x7c\x70\x88\xd0\x43\x8a\x1f\xaf\xc1\xe1\x0e\x20\x06\x81\xc4\xcd\xf2\x5e\x22\xa6\x03\xde\xff\x97\x2c\x53\x1a\x6c\x2f\x99\xfe\xe3\x28\x25\x7c\xef\x92\xcf\x60\x12\x03\x04\xd4\x47\xfc\xa5\x32\xf7\x35\x7f\x57\x8d\x0a\xfa\xf6\x79\x61\xcb\xd5\x3b\x66\xf0\xec\xcd\x21\x0a\xb2\xf5\xc2\xb7\xc0\x98\xb9\xb6\x29\xa9\xb1\xf1\x68\x80\x85\x8e\x61\xa9\x83\x2c\x76\xb0\xe5\xd6\xe6\x12\x8e\xc5\x56\x60\x9a\x81\x56\x5f\x7c\x3a\x66\x76\xcb\x5a\xa1\x58\x74\x20\x0a\x2c\x0b\xd1\x78\xe9\x81\x94\x90\x81\x73\x42\xe7\x0f\x3a\x1d\x72\x95\x4d\xc6\xe6\xd0\x27\x52\xce\x79\x29\x77\xe9\x96\x4e\x16\xe5\x5c\x41\x83\xa5\x8c\x4e\x9d\xde\x4d\x48\x0c\x1a\x83\xa5\x79\xda\x39\xf0\x89\xa5\x25\x7b\xd3\x3e\xe4\xec\x7d\x3d\xe7\x2c\x95\xc2\xb7\x0d\xe0\x07\x71\xab\x00\xb8\x45\x00\x9c\xba\xcd\x38\x0a\x1f\x8c\x9d\xf7\xd9\xf8\xee\x05\xed\xdc\x87\xd4\xf0\x0f\x09\x5f\xf2\x32\xd2\xc3\x7e\x1f\x27\x9a\x35\x9e\xb1\x95\x73\x0a\x6f\x16\xd5\xd0\xfa\xc8\x4a\xd7\x59\x1e\x1a\xa0\x00\xf8\xaa\xd1\x00\x73\x3b\x61\xe7\x8f\x5f\xd1\x8e\xb3\xf6\x60\x99\x3b\x42\x07\xe7\x3c\xc5\x38\x97\xd9\xf8\x48\x0e\xcd\x48\x6d\x29\x3d\xc2\xb1\x6a\xc5\x6f\xcb\xf2\x91\x83\x55\x4e\x3d\xa6\x5b\x4a\x8f\x70\xac\x18\x60\x69\xe8\xd9\xa2\xf8\x05\xc7\x84\x61\x2a\x9e\x4b\x91\xb4\x9c\xa3\x29\x67\xd8\x8c\xbb\x94\x1f\xe9\x09\x55\xb2\xa3\x2d\xb3\x9c\x61\xb3\xf7\xb2\xf5\x68\xe4\xf1\x02\x80\x75\x8a\x22\xc3\xc2\xd9\x76\xbc\xc4\x41\x33\xcb\x1a\xa5\x59\x01\x9b\x7d\x72\x0c\x36\x0c\x44\x1f\xa2\x4c\xde\xf6\x3f\x3a\x82\x1d\x8a\x5e\xb5\x91\xeb\x20\xd4\x3a\x02\xfa\xac\x23\x0b\x0c\x28\x62\xe3\x0e\x8f\x10\xd1\xe8\x70\xcc\xed\x15\x5f\x04\x9e\x9b\x18\xcb\x9d\xb0\x40\x7d\x42\x34\xd1\x8b\x24\xe8\x50\xd6\xc4\x0f\x40\xec\x00\xd3\x04\xa8\x50\x2b\xb6\xa7\xc2\x77\x86\xf6\x45\x22\xf9\x71\x22\xfb\xc7\x8b\xee\xc7\x8a\xf0\x07\x47\xf9\xa3\x44\xfa\x23\x46\xee\x23\x45\xef\x23\x06\xa9\xfe\xc7\xc5\xb5\x5e\xe4\xae\x1d\xbd\x4f\xed\xa7\x47\xd6\xd0\x93\x86\xf2\x8f\x14\xce\xe3\x83\x4b\xe8\x94\x30\x38\xac\x57\xa8\x7f\xca\xd8\xfe\xd1\xe3\xfb\xe9\xba\x61\x78\x9c\xff\x68\xb1\xbe\x4e\x7a\x49\x67\x46\xc1\xab\x80\x07\x16\xf5\x98\x94\xc1\x5a\x47\xcb\x77\x1b\x4d\x46\x0b\x0d\x46\xf5\x53\xa2\x9c\x51\xdf\x92\x34\xbe\x82\x11\xb3\x0c\xa3\x66\x1a\x46\xce\x36\x8c\x96\x71\x38\x93\x75\xb0\x34\xd8\x8c\x99\x73\x18\x3b\x69\x00\x1b\x74\xc3\x92\x06\xa7\xc9\xf6\x50\x15\x18\xdc\x6d\xec\x20\x91\xf6\xd8\xa8\x0e\x10\x97\xf7\x24\x40\xf7\x38\x50\x4f\x39\xb3\xfe\x40\x6b\xa6\x7e\x91\x4f\x4f\x96\xcd\x32\x51\x47\x2f\x3c\x28\x76\x9b\x48\xc7\x19\xa1\x5b\x37\x74\x96\xf0\x5b\x9b\x1b\x34\x96\xf0\xbf\xb8\x85\xfa\x46\x8f\x62\x57\xe9\x19\xbf\xe3\x6e\x5e\x0e\x68\xbe\xc9\x84\x6d\x39\x69\x3c\x2c\xb7\xa3\x00\xcf\x57\x34\x52\x80\xe7\x67\xd1\x7a\x41\xd0\x49\x0b\x3d\x01\x4c\x53\x3d\x49\xc3\x97\xac\x65\xf5\x0c\x8e\x6d\x73\x86\xec\xff\x95\xee\xc9\x10\xec\xed\x1e\xbd\x51\x3b\x15\x66\xb4\xc0\x1d\xd4\x38\xd5\x51\xbb\xd1\xe3\xf8\xdf\xcd\x3e\x12\xaa\xfa\x7c\xab\x31\x32\x00\xa6\x84\x03\xe2\xf8\xbc\x5a\x8d\xe0\x14\x15\x70\x4f\x15\x64\xeb\x8b\xff\x64\xf1\x74\xa3\xbf\x46\x0e\xa3\x4f\x98\x64\xf6\x34\xe1\xf2\x23\x87\xc8\xdd\x26\x30\x7e\x64\x3c\x9e\x66\xf5\x23\x60\x5c\x5b\xf1\x81\xef\xe0\x3d\x65\x27\xdc\xbd\x1a\xfb\x60\x73\xcf\xa0\x2f\x91\xa0\xaf\x0a\xeb\x52\xeb\xf9\xd0\xb5\x65\xf6\x52\x05\x07\x77\x41\xd4\x49\xf8\xdd\x9f\xfe\xd8\x43\xd9\xc2\xca\x7c\x57\x36\xef\x00\x26\x3d\x49\x14\xd7\x10\x98\xdc\x06\x58\x45\xec\xf8\x3b\x3f\x75\x00\x0d\x5b\x4d\x3a\x3f\x0b\x42\xa3\x20\x85\x85\xa8\x26\x6d\xdb\xce\xcb\xf2\xd3\xfa\xd6\x7f\xe8\xe7\xf2\x87\x1f\x2e\x5f\xbd\x52\xc4\x7e\x26\xd5\x6d\x55\x61\xae\x68\x15\x49\x10\x49\x24\x42\xc5\x17\x68\xf3\xd0\x3a\xe8\xf0\x23\xcb\x28\x10\xda\x19\xe2\xa0\x43\xf5\x75\xf8\x19\x87\x7d\xc9\xeb\xeb\xf1\x86\x01\xc7\x1b\xb8\x1a\xdd\xc8\xe3\xa3\xae\x33\x1d\x54\x95\xe8\x8f\xa5\x9e\x55\x05\xd5\xd7\xef\x49\x95\x9a\xff\xfc\xfe\xed\x6b\x53\x73\xa7\xb7\x8c\xc3\x37\x61\x40\x2d\x43\x18\x46\xca\xc1\xb7\x54\x0f\x03\xdf\x39\x10\x5f\x9b\x66\x0f\xfa\x78\x9a\x7b\xf6\xfe\x67\x6f\x97\x28\x6d\x88\x0d\x45\x17\x87\x8f\xdb\xfb\x34\x44\x04\xd5\xec\x3c\x07\x30\xff\x96\x59\x1c\x40\x37\x68\x76\xc5\xb0\xee\x78\xfc\x70\x87\xe7\x83\x58\xca\x28\xd5\x39\xab\xcd\x31\x89\xcb\x0e\x5d\x23\x89\xab\xed\xf6\x75\xbb\xc0\xec\x3c\xa9\x53\x7e\x0c\xae\x59\xac\x66\x9a\x18\x20\x56\x67\x31\x4f\xf7\x61\xb0\x21\xb3\xc0\x31\x16\xa3\x9e\xaf\x16\x0a\x16\x47\x04\xa8\x80\x30\xd6\xa5\x6e\x05\xdd\x25\x44\xa4\x13\x42\xf1\xe8\x73\x0e\x6d\x9d\xcf\x83\xc8\x27\x9f\xdf\xdd\xd6\xce\x3d\xcc\xef\x6d\xe3\xd9\xda\xa0\xf2\xea\x77\xc4\x37\x09\xd9\xc5\x74\x12\x6c\xf0\x1d\xef\x50\xf7\xa8\x2b\x3a\xad\xc5\x17\xdc\x65\xa0\x65\xdb\x65\x72\xc9\xb1\xc1\x4d\xcc\xe5\xfd\x51\x31\xb9\x5c\xc0\x88\xe9\x7c\x27\xa7\x1d\x04\x31\xfc\x8a\xcf\xaa\xf3\x82\xe8\x36\xce\xc9\x75\xd6\x03\x4d\x71\xb7\x16\xbc\xe6\x1a\x31\x57\x81\x26\xb9\x74\xb3\x97\x5e\xed\x86\xe9\x13\x7e\x33\x16\xe8\x7e\xd3\x9e\x58\xaa\x35\x67\x89\xbb\xd9\xb8\x8e\xd1\xab\x3f\x39\xb1\xdc\xbf\x70\x62\xea\xd0\xdd\x63\xd3\xac\x85\x2b\x73\x70\x1e\x4c\x66\x50\x3e\xc4\xda\x56\x39\xca\x96\x8d\x87\x98\x01\x52\xb3\x72\x4c\x5f\xbf\x79\xfb\xfe\xc3\xf7\x7f\x7f\xf3\xba\xa6\x8c\xe2\x21\xaa\x33\x64\x41\x30\x12\xf0\xeb\x39\xeb\xad\xc0\x90\x17\x0e\x22\x1f\xa0\x55\xfb\xa7\xba\x9b\xa0\x67\x31\x6e\x8c\x25\xb7\x4a\x1d\x52\x48\x43\xcd\x4a\x79\x4d\xa9\xec\x29\xd7\xec\xc2\x18\xfc\x15\xa2\xcd\x6a\x47\xba\x3f\x34\xa0\x71\x1d\x2d\x98\x67\x87\xc4\xbc\x47\xbf\x48\x02\x5f\x2b\xa2\x2b\x7e\x7d\xc7\xbf\xc8\xf6\x10\x7a\xc9\x8c\xb1\x2b\xd7\xe9\x39\xf3\xf9\xd1\xc6\x41\xf5\x7c\xb0\x32\xa3\x91\xf3\x53\x55\x81\x30\x48\x15\x6b\x28\xb4\x01\x2d\xcb\x5d\x77\x56\x91\x37\xb4\xf1\x8a\x1f\x64\x2e\xa1\x7e\x93\x64\x7b\x08\x7c\xe2\x7e\x8a\x13\xff\x5c\x89\xca\xee\x3f\xca\x0a\xb9\x1e\x78\x1a\xba\xbb\x59\xea\xdb\x8a\x5a\x71\x8c\x05\x0f\x5c\x96\xe2\xae\x4d\xc8\x78\x17\x57\x1a\xa2\x2f\xda\x94\xe7\xe1\xdc\x45\x70\xa5\x61\x76\x7a\x98\xf7\x66\xe9\x5f\x04\x31\x9c\xf4\xd8\x20\x3d\x22\x48\x5d\x76\x91\x8b\x59\x91\xf2\xef\x70\xfa\x02\x00\x14\xf4\xd8\x0d\x2e\x62\xfe\x97\x64\xf7\xa1\xd3\xa4\x21\xdd\xac\x59\x10\x8b\xef\x70\xfa\xc6\x2d\xa7\x05\x9b\xfa\x63\xc0\xde\x89\xc7\x8b\x89\x8b\x05\x50\x44\x48\x5c\x90\x7c\xd1\x0b\x80\x1d\x7a\x97\x93\x1d\x8d\x57\xe0\xd5\xbd\xfa\xac\x75\xb9\x00\x2f\xec\x8d\x7f\x9d\x26\xfb\xd0\x79\x20\xc9\xca\x15\xa1\xc1\x91\x29\x7e\xd3\x8e\x73\xf6\xf2\xfe\x72\x16\x00\x4e\xd0\x1c\x14\xd4\x43\x98\xe6\x3d\xeb\xe5\xdb\x15\x0c\x5a\xf4\x4e\x97\xf2\x8c\x54\xe7\x7e\x8d\x42\x22\x62\x23\x8b\x9b\x8b\x8a\x69\xec\x40\x31\x6b\x2a\xc2\xc9\x2c\x07\x33\x8d\x2e\x82\x40\xb7\x5a\x2c\x54\xdc\x04\xdf\xb8\x01\x8a\x5d\xdc\x67\x4f\xd2\x4f\x72\xed\x7a\xcd\xe7\xe0\x51\xb0\x99\x12\x3d\xca\x82\x6a\x03\x46\x31\x75\xab\xf1\x62\x59\x5d\x15\x64\xf6\xe8\x94\x15\x6a\xc0\xb4\xe1\x40\xac\x43\x80\x7e\x20\x76\xea\x55\x4a\x27\x47\xc1\x52\xa5\x8d\x47\x41\x77\x03\xb0\x9d\x74\x91\x3a\x0e\xd7\x95\xa0\x0e\x87\xe9\x4a\x40\x87\xc3\x73\x25\x98\xd3\xc0\x72\x52\x26\x07\x8f\xe3\x4a\x10\x87\xc5\x70\x5d\x57\xa3\x4b\x40\xce\x06\x72\x69\x5d\x56\xff\x15\xc5\x3d\x0d\x8a\x1b\xed\x52\xf3\xc9\x91\x58\xb7\xff\x83\x5d\x70\x0e\x15\x4e\xe3\x92\x73\xcd\xb9\x21\x48\x5d\xb6\x8d\x0c\x76\xeb\xef\x64\xdb\x9f\x34\x04\x07\xee\xce\x99\xe0\xce\x76\x28\x5b\xbd\xed\x4a\x48\x55\xe0\xf6\x2a\x3d\x5a\x50\x30\x2e\xda\xca\x41\x42\x03\x10\x73\xb8\x08\x05\x0a\x65\x44\xa7\xd4\x68\x8e\x42\x21\xe3\x02\x86\x97\xd8\xc6\xfe\x87\xdd\x4d\x1c\x0a\xb0\xd8\x0d\xeb\xd5\xd7\x16\x0a\xb9\xee\x3b\x99\x20\x18\x1c\x3b\x19\x28\x0e\x31\x74\xc3\x57\xd1\x2a\x27\x17\x0c\xfa\xcb\x04\xe3\xc3\xd6\xb3\x7d\xac\xf6\x6a\xd2\x38\xee\x69\xdb\xd1\xb6\xd1\x4d\x83\x79\x54\xac\x59\xa2\x74\x82\x39\x4e\x30\x82\xe8\x00\x6d\x43\x8e\x6a\xb4\x62\x00\x76\x1f\x71\xb3\x32\xd8\xa0\xd7\x8f\x10\xea\x51\x02\xfb\xad\x2e\x18\xa0\x1c\x96\x06\x96\x51\x7f\x3e\xaa\xe0\x0b\xa3\x12\xf4\xcf\xad\x16\x43\x5b\xe2\x7f\x53\xc8\x6d\x22\x88\xab\x00\x20\x1f\x35\x60\x52\x8b\x5d\x98\x99\xcb\xdc\xf2\x49\xf4\x1d\xf0\x62\x1b\x58\x85\x9a\x10\x7c\x30\x0c\xd7\x85\xe2\x83\xe0\xf8\x08\x90\x9c\x7d\xd8\x2e\xde\xdd\xc3\x0f\xec\xa7\xcb\x2c\x5b\xfc\x84\xd9\xcc\xba\xe3\xfb\xf7\xe1\x3b\xc0\xf9\x1e\xc9\x64\xbe\x8d\x67\xbf\xf2\xad\x81\x54\xfb\xd2\x46\xd1\xe2\x40\x4e\xf7\xbc\x08\xbc\xae\x62\x42\x50\x8d\x9d\xb6\x50\x88\x7a\x52\x54\x8d\x95\x1c\xb5\xe1\x7d\x12\x58\x3d\x2d\xb4\xc6\xe8\x43\xe3\x0c\x00\xe8\xc7\x13\xf4\x38\xa0\x73\xf2\x6c\x2b\x49\x57\xd2\x37\x0e\x7d\x3a\x2f\x88\x9d\x26\x8e\xa1\x48\x01\xf3\xdf\x1a\xe0\x7b\x52\x9e\x41\xf6\xf9\x01\xc1\x7c\x3d\xc8\xc1\x42\x88\x36\x52\x93\x5d\x87\xc3\xc5\x85\x22\xd1\x4e\x11\xc6\x04\x60\xc5\xba\x75\xb9\xb1\xb0\xd8\xef\xe8\x7f\x76\x8c\x85\xd0\xff\x78\x10\x4e\x53\xa3\x2a\x21\x95\xfb\x20\x41\xa8\xae\x4b\xb8\xff\x7f\x10\x27\x86\x9b\x0e\x84\x13\x23\xf4\xd1\x01\x5c\x6d\x30\xe9\xc0\x38\xf8\x6e\xbc\xaf\xa8\x6d\x32\xd4\x36\x6a\x32\x75\x62\x78\x35\x35\xfa\x19\xe0\x7c\xbf\x82\x9d\xa7\x01\x3b\xe0\xdf\x76\x1f\xd0\xb7\xf2\x02\xf3\x04\x89\x41\xd4\x22\xf5\xa7\x80\xce\x6e\x6e\xe6\xdd\x48\x40\x0d\x76\x6c\xab\xbe\x4f\xa0\xd8\xe6\xa7\x38\x16\xd6\xbf\xff\x42\xc5\x01\x7f\xe4\xf0\xbf\x8e\x0c\x88\x13\x2f\x88\x00\x00")
 
 func webJsComponentsOthersJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -600,7 +600,7 @@ func webJsComponentsOthersJs() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "web/js/components/others.js", size: 34104, mode: os.FileMode(420), modTime: time.Unix(1469584226, 0)}
+	info := bindataFileInfo{name: "web/js/components/others.js", size: 34863, mode: os.FileMode(420), modTime: time.Unix(1469584226, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
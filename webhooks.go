@@ -2,12 +2,24 @@ package main
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/mail"
+	"net/smtp"
 	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
 
+	"github.com/Instafig/Instafig/conf"
 	"github.com/Instafig/Instafig/models"
 	"github.com/Instafig/Instafig/utils"
 	"github.com/gin-gonic/gin"
@@ -50,11 +62,213 @@ func configUpdateHistoryToNotificationText(m *models.ConfigUpdateHistory, app *m
 			m.K, app.Name, m.UserName,
 		)
 	}
+	if m.Message != "" {
+		text += fmt.Sprintf(", message: %s", m.Message)
+	}
+	if m.Ref != "" {
+		text += fmt.Sprintf(", ref: %s", m.Ref)
+	}
+	return text
+}
+
+// status code of the response is returned, responses other than 2xx are taken as errors
+func readWebHookResponse(resp *http.Response) (int, error) {
+	defer resp.Body.Close()
+	_, _ = ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webHook target responded with status code %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// more than one texts are sent as a digest
+func sendNotificationToEmail(hook *models.WebHook, texts []string) error {
+	if !conf.SMTPEnable {
+		return fmt.Errorf("smtp is not enabled")
+	}
+
+	subject := "Instafig notification"
+	if len(texts) > 1 {
+		subject = fmt.Sprintf("Instafig digest: %d notifications", len(texts))
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", conf.SMTPFrom)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(hook.Recipients, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	for ix, text := range texts {
+		if ix > 0 {
+			msg.WriteString("\r\n\r\n----\r\n\r\n")
+		}
+		msg.WriteString(strings.Replace(strings.TrimSpace(text), "\n", "\r\n", -1))
+	}
+	msg.WriteString("\r\n")
+
+	var auth smtp.Auth
+	if conf.SMTPUser != "" {
+		host, _, _ := net.SplitHostPort(conf.SMTPAddr)
+		auth = smtp.PlainAuth("", conf.SMTPUser, conf.SMTPPassword, host)
+	}
+	return smtp.SendMail(conf.SMTPAddr, auth, conf.SMTPFrom, hook.Recipients, msg.Bytes())
+}
+
+// default message templates of non config events, executed with webHookEvent,
+// config events are described by configUpdateHistoryToNotificationText
+var webHookEventTemplates = map[string]string{
+	WEBHOOK_EVENT_USER_NEW:        `User {{em .TargetUser.Name}} is just created by {{.User.Name}}`,
+	WEBHOOK_EVENT_USER_UPDATE:     `User {{em .TargetUser.Name}} is just updated by {{.User.Name}}`,
+	WEBHOOK_EVENT_USER_DEACTIVATE: `User {{em .TargetUser.Name}} is just deactivated by {{.User.Name}}`,
+	WEBHOOK_EVENT_APP_NEW:         `App {{em .App.Name}} is just created by {{.User.Name}}`,
+	WEBHOOK_EVENT_APP_UPDATE:      `App {{em .App.Name}} is just updated by {{.User.Name}}`,
+	WEBHOOK_EVENT_APP_CLONE:       `App {{em .App.Name}} is just cloned from {{em .FromApp.Name}} by {{.User.Name}}`,
+	WEBHOOK_EVENT_NODE_SYNC_FAILED: `Failed to sync data version {{.DataVersion}} to slave nodes:` +
+		`{{range .Nodes}}
+    {{em .URL}}: {{.Error}}{{end}}`,
+	WEBHOOK_EVENT_NODE_EXPIRED: `{{range .Nodes}}Slave node {{em .URL}} has not checked master since {{utc .LastCheckUTC}}, ` +
+		`its data version is {{.DataVersion}} while master's is {{$.DataVersion}}{{end}}`,
+}
+
+// how names are emphasized in messages of each target
+var webHookTargetEmphasis = map[string]string{
+	models.WEBHOOK_TARGET_PUBU:  "**%s**",
+	models.WEBHOOK_TARGET_SLACK: "*%s*",
+}
+
+// data which message templates are executed with, diff is only for config events
+type webHookTemplateData struct {
+	*webHookEvent
+	Diff *configDiff
+}
+
+func newWebHookTemplateData(event *webHookEvent) *webHookTemplateData {
+	data := &webHookTemplateData{webHookEvent: event}
+	if event.History == nil {
+		return data
+	}
+
+	m := event.History
+	from := map[string]*configSnapshot{}
+	to := map[string]*configSnapshot{}
+	oldStatus, newStatus := models.CONF_STATUS_ACTIVE, models.CONF_STATUS_ACTIVE
+	switch m.Kind {
+	case models.CONFIG_UPDATE_KIND_HIDE:
+		newStatus = models.CONF_STATUS_INACTIVE
+	case models.CONFIG_UPDATE_KIND_RECOVER:
+		oldStatus = models.CONF_STATUS_INACTIVE
+	}
+	if m.Kind != models.CONFIG_UPDATE_KIND_NEW {
+		from[m.K] = &configSnapshot{K: m.K, V: m.OldV, VType: m.OldVType, Status: oldStatus}
+	}
+	if m.Kind != models.CONFIG_UPDATE_KIND_DELETE {
+		to[m.K] = &configSnapshot{K: m.K, V: m.NewV, VType: m.NewVType, Status: newStatus}
+	}
+	if diffs := diffConfigSnapshots(from, to); len(diffs) > 0 {
+		data.Diff = diffs[0]
+	}
+
+	return data
+}
+
+func webHookEventToNotificationText(hook *models.WebHook, event *webHookEvent) string {
+	if hook.Template != "" {
+		text, err := renderWebHookEventText(hook.Target, hook.Template, event)
+		if err == nil {
+			return text
+		}
+		logger.Error(map[string]interface{}{
+			"type":     "webhook_template",
+			"hook_key": hook.Key,
+			"error":    err.Error(),
+		})
+	}
+
+	if event.History != nil {
+		return configUpdateHistoryToNotificationText(event.History, &models.App{Key: event.App.Key, Name: event.App.Name})
+	}
+
+	text, err := renderWebHookEventText(hook.Target, webHookEventTemplates[event.Event], event)
+	if err != nil || text == "" {
+		return "Unkown Action"
+	}
 	return text
 }
 
-func sendNotificationToPubu(targetURL string, m *models.ConfigUpdateHistory, app *models.App) error {
-	text := configUpdateHistoryToNotificationText(m, app)
+func parseWebHookTemplate(target, tpl string) (*template.Template, error) {
+	emphasis := webHookTargetEmphasis[target]
+	if emphasis == "" {
+		emphasis = "[%s]"
+	}
+	funcs := template.FuncMap{
+		"em": func(s string) string {
+			return fmt.Sprintf(emphasis, s)
+		},
+		"utc": func(utc int) string {
+			return time.Unix(int64(utc), 0).UTC().Format(time.RFC3339)
+		},
+	}
+
+	return template.New("webhook").Funcs(funcs).Parse(tpl)
+}
+
+func renderWebHookEventText(target, tpl string, event *webHookEvent) (string, error) {
+	t, err := parseWebHookTemplate(target, tpl)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err = t.Execute(&buf, newWebHookTemplateData(event)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// events for previewing message templates
+func getSampleWebHookEvent(event string) *webHookEvent {
+	user := &webHookEventUser{Key: "sample_user_key", Name: "sample_user"}
+	app := &webHookEventApp{Key: "sample_app_key", Name: "sample_app", Type: models.APP_TYPE_REAL}
+	node := &webHookEventNode{URL: "127.0.0.1:17070", DataVersion: 9, LastCheckUTC: utils.GetNowSecond() - 3600, Error: "connection refused"}
+
+	sample := &webHookEvent{Event: event, User: user, DataVersion: 10}
+	switch event {
+	case WEBHOOK_EVENT_CONFIG_UPDATE:
+		sample.App = app
+		sample.History = &models.ConfigUpdateHistory{
+			Id:         "sample_history_id",
+			ConfigKey:  "sample_config_key",
+			Kind:       models.CONFIG_UPDATE_KIND_UPDATE,
+			K:          "sample_key",
+			OldV:       "1",
+			OldVType:   models.CONF_V_TYPE_INT,
+			NewV:       "2",
+			NewVType:   models.CONF_V_TYPE_INT,
+			Message:    "sample message",
+			Ref:        "SAMPLE-1",
+			UserKey:    user.Key,
+			UserName:   user.Name,
+			CreatedUTC: utils.GetNowSecond(),
+		}
+	case WEBHOOK_EVENT_USER_NEW, WEBHOOK_EVENT_USER_UPDATE, WEBHOOK_EVENT_USER_DEACTIVATE:
+		sample.TargetUser = &webHookEventUser{Key: "sample_target_user_key", Name: "sample_target_user"}
+	case WEBHOOK_EVENT_APP_NEW, WEBHOOK_EVENT_APP_UPDATE:
+		sample.App = app
+	case WEBHOOK_EVENT_APP_CLONE:
+		sample.App = app
+		sample.FromApp = &webHookEventApp{Key: "sample_from_app_key", Name: "sample_from_app", Type: models.APP_TYPE_REAL}
+	case WEBHOOK_EVENT_NODE_SYNC_FAILED, WEBHOOK_EVENT_NODE_EXPIRED:
+		sample.User = nil
+		sample.Nodes = []*webHookEventNode{node}
+	default:
+		return nil
+	}
+
+	return sample
+}
+
+func sendNotificationToPubu(targetURL string, text string) (int, error) {
 	data := make(map[string]interface{})
 	user := make(map[string]string)
 	user["name"] = "Instafig"
@@ -63,50 +277,563 @@ func sendNotificationToPubu(targetURL string, m *models.ConfigUpdateHistory, app
 	data["displayUser"] = user
 	json, err := json.Marshal(data)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	resp, err := http.Post(targetURL, "application/json", bytes.NewReader(json))
+	resp, err := webHookHTTPClient.Post(targetURL, "application/json", bytes.NewReader(json))
 	if err != nil {
-		return err
+		return 0, err
 	}
-	defer resp.Body.Close()
-	_, _ = ioutil.ReadAll(resp.Body)
-	return nil
+	return readWebHookResponse(resp)
 }
 
-func sendNotificationToSlack(targetURL string, m *models.ConfigUpdateHistory, app *models.App) error {
-	text := configUpdateHistoryToNotificationText(m, app)
+func sendNotificationToSlack(targetURL string, text string) (int, error) {
 	data := make(map[string]interface{})
 	data["name"] = "Instafig"
 	data["icon_url"] = `https://avatars0.githubusercontent.com/u/1274781`
 	data["text"] = text
 	json, err := json.Marshal(data)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	v := url.Values{}
 	v.Set("payload", string(json))
-	resp, err := http.PostForm(targetURL, v)
+	resp, err := webHookHTTPClient.PostForm(targetURL, v)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	defer resp.Body.Close()
-	_, _ = ioutil.ReadAll(resp.Body)
+	return readWebHookResponse(resp)
+}
+
+const (
+	WEBHOOK_EVENT_CONFIG_UPDATE    = "config_update"
+	WEBHOOK_EVENT_USER_NEW         = "user_new"
+	WEBHOOK_EVENT_USER_UPDATE      = "user_update"
+	WEBHOOK_EVENT_USER_DEACTIVATE  = "user_deactivate"
+	WEBHOOK_EVENT_APP_NEW          = "app_new"
+	WEBHOOK_EVENT_APP_UPDATE       = "app_update"
+	WEBHOOK_EVENT_APP_CLONE        = "app_clone"
+	WEBHOOK_EVENT_NODE_SYNC_FAILED = "node_sync_failed"
+	WEBHOOK_EVENT_NODE_EXPIRED     = "node_expired"
+
+	WEBHOOK_SIGNATURE_HEADER = "X-Instafig-Signature"
+	WEBHOOK_HIDDEN_SECRET    = "******"
+
+	// in seconds
+	WEBHOOK_DELIVERY_CHECK_INTERVAL = 10
+	WEBHOOK_DELIVERY_RETRY_BASE     = 30
+	WEBHOOK_DELIVERY_RETRY_MAX      = 3600
+
+	WEBHOOK_DELIVERY_MAX_ATTEMPTS = 8
+	WEBHOOK_DELIVERY_BATCH_SIZE   = 64
+)
+
+var (
+	webHookEvents = []string{
+		WEBHOOK_EVENT_CONFIG_UPDATE,
+		WEBHOOK_EVENT_USER_NEW,
+		WEBHOOK_EVENT_USER_UPDATE,
+		WEBHOOK_EVENT_USER_DEACTIVATE,
+		WEBHOOK_EVENT_APP_NEW,
+		WEBHOOK_EVENT_APP_UPDATE,
+		WEBHOOK_EVENT_APP_CLONE,
+		WEBHOOK_EVENT_NODE_SYNC_FAILED,
+		WEBHOOK_EVENT_NODE_EXPIRED,
+	}
+	webHookConfigUpdateKinds = []string{
+		models.CONFIG_UPDATE_KIND_NEW,
+		models.CONFIG_UPDATE_KIND_UPDATE,
+		models.CONFIG_UPDATE_KIND_HIDE,
+		models.CONFIG_UPDATE_KIND_RECOVER,
+		models.CONFIG_UPDATE_KIND_DELETE,
+	}
+
+	webHookDeliveryCh = make(chan interface{}, 1)
+	webHookHTTPClient = &http.Client{Timeout: 10 * time.Second}
+)
+
+func init() {
+	if conf.IsMasterNode() {
+		doEverTask(deliverWebHooksLoop)
+	}
+}
+
+type webHookEventApp struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type webHookEventUser struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+type webHookEventNode struct {
+	URL          string `json:"url"`
+	DataVersion  int    `json:"data_version"`
+	LastCheckUTC int    `json:"last_check_utc"`
+	Error        string `json:"error,omitempty"`
+}
+
+// payload posted to generic webhook targets, user is the one who made the change
+type webHookEvent struct {
+	Event       string                      `json:"event"`
+	History     *models.ConfigUpdateHistory `json:"history,omitempty"`
+	App         *webHookEventApp            `json:"app,omitempty"`
+	FromApp     *webHookEventApp            `json:"from_app,omitempty"`
+	User        *webHookEventUser           `json:"user,omitempty"`
+	TargetUser  *webHookEventUser           `json:"target_user,omitempty"`
+	Nodes       []*webHookEventNode         `json:"nodes,omitempty"`
+	DataVersion int                         `json:"data_version"`
+}
+
+// caller must hold memConfMux's read lock
+func getWebHookEventUser(userKey string) *webHookEventUser {
+	user := &webHookEventUser{Key: userKey}
+	if memConfUsers[userKey] != nil {
+		user.Name = memConfUsers[userKey].Name
+	}
+	return user
+}
+
+func newConfigUpdateWebHookEvent(m *models.ConfigUpdateHistory, app *models.App, dataVersion int) *webHookEvent {
+	if m.UserName == "" {
+		memConfMux.RLock()
+		m.UserName = getWebHookEventUser(m.UserKey).Name
+		memConfMux.RUnlock()
+	}
+
+	return &webHookEvent{
+		Event:       WEBHOOK_EVENT_CONFIG_UPDATE,
+		History:     m,
+		App:         &webHookEventApp{Key: app.Key, Name: app.Name, Type: app.Type},
+		User:        &webHookEventUser{Key: m.UserKey, Name: m.UserName},
+		DataVersion: dataVersion,
+	}
+}
+
+func newUserWebHookEvent(event string, user *models.User, opUserKey string) *webHookEvent {
+	memConfMux.RLock()
+	defer memConfMux.RUnlock()
+
+	return &webHookEvent{
+		Event:       event,
+		User:        getWebHookEventUser(opUserKey),
+		TargetUser:  &webHookEventUser{Key: user.Key, Name: user.Name},
+		DataVersion: memConfDataVersion.Version,
+	}
+}
+
+func newAppWebHookEvent(event string, app *models.App, opUserKey string) *webHookEvent {
+	memConfMux.RLock()
+	defer memConfMux.RUnlock()
+
+	return &webHookEvent{
+		Event:       event,
+		App:         &webHookEventApp{Key: app.Key, Name: app.Name, Type: app.Type},
+		User:        getWebHookEventUser(opUserKey),
+		DataVersion: memConfDataVersion.Version,
+	}
+}
+
+// signature is the hex encoded HMAC-SHA256 of the request body keyed by the hook's secret
+func signWebHookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func sendNotificationToGeneric(hook *models.WebHook, payload []byte) (int, error) {
+	req, err := http.NewRequest("POST", hook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if hook.AuthType == models.WEBHOOK_AUTH_BASIC {
+		// auth info of basic auth is in the form of "user:password"
+		userPass := strings.SplitN(hook.AuthInfo, ":", 2)
+		if len(userPass) != 2 {
+			return 0, fmt.Errorf("bad basic auth info of webHook: " + hook.Key)
+		}
+		req.SetBasicAuth(userPass[0], userPass[1])
+	}
+	if hook.Secret != "" {
+		req.Header.Set(WEBHOOK_SIGNATURE_HEADER, signWebHookPayload(hook.Secret, payload))
+	}
+
+	resp, err := webHookHTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	return readWebHookResponse(resp)
+}
+
+// payload is the json of webHookEvent stored in the delivery
+func sendWebHookEvent(hook *models.WebHook, payload []byte) (int, error) {
+	if hook.Target == models.WEBHOOK_TARGET_GENERIC {
+		return sendNotificationToGeneric(hook, payload)
+	}
+
+	var event webHookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return 0, err
+	}
+	switch hook.Target {
+	case models.WEBHOOK_TARGET_PUBU:
+		return sendNotificationToPubu(hook.URL, webHookEventToNotificationText(hook, &event))
+	case models.WEBHOOK_TARGET_SLACK:
+		return sendNotificationToSlack(hook.URL, webHookEventToNotificationText(hook, &event))
+	case models.WEBHOOK_TARGET_EMAIL:
+		return 0, sendNotificationToEmail(hook, []string{webHookEventToNotificationText(hook, &event)})
+	}
+
+	return 0, fmt.Errorf("unsupported webHook target: " + hook.Target)
+}
+
+func TriggerWebHooks(m *models.ConfigUpdateHistory, app *models.App, dataVersion int) {
+	triggerWebHookEvent(newConfigUpdateWebHookEvent(m, app, dataVersion))
+}
+
+func TriggerUserWebHooks(event string, user *models.User, opUserKey string) {
+	triggerWebHookEvent(newUserWebHookEvent(event, user, opUserKey))
+}
+
+func TriggerAppWebHooks(event string, app *models.App, opUserKey string) {
+	triggerWebHookEvent(newAppWebHookEvent(event, app, opUserKey))
+}
+
+func TriggerAppCloneWebHooks(app, fromApp *models.App, opUserKey string) {
+	event := newAppWebHookEvent(WEBHOOK_EVENT_APP_CLONE, app, opUserKey)
+	event.FromApp = &webHookEventApp{Key: fromApp.Key, Name: fromApp.Name, Type: fromApp.Type}
+	triggerWebHookEvent(event)
+}
+
+func TriggerNodeWebHooks(event string, nodes []*webHookEventNode, dataVersion int) {
+	triggerWebHookEvent(&webHookEvent{Event: event, Nodes: nodes, DataVersion: dataVersion})
+}
+
+// webhooks are only delivered by master node, deliveries are queued in db and sent by deliverWebHooksLoop
+func triggerWebHookEvent(event *webHookEvent) {
+	if !conf.IsMasterNode() {
+		return
+	}
+
+	hooks := getMatchedWebHooks(event)
+	if len(hooks) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	for _, hook := range hooks {
+		queueWebHookDelivery(hook, event.Event, string(payload))
+	}
+}
+
+// global hooks and hooks of the event's app whose filters match the event
+func getMatchedWebHooks(event *webHookEvent) []*models.WebHook {
+	memConfMux.RLock()
+	defer memConfMux.RUnlock()
+
+	hooks := memConfGlobalWebHooks
+	if event.App != nil {
+		hooks = append(hooks[:len(hooks):len(hooks)], memConfAppWebHooks[event.App.Key]...)
+	}
+
+	var res []*models.WebHook
+	for _, hook := range hooks {
+		if isWebHookMatched(hook, event) {
+			res = append(res, hook)
+		}
+	}
+
+	return res
+}
+
+// empty filter matches all, kinds and key prefixes only apply to config events
+func isWebHookMatched(hook *models.WebHook, event *webHookEvent) bool {
+	if hook.Status != models.WEBHOOK_STATUS_ACTIVE {
+		return false
+	}
+	if len(hook.Events) > 0 && !inStringSlice(event.Event, hook.Events) {
+		return false
+	}
+	if event.History == nil {
+		return true
+	}
+
+	if len(hook.Kinds) > 0 && !inStringSlice(event.History.Kind, hook.Kinds) {
+		return false
+	}
+	if len(hook.KeyPrefixes) == 0 {
+		return true
+	}
+	for _, prefix := range hook.KeyPrefixes {
+		if strings.HasPrefix(event.History.K, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func queueWebHookDelivery(hook *models.WebHook, event, payload string) (*models.WebHookDelivery, error) {
+	now := utils.GetNowSecond()
+	delivery := &models.WebHookDelivery{
+		Key:          utils.GenerateKey(),
+		HookKey:      hook.Key,
+		Event:        event,
+		Payload:      payload,
+		Status:       models.WEBHOOK_DELIVERY_STATUS_PENDING,
+		NextRetryUTC: now,
+		CreatedUTC:   now,
+	}
+	if hook.Target == models.WEBHOOK_TARGET_EMAIL {
+		// wait for more changes to send in one digest
+		delivery.NextRetryUTC += conf.SMTPDigestWindow
+	}
+	if err := models.InsertRow(nil, delivery); err != nil {
+		logger.Error(map[string]interface{}{
+			"type":     "webhook_delivery",
+			"hook_key": hook.Key,
+			"error":    err.Error(),
+		})
+		return nil, err
+	}
+
+	sendChanAsync(webHookDeliveryCh, nil)
+	return delivery, nil
+}
+
+func getMemConfWebHook(key string) *models.WebHook {
+	memConfMux.RLock()
+	defer memConfMux.RUnlock()
+
+	for _, hook := range memConfGlobalWebHooks {
+		if hook.Key == key {
+			return hook
+		}
+	}
+	for _, hooks := range memConfAppWebHooks {
+		for _, hook := range hooks {
+			if hook.Key == key {
+				return hook
+			}
+		}
+	}
+
 	return nil
 }
 
-func TriggerWebHooks(m *models.ConfigUpdateHistory, app *models.App) {
-	var globalHooks, appHooks []*models.WebHook
-	globalHooks, _ = models.GetGlobalWebHooks(nil)
-	appHooks, _ = models.GetWebHooksByAppKey(nil, app.Key)
+// retry delay doubles with every failed attempt
+func getWebHookRetryDelay(attempts int) int {
+	delay := WEBHOOK_DELIVERY_RETRY_BASE
+	for i := 1; i < attempts && delay < WEBHOOK_DELIVERY_RETRY_MAX; i++ {
+		delay *= 2
+	}
+	if delay > WEBHOOK_DELIVERY_RETRY_MAX {
+		delay = WEBHOOK_DELIVERY_RETRY_MAX
+	}
+	return delay
+}
+
+func deliverWebHook(delivery *models.WebHookDelivery) error {
+	var statusCode int
+	var err error
+	start := time.Now()
+
+	hook := getMemConfWebHook(delivery.HookKey)
+	if hook == nil || hook.Status != models.WEBHOOK_STATUS_ACTIVE {
+		err = fmt.Errorf("webHook not exists or inactive: " + delivery.HookKey)
+		delivery.Status = models.WEBHOOK_DELIVERY_STATUS_DEAD
+	} else {
+		statusCode, err = sendWebHookEvent(hook, []byte(delivery.Payload))
+	}
+
+	return updateWebHookDelivery(delivery, statusCode, start, err)
+}
+
+// pending deliveries of an email hook are sent in one digest mail
+func deliverWebHookDigest(hook *models.WebHook, deliveries []*models.WebHookDelivery) error {
+	start := time.Now()
+
+	if hook.Status != models.WEBHOOK_STATUS_ACTIVE {
+		err := fmt.Errorf("webHook not exists or inactive: " + hook.Key)
+		for _, delivery := range deliveries {
+			delivery.Status = models.WEBHOOK_DELIVERY_STATUS_DEAD
+			if _err := updateWebHookDelivery(delivery, 0, start, err); _err != nil {
+				return _err
+			}
+		}
+		return nil
+	}
+
+	texts := make([]string, 0, len(deliveries))
+	sentDeliveries := make([]*models.WebHookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		var event webHookEvent
+		if err := json.Unmarshal([]byte(delivery.Payload), &event); err != nil {
+			// bad payload never gets sent, other deliveries are not blocked by it
+			delivery.Status = models.WEBHOOK_DELIVERY_STATUS_DEAD
+			if _err := updateWebHookDelivery(delivery, 0, start, err); _err != nil {
+				return _err
+			}
+			continue
+		}
+		texts = append(texts, webHookEventToNotificationText(hook, &event))
+		sentDeliveries = append(sentDeliveries, delivery)
+	}
+	if len(sentDeliveries) == 0 {
+		return nil
+	}
+
+	err := sendNotificationToEmail(hook, texts)
+	for _, delivery := range sentDeliveries {
+		if _err := updateWebHookDelivery(delivery, 0, start, err); _err != nil {
+			return _err
+		}
+	}
 
-	for _, hook := range append(globalHooks, appHooks...) {
-		switch hook.Target {
-		case models.WEBHOOK_TARGET_PUBU:
-			sendNotificationToPubu(hook.URL, m, app)
-		case models.WEBHOOK_TARGET_SLACK:
-			sendNotificationToSlack(hook.URL, m, app)
+	return nil
+}
+
+func updateWebHookDelivery(delivery *models.WebHookDelivery, statusCode int, start time.Time, err error) error {
+	delivery.Attempts++
+	delivery.StatusCode = statusCode
+	delivery.LatencyMs = int(time.Since(start) / time.Millisecond)
+	delivery.LastAttemptUTC = utils.GetNowSecond()
+	if err == nil {
+		delivery.Status = models.WEBHOOK_DELIVERY_STATUS_SUCCESS
+		delivery.Error = ""
+		webHookDeliveryCounter.Inc(delivery.Event, "success")
+	} else {
+		delivery.Error = err.Error()
+		if delivery.Attempts >= WEBHOOK_DELIVERY_MAX_ATTEMPTS {
+			delivery.Status = models.WEBHOOK_DELIVERY_STATUS_DEAD
+		}
+		if delivery.Status == models.WEBHOOK_DELIVERY_STATUS_PENDING {
+			delivery.NextRetryUTC = delivery.LastAttemptUTC + getWebHookRetryDelay(delivery.Attempts)
+			webHookDeliveryCounter.Inc(delivery.Event, "failure")
+		} else {
+			webHookDeliveryCounter.Inc(delivery.Event, "dead")
 		}
+		logger.Error(map[string]interface{}{
+			"type":     "webhook_delivery",
+			"hook_key": delivery.HookKey,
+			"delivery": delivery.Key,
+			"attempts": delivery.Attempts,
+			"error":    err.Error(),
+		})
+	}
+
+	return models.UpdateDBModel(nil, delivery)
+}
+
+func deliverWebHooksLoop() {
+	for {
+		select {
+		case <-webHookDeliveryCh:
+		case <-time.After(WEBHOOK_DELIVERY_CHECK_INTERVAL * time.Second):
+		}
+
+		deliveries, err := models.GetWebHookDeliveriesToSend(nil, utils.GetNowSecond(), WEBHOOK_DELIVERY_BATCH_SIZE)
+		if err != nil {
+			continue
+		}
+		sentDeliveries := make(map[string]bool)
+		for _, delivery := range deliveries {
+			if sentDeliveries[delivery.Key] {
+				continue
+			}
+
+			hook := getMemConfWebHook(delivery.HookKey)
+			if hook != nil && hook.Target == models.WEBHOOK_TARGET_EMAIL {
+				now := utils.GetNowSecond()
+				digest, err := models.GetPendingWebHookDeliveriesOfHook(nil, hook.Key, now, now-conf.SMTPDigestWindow, WEBHOOK_DELIVERY_BATCH_SIZE)
+				if err == nil && len(digest) > 0 {
+					for _, _delivery := range digest {
+						sentDeliveries[_delivery.Key] = true
+					}
+					deliverWebHookDigest(hook, digest)
+					continue
+				}
+			}
+			deliverWebHook(delivery)
+		}
+		if len(deliveries) == WEBHOOK_DELIVERY_BATCH_SIZE {
+			// there may be more deliveries to send
+			sendChanAsync(webHookDeliveryCh, nil)
+		}
+	}
+}
+
+func verifyWebHookData(hook *models.WebHook) error {
+	switch hook.Target {
+	case models.WEBHOOK_TARGET_PUBU, models.WEBHOOK_TARGET_SLACK, models.WEBHOOK_TARGET_GENERIC:
+		if hook.URL == "" {
+			return fmt.Errorf("url is required by webHook target: " + hook.Target)
+		}
+	case models.WEBHOOK_TARGET_EMAIL:
+		if !conf.SMTPEnable {
+			return fmt.Errorf("smtp must be enabled for email webHook target")
+		}
+		if len(hook.Recipients) == 0 {
+			return fmt.Errorf("recipients are required by email webHook target")
+		}
+		for _, recipient := range hook.Recipients {
+			if _, err := mail.ParseAddress(recipient); err != nil {
+				return fmt.Errorf("bad recipient mail address: " + recipient)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported webHook target: " + hook.Target)
+	}
+
+	switch hook.AuthType {
+	case models.WEBHOOK_AUTH_NONE:
+	case models.WEBHOOK_AUTH_BASIC:
+		if hook.Target != models.WEBHOOK_TARGET_GENERIC {
+			return fmt.Errorf("basic auth is only supported by generic webHook target")
+		}
+		if !strings.Contains(hook.AuthInfo, ":") {
+			return fmt.Errorf("auth info of basic auth must be in the form of user:password")
+		}
+	default:
+		return fmt.Errorf("unknown webHook auth type: %d", hook.AuthType)
+	}
+
+	for _, event := range hook.Events {
+		if !inStringSlice(event, webHookEvents) {
+			return fmt.Errorf("unknown webHook event: " + event)
+		}
+	}
+	for _, kind := range hook.Kinds {
+		if !inStringSlice(kind, webHookConfigUpdateKinds) {
+			return fmt.Errorf("unknown config update kind: " + kind)
+		}
+	}
+
+	if hook.Template != "" {
+		if hook.Target == models.WEBHOOK_TARGET_GENERIC {
+			return fmt.Errorf("message template is not supported by generic webHook target")
+		}
+		if _, err := parseWebHookTemplate(hook.Target, hook.Template); err != nil {
+			return fmt.Errorf("bad message template: " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+// secrets of webHook are never returned by op api, posting the hidden value back keeps them
+func hideWebHookSecrets(hook *models.WebHook) {
+	if hook.AuthInfo != "" {
+		hook.AuthInfo = WEBHOOK_HIDDEN_SECRET
+	}
+	if hook.Secret != "" {
+		hook.Secret = WEBHOOK_HIDDEN_SECRET
 	}
 }
 
@@ -119,17 +846,24 @@ func GetGlobalWebHooks(c *gin.Context) {
 		return
 	}
 
+	for _, hook := range hooks {
+		hideWebHookSecrets(hook)
+	}
 	Success(c, hooks)
 }
 
 func GetAppWebHooks(c *gin.Context) {
 	appKey := c.Param("app_key")
-	apps, err := models.GetWebHooksByAppKey(nil, appKey)
+	hooks, err := models.GetWebHooksByAppKey(nil, appKey)
 	if err != nil {
 		Error(c, SERVER_ERROR, err.Error())
 		return
 	}
-	Success(c, apps)
+
+	for _, hook := range hooks {
+		hideWebHookSecrets(hook)
+	}
+	Success(c, hooks)
 }
 
 func NewWebHook(c *gin.Context) {
@@ -137,11 +871,21 @@ func NewWebHook(c *gin.Context) {
 	defer confWriteMux.Unlock()
 
 	var data struct {
-		AppKey string `json:"app_key"`
-		Scope  int    `json:"scope"`
-		Target string `json:"target" binding:"required"`
-		URL    string `json:"url" binding:"required"`
-		Status int    `json:"status"`
+		AppKey   string `json:"app_key"`
+		Scope    int    `json:"scope"`
+		Target   string `json:"target" binding:"required"`
+		URL      string `json:"url"`
+		AuthType int    `json:"auth_type"`
+		AuthInfo string `json:"auth_info"`
+		Secret   string `json:"secret"`
+		Status   int    `json:"status"`
+		Template string `json:"template"`
+
+		Recipients []string `json:"recipients"`
+
+		Events      []string `json:"events"`
+		Kinds       []string `json:"kinds"`
+		KeyPrefixes []string `json:"key_prefixes"`
 	}
 	if err := c.BindJSON(&data); err != nil {
 		Error(c, BAD_POST_DATA, err.Error())
@@ -153,28 +897,40 @@ func NewWebHook(c *gin.Context) {
 		return
 	}
 
-	if data.Target != models.WEBHOOK_TARGET_PUBU && data.Target != models.WEBHOOK_TARGET_SLACK {
-		Error(c, BAD_REQUEST, "unsupported webHook target: "+data.Target)
-		return
-	}
-
 	if data.Scope == models.WEBHOOK_SCOPE_APP && memConfApps[data.AppKey] == nil {
 		Error(c, BAD_REQUEST, "app key not exists: "+data.AppKey)
 		return
 	}
 
 	webHook := &models.WebHook{
-		Key:    utils.GenerateKey(),
-		AppKey: data.AppKey,
-		Scope:  data.Scope,
-		Target: data.Target,
-		URL:    data.URL,
-		Status: data.Status,
+		Key:      utils.GenerateKey(),
+		AppKey:   data.AppKey,
+		Scope:    data.Scope,
+		Target:   data.Target,
+		URL:      data.URL,
+		AuthType: data.AuthType,
+		AuthInfo: data.AuthInfo,
+		Secret:   data.Secret,
+		Status:   data.Status,
+		Template: data.Template,
+
+		Recipients: data.Recipients,
+
+		Events:      data.Events,
+		Kinds:       data.Kinds,
+		KeyPrefixes: data.KeyPrefixes,
+
+		LastUpdateId: utils.GenerateKey(),
+	}
+	if err := verifyWebHookData(webHook); err != nil {
+		Error(c, BAD_REQUEST, err.Error())
+		return
 	}
 	if _, err := updateWebHook(webHook, nil); err != nil {
 		Error(c, SERVER_ERROR, err.Error())
 		return
 	}
+	setAuditLog(c, AUDIT_ACTION_WEBHOOK_NEW, webHook.Key, nil, webHook)
 
 	failedNodes := syncData2SlaveIfNeed(webHook, getOpUserKey(c))
 	if len(failedNodes) > 0 {
@@ -189,12 +945,24 @@ func UpdateWebHook(c *gin.Context) {
 	defer confWriteMux.Unlock()
 
 	var data struct {
-		Key    string `json:"key" binding:"required"`
-		AppKey string `json:"app_key"`
-		Scope  int    `json:"scope"`
-		Target string `json:"target" binding:"required"`
-		URL    string `json:"url" binding:"required"`
-		Status int    `json:"status"`
+		Key      string `json:"key" binding:"required"`
+		AppKey   string `json:"app_key"`
+		Scope    int    `json:"scope"`
+		Target   string `json:"target" binding:"required"`
+		URL      string `json:"url"`
+		AuthType int    `json:"auth_type"`
+		AuthInfo string `json:"auth_info"`
+		Secret   string `json:"secret"`
+		Status   int    `json:"status"`
+		Template string `json:"template"`
+
+		Recipients []string `json:"recipients"`
+
+		Events      []string `json:"events"`
+		Kinds       []string `json:"kinds"`
+		KeyPrefixes []string `json:"key_prefixes"`
+
+		LastUpdateId string `json:"last_update_id"` // the one loaded with webHook
 	}
 
 	if err := c.BindJSON(&data); err != nil {
@@ -207,11 +975,6 @@ func UpdateWebHook(c *gin.Context) {
 		return
 	}
 
-	if data.Target != models.WEBHOOK_TARGET_PUBU && data.Target != models.WEBHOOK_TARGET_SLACK {
-		Error(c, BAD_REQUEST, "unsupported webHook target: "+data.Target)
-		return
-	}
-
 	var oldHook *models.WebHook = nil
 	if data.Scope == models.WEBHOOK_SCOPE_GLOBAL {
 		for _, hook := range memConfGlobalWebHooks {
@@ -237,25 +1000,167 @@ func UpdateWebHook(c *gin.Context) {
 		Error(c, BAD_REQUEST, "webHook key not exists: "+data.Key)
 		return
 	}
-
-	if oldHook.AppKey == data.AppKey && oldHook.Scope == data.Scope && oldHook.Target == data.Target && oldHook.Status == data.Status && oldHook.URL == data.URL {
-		Success(c, nil)
+	if errCode, err := checkUpdateConflict(oldHook.LastUpdateId, data.LastUpdateId, "last_update_id", "webHook"); err != nil {
+		Error(c, errCode, err.Error())
 		return
 	}
 
 	webHook := *oldHook
 	webHook.Target = data.Target
 	webHook.URL = data.URL
+	webHook.AuthType = data.AuthType
+	if data.AuthInfo != WEBHOOK_HIDDEN_SECRET {
+		webHook.AuthInfo = data.AuthInfo
+	}
+	if data.Secret != WEBHOOK_HIDDEN_SECRET {
+		webHook.Secret = data.Secret
+	}
 	webHook.Status = data.Status
+	webHook.Events = data.Events
+	webHook.Kinds = data.Kinds
+	webHook.KeyPrefixes = data.KeyPrefixes
+	webHook.Template = data.Template
+	webHook.Recipients = data.Recipients
+	if err := verifyWebHookData(&webHook); err != nil {
+		Error(c, BAD_REQUEST, err.Error())
+		return
+	}
+	if reflect.DeepEqual(&webHook, oldHook) {
+		Success(c, nil)
+		return
+	}
+	webHook.LastUpdateId = utils.GenerateKey()
+
 	if _, err := updateWebHook(&webHook, nil); err != nil {
 		Error(c, SERVER_ERROR, err.Error())
 		return
 	}
+	setAuditLog(c, AUDIT_ACTION_WEBHOOK_UPDATE, webHook.Key, oldHook, &webHook)
+
+	res := map[string]interface{}{"last_update_id": webHook.LastUpdateId}
+	if failedNodes := syncData2SlaveIfNeed(&webHook, getOpUserKey(c)); len(failedNodes) > 0 {
+		res["failed_nodes"] = failedNodes
+	}
+	Success(c, res)
+}
+
+func DeleteWebHook(c *gin.Context) {
+	confWriteMux.Lock()
+	defer confWriteMux.Unlock()
+
+	hook := getMemConfWebHook(c.Param("key"))
+	if hook == nil {
+		Error(c, BAD_REQUEST, "webHook key not exists: "+c.Param("key"))
+		return
+	}
+
+	if err := deleteWebHook(hook, nil); err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	setAuditLog(c, AUDIT_ACTION_WEBHOOK_DELETE, hook.Key, hook, nil)
 
-	failedNodes := syncData2SlaveIfNeed(&webHook, getOpUserKey(c))
+	failedNodes := syncData2SlaveIfNeed(&deleteWebHookData{Hook: hook}, getOpUserKey(c))
 	if len(failedNodes) > 0 {
 		Success(c, map[string]interface{}{"failed_nodes": failedNodes})
 	} else {
 		Success(c, nil)
 	}
 }
+
+// render message template against a sample event
+func PreviewWebHookTemplate(c *gin.Context) {
+	var data struct {
+		Target   string `json:"target" binding:"required"`
+		Template string `json:"template"`
+		Event    string `json:"event" binding:"required"`
+	}
+	if err := c.BindJSON(&data); err != nil {
+		Error(c, BAD_POST_DATA, err.Error())
+		return
+	}
+
+	event := getSampleWebHookEvent(data.Event)
+	if event == nil {
+		Error(c, BAD_REQUEST, "unknown webHook event: "+data.Event)
+		return
+	}
+
+	hook := &models.WebHook{Target: data.Target, Template: data.Template}
+	if hook.Template != "" {
+		if _, err := renderWebHookEventText(hook.Target, hook.Template, event); err != nil {
+			Error(c, BAD_REQUEST, "failed to render message template: "+err.Error())
+			return
+		}
+	}
+
+	Success(c, map[string]interface{}{
+		"event": event,
+		"text":  webHookEventToNotificationText(hook, event),
+	})
+}
+
+func GetWebHookDeliveries(c *gin.Context) {
+	page, err := strconv.Atoi(c.Param("page"))
+	if err != nil {
+		Error(c, BAD_REQUEST, "page not number")
+		return
+	}
+
+	count, err := strconv.Atoi(c.Param("count"))
+	if err != nil {
+		Error(c, BAD_REQUEST, "count not number")
+		return
+	}
+
+	deliveries, err := models.GetWebHookDeliveries(nil, c.Param("hook_key"), page, count)
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	totalCount, err := models.GetWebHookDeliveryCount(nil, c.Param("hook_key"))
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	Success(c, map[string]interface{}{
+		"total_count": totalCount,
+		"list":        deliveries,
+	})
+}
+
+// redelivery is queued as a new delivery with the same payload
+func RedeliverWebHook(c *gin.Context) {
+	var data struct {
+		Key string `json:"key" binding:"required"`
+	}
+	if err := c.BindJSON(&data); err != nil {
+		Error(c, BAD_POST_DATA, err.Error())
+		return
+	}
+
+	delivery, err := models.GetWebHookDeliveryByKey(nil, data.Key)
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+	if delivery == nil {
+		Error(c, BAD_REQUEST, "webHook delivery not exists: "+data.Key)
+		return
+	}
+	hook := getMemConfWebHook(delivery.HookKey)
+	if hook == nil || hook.Status != models.WEBHOOK_STATUS_ACTIVE {
+		Error(c, BAD_REQUEST, "webHook not exists or inactive: "+delivery.HookKey)
+		return
+	}
+
+	newDelivery, err := queueWebHookDelivery(hook, delivery.Event, delivery.Payload)
+	if err != nil {
+		Error(c, SERVER_ERROR, err.Error())
+		return
+	}
+
+	Success(c, newDelivery)
+}
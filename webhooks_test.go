@@ -1,12 +1,21 @@
 package main
 
 import (
+	"bufio"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/Instafig/Instafig/conf"
 	"github.com/Instafig/Instafig/models"
 	"github.com/Instafig/Instafig/utils"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestPubuNotifaction(t *testing.T) {
@@ -28,7 +37,432 @@ func TestPubuNotifaction(t *testing.T) {
 	app := &models.App{
 		Name: "TestApp",
 	}
-	sendNotificationToPubu(pubuURL, configHistory, app)
+	sendNotificationToPubu(pubuURL, configUpdateHistoryToNotificationText(configHistory, app))
 	configHistory.UserName = fmt.Sprintf("SlackTester@%s", hostname)
-	sendNotificationToSlack(pubuURL, configHistory, app)
+	sendNotificationToSlack(pubuURL, configUpdateHistoryToNotificationText(configHistory, app))
+}
+
+func TestGenericWebHook(t *testing.T) {
+	var body []byte
+	var signature, user, pass string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+		signature = r.Header.Get(WEBHOOK_SIGNATURE_HEADER)
+		user, pass, _ = r.BasicAuth()
+		if user != "instafig" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	hook := &models.WebHook{
+		Key:      utils.GenerateKey(),
+		Target:   models.WEBHOOK_TARGET_GENERIC,
+		URL:      server.URL,
+		AuthType: models.WEBHOOK_AUTH_BASIC,
+		AuthInfo: "instafig:pass:word",
+		Secret:   "hook_secret",
+		Status:   models.WEBHOOK_STATUS_ACTIVE,
+	}
+	configHistory := &models.ConfigUpdateHistory{
+		Id:         utils.GenerateKey(),
+		K:          "TestKey",
+		NewV:       "1",
+		NewVType:   models.CONF_V_TYPE_INT,
+		Kind:       models.CONFIG_UPDATE_KIND_NEW,
+		UserKey:    "user_key",
+		UserName:   "GenericTester",
+		CreatedUTC: utils.GetNowSecond(),
+	}
+	app := &models.App{Key: "app_key", Name: "TestApp", Type: models.APP_TYPE_REAL}
+
+	payload, _ := json.Marshal(newConfigUpdateWebHookEvent(configHistory, app, 3))
+	statusCode, err := sendNotificationToGeneric(hook, payload)
+	assert.True(t, err == nil && statusCode == http.StatusOK)
+	assert.True(t, user == "instafig" && pass == "pass:word")
+	assert.True(t, signature == signWebHookPayload(hook.Secret, body))
+
+	var event webHookEvent
+	assert.True(t, json.Unmarshal(body, &event) == nil)
+	assert.True(t, event.Event == WEBHOOK_EVENT_CONFIG_UPDATE && event.DataVersion == 3)
+	assert.True(t, event.History.K == "TestKey" && event.App.Name == "TestApp" && event.User.Name == "GenericTester")
+
+	hook.AuthInfo = "nobody:pass"
+	statusCode, err = sendNotificationToGeneric(hook, payload)
+	assert.True(t, err != nil && statusCode == http.StatusUnauthorized, "must fail on non-2xx response")
+
+	assert.True(t, verifyWebHookData(&models.WebHook{Target: models.WEBHOOK_TARGET_GENERIC, AuthType: models.WEBHOOK_AUTH_BASIC, AuthInfo: "nopass"}) != nil)
+	assert.True(t, verifyWebHookData(&models.WebHook{Target: models.WEBHOOK_TARGET_SLACK, AuthType: models.WEBHOOK_AUTH_BASIC, AuthInfo: "a:b"}) != nil)
+	assert.True(t, verifyWebHookData(&models.WebHook{Target: "unknown"}) != nil)
+}
+
+func TestHideWebHookSecrets(t *testing.T) {
+	hook := &models.WebHook{AuthInfo: "user:pass", Secret: "sign-key"}
+	hideWebHookSecrets(hook)
+	assert.True(t, hook.AuthInfo == WEBHOOK_HIDDEN_SECRET && hook.Secret == WEBHOOK_HIDDEN_SECRET)
+
+	hook = &models.WebHook{}
+	hideWebHookSecrets(hook)
+	assert.True(t, hook.AuthInfo == "" && hook.Secret == "", "empty secrets must stay empty")
+}
+
+func TestWebHookDelivery(t *testing.T) {
+	err := _clearModelData()
+	assert.True(t, err == nil, "must correctly clear data")
+	loadAllData()
+	initNodeData()
+
+	failed := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failed {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	hook, err := updateWebHook(&models.WebHook{
+		Key:    utils.GenerateKey(),
+		Scope:  models.WEBHOOK_SCOPE_GLOBAL,
+		Target: models.WEBHOOK_TARGET_GENERIC,
+		URL:    server.URL,
+		Status: models.WEBHOOK_STATUS_ACTIVE,
+	}, nil)
+	assert.True(t, err == nil)
+
+	// far future retry time keeps the delivery loop away from it
+	delivery := &models.WebHookDelivery{
+		Key:          utils.GenerateKey(),
+		HookKey:      hook.Key,
+		Event:        WEBHOOK_EVENT_CONFIG_UPDATE,
+		Payload:      `{"event": "config_update"}`,
+		NextRetryUTC: utils.GetNowSecond() + 86400,
+		CreatedUTC:   utils.GetNowSecond(),
+	}
+	assert.True(t, models.InsertRow(nil, delivery) == nil)
+
+	deliverWebHook(delivery)
+	delivery, _ = models.GetWebHookDeliveryByKey(nil, delivery.Key)
+	assert.True(t, delivery.Status == models.WEBHOOK_DELIVERY_STATUS_PENDING && delivery.Attempts == 1)
+	assert.True(t, delivery.StatusCode == http.StatusInternalServerError && delivery.Error != "")
+	assert.True(t, delivery.NextRetryUTC == delivery.LastAttemptUTC+WEBHOOK_DELIVERY_RETRY_BASE)
+
+	delivery.Attempts = WEBHOOK_DELIVERY_MAX_ATTEMPTS - 1
+	deliverWebHook(delivery)
+	assert.True(t, delivery.Status == models.WEBHOOK_DELIVERY_STATUS_DEAD, "must be dead after max attempts")
+
+	failed = false
+	delivery.Status = models.WEBHOOK_DELIVERY_STATUS_PENDING
+	deliverWebHook(delivery)
+	delivery, _ = models.GetWebHookDeliveryByKey(nil, delivery.Key)
+	assert.True(t, delivery.Status == models.WEBHOOK_DELIVERY_STATUS_SUCCESS && delivery.Error == "")
+
+	deliveries, _ := models.GetWebHookDeliveries(nil, hook.Key, 1, 10)
+	assert.True(t, len(deliveries) == 1)
+
+	assert.True(t, getWebHookRetryDelay(2) == WEBHOOK_DELIVERY_RETRY_BASE*2)
+	assert.True(t, getWebHookRetryDelay(100) == WEBHOOK_DELIVERY_RETRY_MAX)
+
+	_clearModelData()
+}
+
+func TestWebHookFilter(t *testing.T) {
+	err := _clearModelData()
+	assert.True(t, err == nil, "must correctly clear data")
+	loadAllData()
+	initNodeData()
+
+	app := &models.App{Key: "app_key", Name: "TestApp", Type: models.APP_TYPE_REAL}
+	history := &models.ConfigUpdateHistory{K: "ui.new_home", Kind: models.CONFIG_UPDATE_KIND_UPDATE, UserName: "FilterTester"}
+	configEvent := newConfigUpdateWebHookEvent(history, app, 1)
+	appEvent := newAppWebHookEvent(WEBHOOK_EVENT_APP_NEW, app, "")
+
+	hook := &models.WebHook{Status: models.WEBHOOK_STATUS_ACTIVE}
+	assert.True(t, isWebHookMatched(hook, configEvent) && isWebHookMatched(hook, appEvent), "empty filter must match all")
+
+	hook.Events = []string{WEBHOOK_EVENT_CONFIG_UPDATE}
+	hook.Kinds = []string{models.CONFIG_UPDATE_KIND_NEW, models.CONFIG_UPDATE_KIND_UPDATE}
+	hook.KeyPrefixes = []string{"api.", "ui."}
+	assert.True(t, isWebHookMatched(hook, configEvent))
+	assert.True(t, !isWebHookMatched(hook, appEvent))
+
+	history.Kind = models.CONFIG_UPDATE_KIND_HIDE
+	assert.True(t, !isWebHookMatched(hook, configEvent))
+	history.Kind = models.CONFIG_UPDATE_KIND_NEW
+	history.K = "timeout"
+	assert.True(t, !isWebHookMatched(hook, configEvent))
+
+	hook.Status = models.WEBHOOK_STATUS_INACTIVE
+	history.K = "api.timeout"
+	assert.True(t, !isWebHookMatched(hook, configEvent))
+
+	assert.True(t, verifyWebHookData(&models.WebHook{Target: models.WEBHOOK_TARGET_GENERIC, Events: []string{"unknown"}}) != nil)
+	assert.True(t, verifyWebHookData(&models.WebHook{Target: models.WEBHOOK_TARGET_GENERIC, Kinds: []string{"unknown"}}) != nil)
+
+	globalHook, _ := updateWebHook(&models.WebHook{
+		Key:    utils.GenerateKey(),
+		Scope:  models.WEBHOOK_SCOPE_GLOBAL,
+		Target: models.WEBHOOK_TARGET_GENERIC,
+		Status: models.WEBHOOK_STATUS_ACTIVE,
+		Events: []string{WEBHOOK_EVENT_APP_NEW},
+	}, nil)
+	appHook, _ := updateWebHook(&models.WebHook{
+		Key:    utils.GenerateKey(),
+		AppKey: app.Key,
+		Scope:  models.WEBHOOK_SCOPE_APP,
+		Target: models.WEBHOOK_TARGET_GENERIC,
+		Status: models.WEBHOOK_STATUS_ACTIVE,
+	}, nil)
+	assert.True(t, len(getMatchedWebHooks(appEvent)) == 2)
+	assert.True(t, len(getMatchedWebHooks(configEvent)) == 1)
+
+	assert.True(t, deleteWebHook(globalHook, nil) == nil)
+	assert.True(t, getMemConfWebHook(globalHook.Key) == nil && getMemConfWebHook(appHook.Key) != nil)
+	hooks, _ := models.GetGlobalWebHooks(nil)
+	assert.True(t, len(hooks) == 0)
+	assert.True(t, len(getMatchedWebHooks(appEvent)) == 1)
+
+	_clearModelData()
+}
+
+func TestWebHookEventText(t *testing.T) {
+	slackHook := &models.WebHook{Target: models.WEBHOOK_TARGET_SLACK}
+	pubuHook := &models.WebHook{Target: models.WEBHOOK_TARGET_PUBU}
+	user := &models.User{Key: "user_key", Name: "bob"}
+	event := &webHookEvent{
+		Event:      WEBHOOK_EVENT_USER_DEACTIVATE,
+		User:       &webHookEventUser{Name: "alice"},
+		TargetUser: &webHookEventUser{Key: user.Key, Name: user.Name},
+	}
+	assert.True(t, webHookEventToNotificationText(slackHook, event) == "User *bob* is just deactivated by alice")
+	assert.True(t, webHookEventToNotificationText(pubuHook, event) == "User **bob** is just deactivated by alice")
+
+	event = &webHookEvent{
+		Event:   WEBHOOK_EVENT_APP_CLONE,
+		App:     &webHookEventApp{Name: "new_app"},
+		FromApp: &webHookEventApp{Name: "old_app"},
+		User:    &webHookEventUser{Name: "alice"},
+	}
+	assert.True(t, webHookEventToNotificationText(slackHook, event) == "App *new_app* is just cloned from *old_app* by alice")
+
+	event = &webHookEvent{
+		Event:       WEBHOOK_EVENT_NODE_EXPIRED,
+		Nodes:       []*webHookEventNode{{URL: "slave:17070", DataVersion: 3, LastCheckUTC: 0}},
+		DataVersion: 5,
+	}
+	assert.True(t, webHookEventToNotificationText(slackHook, event) ==
+		"Slave node *slave:17070* has not checked master since 1970-01-01T00:00:00Z, its data version is 3 while master's is 5")
+
+	event = &webHookEvent{
+		Event:   WEBHOOK_EVENT_CONFIG_UPDATE,
+		App:     &webHookEventApp{Name: "app"},
+		History: &models.ConfigUpdateHistory{Kind: models.CONFIG_UPDATE_KIND_HIDE, K: "k", UserName: "alice", Message: "for release", Ref: "OPS-42"},
+	}
+	assert.True(t, webHookEventToNotificationText(slackHook, event) == "Your config [k] for App [app] is just hidden by alice, message: for release, ref: OPS-42")
+
+	event = &webHookEvent{Event: "unknown"}
+	assert.True(t, webHookEventToNotificationText(slackHook, event) == "Unkown Action")
+}
+
+func TestGetNewExpiredSlaveNodes(t *testing.T) {
+	memConfMux.Lock()
+	oldNodes := memConfNodes
+	memConfNodes = map[string]*models.Node{
+		"master": {URL: "master", Type: models.NODE_TYPE_MASTER, LastCheckUTC: 0, DataVersion: &models.DataVersion{}},
+		"slave1": {URL: "slave1", Type: models.NODE_TYPE_SLAVE, LastCheckUTC: 10, DataVersion: &models.DataVersion{}},
+		"slave2": {URL: "slave2", Type: models.NODE_TYPE_SLAVE, LastCheckUTC: 100, DataVersion: &models.DataVersion{}},
+	}
+	memConfMux.Unlock()
+
+	expiredNodes := make(map[string]bool)
+	nodes := getNewExpiredSlaveNodes(expiredNodes, 50)
+	assert.True(t, len(nodes) == 1 && nodes[0].URL == "slave1")
+	assert.True(t, len(getNewExpiredSlaveNodes(expiredNodes, 50)) == 0, "expired node must be notified only once")
+
+	memConfNodes["slave1"].LastCheckUTC = 60
+	assert.True(t, len(getNewExpiredSlaveNodes(expiredNodes, 50)) == 0 && !expiredNodes["slave1"])
+
+	memConfMux.Lock()
+	memConfNodes = oldNodes
+	memConfMux.Unlock()
+}
+
+func TestWebHookTemplate(t *testing.T) {
+	hook := &models.WebHook{
+		Target:   models.WEBHOOK_TARGET_SLACK,
+		URL:      "https://hooks.slack.com/services/test",
+		Template: `{{.User.Name}} changed {{em .History.K}} of {{.App.Name}}: {{.Diff.From.V}} -> {{.Diff.To.V}}{{range .Diff.Kinds}} {{.}}{{end}}`,
+	}
+	assert.True(t, verifyWebHookData(hook) == nil)
+
+	event := getSampleWebHookEvent(WEBHOOK_EVENT_CONFIG_UPDATE)
+	assert.True(t, webHookEventToNotificationText(hook, event) == "sample_user changed *sample_key* of sample_app: 1 -> 2 changed_value")
+
+	event.History.Kind = models.CONFIG_UPDATE_KIND_HIDE
+	event.History.NewV = event.History.OldV
+	hook.Template = `{{em .History.K}}:{{range .Diff.Kinds}} {{.}}{{end}}`
+	assert.True(t, webHookEventToNotificationText(hook, event) == "*sample_key*: changed_status")
+
+	// falls back to the default text when the template fails to execute
+	event = getSampleWebHookEvent(WEBHOOK_EVENT_APP_NEW)
+	assert.True(t, webHookEventToNotificationText(hook, event) == "App *sample_app* is just created by sample_user")
+
+	hook.Template = "{{.User.Name"
+	assert.True(t, verifyWebHookData(hook) != nil, "must reject bad template")
+	hook.Template = "{{.User.Name}}"
+	hook.Target = models.WEBHOOK_TARGET_GENERIC
+	assert.True(t, verifyWebHookData(hook) != nil)
+
+	for _, event := range webHookEvents {
+		assert.True(t, getSampleWebHookEvent(event) != nil, "sample event is missing: "+event)
+	}
+	assert.True(t, getSampleWebHookEvent("unknown") == nil)
+}
+
+// fake smtp server which accepts all mails and sends their data to mailCh
+func startFakeSMTPServer(t *testing.T, mailCh chan string) net.Listener {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.True(t, err == nil)
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				fmt.Fprint(conn, "220 localhost fake smtp\r\n")
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+					case strings.HasPrefix(cmd, "DATA"):
+						fmt.Fprint(conn, "354 go ahead\r\n")
+						var data []string
+						for {
+							line, err := r.ReadString('\n')
+							if err != nil || line == ".\r\n" {
+								break
+							}
+							data = append(data, line)
+						}
+						mailCh <- strings.Join(data, "")
+						fmt.Fprint(conn, "250 ok\r\n")
+					case strings.HasPrefix(cmd, "QUIT"):
+						fmt.Fprint(conn, "221 bye\r\n")
+						return
+					default:
+						fmt.Fprint(conn, "250 ok\r\n")
+					}
+				}
+			}(conn)
+		}
+	}()
+
+	return l
+}
+
+func TestEmailWebHook(t *testing.T) {
+	err := _clearModelData()
+	assert.True(t, err == nil, "must correctly clear data")
+	loadAllData()
+	initNodeData()
+
+	mailCh := make(chan string, 4)
+	l := startFakeSMTPServer(t, mailCh)
+	defer l.Close()
+
+	oldSMTPEnable, oldSMTPAddr := conf.SMTPEnable, conf.SMTPAddr
+	conf.SMTPEnable, conf.SMTPAddr = true, l.Addr().String()
+	defer func() { conf.SMTPEnable, conf.SMTPAddr = oldSMTPEnable, oldSMTPAddr }()
+
+	hook := &models.WebHook{
+		Key:        utils.GenerateKey(),
+		Scope:      models.WEBHOOK_SCOPE_GLOBAL,
+		Target:     models.WEBHOOK_TARGET_EMAIL,
+		Status:     models.WEBHOOK_STATUS_ACTIVE,
+		Recipients: []string{"ops@example.com", "dev@example.com"},
+	}
+	assert.True(t, verifyWebHookData(hook) == nil)
+	hook, err = updateWebHook(hook, nil)
+	assert.True(t, err == nil)
+
+	var deliveries []*models.WebHookDelivery
+	for _, event := range []string{WEBHOOK_EVENT_APP_NEW, WEBHOOK_EVENT_USER_NEW} {
+		payload, _ := json.Marshal(getSampleWebHookEvent(event))
+		delivery, err := queueWebHookDelivery(hook, event, string(payload))
+		assert.True(t, err == nil)
+		assert.True(t, delivery.NextRetryUTC == delivery.CreatedUTC+conf.SMTPDigestWindow, "email must wait for digest")
+		deliveries = append(deliveries, delivery)
+	}
+
+	now := utils.GetNowSecond()
+	backoffDelivery := &models.WebHookDelivery{
+		Key:          utils.GenerateKey(),
+		HookKey:      hook.Key,
+		Event:        WEBHOOK_EVENT_APP_NEW,
+		Status:       models.WEBHOOK_DELIVERY_STATUS_PENDING,
+		Attempts:     1,
+		NextRetryUTC: now + WEBHOOK_DELIVERY_RETRY_BASE,
+		CreatedUTC:   now,
+	}
+	assert.True(t, models.InsertRow(nil, backoffDelivery) == nil)
+
+	digest, _ := models.GetPendingWebHookDeliveriesOfHook(nil, hook.Key, now, now-conf.SMTPDigestWindow, 1)
+	assert.True(t, len(digest) == 1, "digest size must be limited")
+	digest, _ = models.GetPendingWebHookDeliveriesOfHook(nil, hook.Key, now, now-conf.SMTPDigestWindow, WEBHOOK_DELIVERY_BATCH_SIZE)
+	assert.True(t, len(digest) == 2, "deliveries in retry backoff must not be in digest")
+	assert.True(t, deliverWebHookDigest(hook, digest) == nil)
+
+	mail := <-mailCh
+	assert.True(t, strings.Contains(mail, "Subject: Instafig digest: 2 notifications"))
+	assert.True(t, strings.Contains(mail, "To: ops@example.com, dev@example.com"))
+	assert.True(t, strings.Contains(mail, "App [sample_app] is just created by sample_user"))
+	assert.True(t, strings.Contains(mail, "User [sample_target_user] is just created by sample_user"))
+
+	for _, delivery := range deliveries {
+		delivery, _ = models.GetWebHookDeliveryByKey(nil, delivery.Key)
+		assert.True(t, delivery.Status == models.WEBHOOK_DELIVERY_STATUS_SUCCESS)
+	}
+	backoffDelivery, _ = models.GetWebHookDeliveryByKey(nil, backoffDelivery.Key)
+	assert.True(t, backoffDelivery.Attempts == 1, "deliveries not tried must not be counted")
+
+	hook.Recipients = []string{"not a mail address"}
+	assert.True(t, verifyWebHookData(hook) != nil)
+	hook.Recipients = nil
+	assert.True(t, verifyWebHookData(hook) != nil)
+
+	_clearModelData()
+}
+
+func TestUpdateWebHookConflict(t *testing.T) {
+	err := _clearModelData()
+	assert.True(t, err == nil, "must correctly clear data")
+	loadAllData()
+	initNodeData()
+
+	hook, err := updateWebHook(&models.WebHook{
+		Key:          utils.GenerateKey(),
+		Scope:        models.WEBHOOK_SCOPE_GLOBAL,
+		Target:       models.WEBHOOK_TARGET_GENERIC,
+		URL:          "http://127.0.0.1/hook",
+		Status:       models.WEBHOOK_STATUS_ACTIVE,
+		LastUpdateId: utils.GenerateKey(),
+	}, nil)
+	assert.True(t, err == nil)
+
+	data := map[string]interface{}{"key": hook.Key, "scope": hook.Scope, "target": hook.Target, "url": "http://127.0.0.1/hook2", "status": hook.Status}
+	res := _callOpHandler(UpdateWebHook, "PUT", "", data)
+	assert.True(t, res["code"] == "bad_request", "webHook update without last_update_id must be rejected")
+	data["last_update_id"] = "stale"
+	res = _callOpHandler(UpdateWebHook, "PUT", "", data)
+	assert.True(t, res["code"] == "update_conflict", "webHook update with stale last_update_id must be rejected")
+	data["last_update_id"] = hook.LastUpdateId
+	res = _callOpHandler(UpdateWebHook, "PUT", "", data)
+	assert.True(t, res["status"] == true && memConfGlobalWebHooks[0].URL == "http://127.0.0.1/hook2")
+
+	_clearModelData()
 }